@@ -2,17 +2,24 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"time"
+	_ "time/tzdata" // team timezones must resolve in minimal images
 
 	"EpicScoreBot/internal/ai"
+	"EpicScoreBot/internal/api"
+	"EpicScoreBot/internal/audit"
 	"EpicScoreBot/internal/config"
 	"EpicScoreBot/internal/graceful"
+	"EpicScoreBot/internal/i18n"
 	"EpicScoreBot/internal/repositories"
 	"EpicScoreBot/internal/scoring"
 	"EpicScoreBot/internal/telegram"
 	"EpicScoreBot/internal/utils/logger/handlers/slogpretty"
+	"EpicScoreBot/internal/utils/logger/sl"
 )
 
 const (
@@ -23,6 +30,10 @@ const (
 
 var Version = "0.1"
 
+// migrateCheck is parsed together with -config by config.MustLoad.
+var migrateCheck = flag.Bool("migrate-check", false,
+	"print pending database migrations and exit without applying them")
+
 func main() {
 	cfg := config.MustLoad()
 
@@ -34,8 +45,27 @@ func main() {
 		slog.String("version", Version),
 	)
 
-	repositoryService := repositories.New(log, cfg)
-	scoringService := scoring.New(log, repositoryService)
+	if *migrateCheck {
+		pending, err := repositories.PendingMigrations(log, cfg)
+		if err != nil {
+			log.Error("failed to check migrations", sl.Err(err))
+			os.Exit(1)
+		}
+		if len(pending) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		for _, version := range pending {
+			fmt.Println(version)
+		}
+		return
+	}
+
+	repositoryService, err := repositories.New(log, cfg)
+	if err != nil {
+		log.Error("failed to initialize database", sl.Err(err))
+		os.Exit(1)
+	}
+	scoringService := scoring.New(log, cfg, repositoryService)
 
 	// ai.New may return nil when AI is disabled. We must pass a nil interface
 	// (not a typed-nil pointer) so that telegram's epicBot.ai == nil check works.
@@ -44,24 +74,53 @@ func main() {
 		aiClient = c
 	}
 
-	tgBot := telegram.New(log, cfg, repositoryService, scoringService, aiClient)
+	auditRecorder := audit.New(log, repositoryService)
+
+	localizer, err := i18n.New(cfg.BotConfig.DefaultLanguage)
+	if err != nil {
+		log.Error("failed to load message catalogs", sl.Err(err))
+		os.Exit(1)
+	}
+
+	tgBot := telegram.New(log, cfg, repositoryService, scoringService, aiClient, auditRecorder, localizer, Version)
+	scoringService.SetNotifier(tgBot)
+
+	watcherCtx, stopWatcher := context.WithCancel(context.Background())
+
+	shutdownOps := map[string]graceful.Operation{
+		"Repository service": func(ctx context.Context) error {
+			return repositoryService.Shutdown(ctx)
+		},
+		"Telegram bot": func(ctx context.Context) error {
+			return tgBot.Shutdown(ctx)
+		},
+		"Scoring deadline watcher": func(ctx context.Context) error {
+			stopWatcher()
+			return nil
+		},
+	}
+
+	// api.New returns nil when the JSON API is disabled.
+	apiServer := api.New(log, cfg, repositoryService)
+	if apiServer != nil {
+		shutdownOps["JSON API"] = func(ctx context.Context) error {
+			return apiServer.Shutdown(ctx)
+		}
+	}
 
 	maxSecond := 15 * time.Second
 	waitShutdown := graceful.GracefulShutdown(
 		context.Background(),
 		maxSecond,
-		map[string]graceful.Operation{
-			"Repository service": func(ctx context.Context) error {
-				return repositoryService.Shutdown(ctx)
-			},
-			"Telegram bot": func(ctx context.Context) error {
-				return tgBot.Shutdown(ctx)
-			},
-		},
+		shutdownOps,
 		log,
 	)
 
 	go tgBot.Start(30)
+	go scoringService.RunDeadlineWatcher(watcherCtx)
+	if apiServer != nil {
+		go apiServer.Start()
+	}
 
 	<-waitShutdown
 }
@@ -351,6 +351,7 @@ func executeTool(ctx context.Context, repo Repository, name, argsJSON string) (s
 		type riskRow struct {
 			Description   string   `json:"description"`
 			Status        string   `json:"status"`
+			Importance    string   `json:"importance"`
 			WeightedScore *float64 `json:"weighted_score,omitempty"`
 			Coefficient   *float64 `json:"risk_coefficient,omitempty"`
 		}
@@ -359,10 +360,11 @@ func executeTool(ctx context.Context, repo Repository, name, argsJSON string) (s
 			row := riskRow{
 				Description:   r.Description,
 				Status:        string(r.Status),
+				Importance:    string(r.Importance),
 				WeightedScore: r.WeightedScore,
 			}
 			if r.WeightedScore != nil {
-				c := scoring.RiskCoefficient(*r.WeightedScore)
+				c := scoring.EffectiveRiskCoefficient(*r.WeightedScore, r.Importance)
 				row.Coefficient = &c
 			}
 			rows = append(rows, row)
@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/scoring"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/google/uuid"
+)
+
+// EpicJSON is the API representation of an epic with its results.
+type EpicJSON struct {
+	ID              uuid.UUID       `json:"id"`
+	Number          string          `json:"number"`
+	Name            string          `json:"name"`
+	Description     string          `json:"description"`
+	TeamID          uuid.UUID       `json:"team_id"`
+	Status          domain.Status   `json:"status"`
+	ScoringDeadline *time.Time      `json:"scoring_deadline"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	RoleScores      []RoleScoreJSON `json:"role_scores"`
+	Risks           []RiskJSON      `json:"risks"`
+	FinalScore      *float64        `json:"final_score"` // null until scored
+}
+
+// RoleScoreJSON is the weighted average effort score of one role.
+type RoleScoreJSON struct {
+	RoleID      uuid.UUID `json:"role_id"`
+	RoleName    string    `json:"role_name"`
+	WeightedAvg float64   `json:"weighted_avg"`
+}
+
+// RiskJSON is a risk with its score and the coefficient it applies to the
+// final score.
+type RiskJSON struct {
+	ID            uuid.UUID         `json:"id"`
+	Description   string            `json:"description"`
+	Status        domain.Status     `json:"status"`
+	Importance    domain.Importance `json:"importance"`
+	WeightedScore *float64          `json:"weighted_score"` // null until scored
+	Coefficient   *float64          `json:"coefficient"`    // null until scored
+}
+
+// handleEpic serves GET /api/epics/{id}.
+func (s *Server) handleEpic(w http.ResponseWriter, r *http.Request) {
+	op := "api.handleEpic"
+	log := s.log.With(slog.String("op", op))
+
+	epicID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid epic id")
+		return
+	}
+	roleNames, err := s.roleNames(r.Context())
+	if err != nil {
+		log.Error("error getting roles", sl.Err(err))
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	detail, err := s.repo.GetEpicWithRisks(r.Context(), epicID)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "epic not found")
+			return
+		}
+		log.Error("error getting epic", sl.Err(err))
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, epicJSON(detail, roleNames))
+}
+
+// handleTeamEpics serves GET /api/teams/{id}/epics.
+func (s *Server) handleTeamEpics(w http.ResponseWriter, r *http.Request) {
+	op := "api.handleTeamEpics"
+	log := s.log.With(slog.String("op", op))
+
+	teamID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid team id")
+		return
+	}
+	if _, err := s.repo.GetTeamByID(r.Context(), teamID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "team not found")
+			return
+		}
+		log.Error("error getting team", sl.Err(err))
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	roleNames, err := s.roleNames(r.Context())
+	if err != nil {
+		log.Error("error getting roles", sl.Err(err))
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	epics, err := s.repo.GetEpicsByTeamID(r.Context(), teamID)
+	if err != nil {
+		log.Error("error getting team epics", sl.Err(err))
+		writeError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	result := make([]EpicJSON, 0, len(epics))
+	for _, e := range epics {
+		detail, err := s.repo.GetEpicWithRisks(r.Context(), e.ID)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				// Deleted while the list was being built.
+				continue
+			}
+			log.Error("error getting epic", slog.String("epic_id", e.ID.String()), sl.Err(err))
+			writeError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+		result = append(result, epicJSON(detail, roleNames))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// roleNames maps role IDs to names for the role scores.
+func (s *Server) roleNames(ctx context.Context) (map[uuid.UUID]string, error) {
+	roles, err := s.repo.GetAllRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[uuid.UUID]string, len(roles))
+	for _, r := range roles {
+		names[r.ID] = r.Name
+	}
+	return names, nil
+}
+
+// epicJSON converts an epic with its results to the API representation.
+// Coefficients are only given for scored risks, as they are the ones
+// applied to the final score.
+func epicJSON(d *domain.EpicDetail, roleNames map[uuid.UUID]string) EpicJSON {
+	e := EpicJSON{
+		ID:              d.ID,
+		Number:          d.Number,
+		Name:            d.Name,
+		Description:     d.Description,
+		TeamID:          d.TeamID,
+		Status:          d.Status,
+		ScoringDeadline: d.ScoringDeadline,
+		CreatedAt:       d.CreatedAt,
+		UpdatedAt:       d.UpdatedAt,
+		RoleScores:      make([]RoleScoreJSON, 0, len(d.RoleScores)),
+		Risks:           make([]RiskJSON, 0, len(d.Risks)),
+		FinalScore:      d.FinalScore,
+	}
+	for _, rs := range d.RoleScores {
+		e.RoleScores = append(e.RoleScores, RoleScoreJSON{
+			RoleID:      rs.RoleID,
+			RoleName:    roleNames[rs.RoleID],
+			WeightedAvg: rs.WeightedAvg,
+		})
+	}
+	for _, r := range d.Risks {
+		risk := RiskJSON{
+			ID:            r.ID,
+			Description:   r.Description,
+			Status:        r.Status,
+			Importance:    r.Importance,
+			WeightedScore: r.WeightedScore,
+		}
+		if r.Status == domain.StatusScored && r.WeightedScore != nil {
+			// Coefficients have two decimals; drop float noise.
+			c := math.Round(scoring.EffectiveRiskCoefficient(*r.WeightedScore, r.Importance)*100) / 100
+			risk.Coefficient = &c
+		}
+		e.Risks = append(e.Risks, risk)
+	}
+	return e
+}
+
+// writeJSON writes v with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes {"error": message} with the given status code.
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
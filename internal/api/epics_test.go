@@ -0,0 +1,201 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+
+	"github.com/google/uuid"
+)
+
+const testToken = "secret"
+
+// fakeRepo serves one team with a scored and a pending epic.
+type fakeRepo struct {
+	team  domain.Team
+	roles []domain.Role
+	epics map[uuid.UUID]*domain.EpicDetail
+}
+
+func newFakeRepo() *fakeRepo {
+	r := &fakeRepo{
+		team:  domain.Team{ID: uuid.New(), Name: "Platform"},
+		roles: []domain.Role{{ID: uuid.New(), Name: "Backend"}},
+		epics: make(map[uuid.UUID]*domain.EpicDetail),
+	}
+	final, riskScore := 23.4, 6.5
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	scored := &domain.EpicDetail{
+		Epic: domain.Epic{
+			ID: uuid.New(), Number: "EP-1", Name: "Login", TeamID: r.team.ID,
+			Status: domain.StatusScored, FinalScore: &final, CreatedAt: created, UpdatedAt: created,
+		},
+		RoleScores: []domain.EpicRoleScore{{RoleID: r.roles[0].ID, WeightedAvg: 18}},
+		Risks: []domain.Risk{
+			{ID: uuid.New(), Description: "API", Status: domain.StatusScored,
+				Importance: domain.ImportanceHigh, WeightedScore: &riskScore},
+			{ID: uuid.New(), Description: "UI", Status: domain.StatusSkipped, Importance: domain.ImportanceLow},
+		},
+	}
+	pending := &domain.EpicDetail{
+		Epic: domain.Epic{ID: uuid.New(), Number: "EP-2", Name: "Logout", TeamID: r.team.ID,
+			Status: domain.StatusNew, CreatedAt: created, UpdatedAt: created},
+	}
+	r.epics[scored.ID] = scored
+	r.epics[pending.ID] = pending
+	return r
+}
+
+func (r *fakeRepo) GetEpicWithRisks(_ context.Context, epicID uuid.UUID) (*domain.EpicDetail, error) {
+	d, ok := r.epics[epicID]
+	if !ok {
+		return nil, repositories.ErrNotFound
+	}
+	return d, nil
+}
+
+func (r *fakeRepo) GetEpicsByTeamID(_ context.Context, teamID uuid.UUID) ([]domain.Epic, error) {
+	var epics []domain.Epic
+	for _, d := range r.epics {
+		if d.TeamID == teamID {
+			epics = append(epics, d.Epic)
+		}
+	}
+	return epics, nil
+}
+
+func (r *fakeRepo) GetTeamByID(_ context.Context, teamID uuid.UUID) (*domain.Team, error) {
+	if teamID != r.team.ID {
+		return nil, repositories.ErrNotFound
+	}
+	return &r.team, nil
+}
+
+func (r *fakeRepo) GetAllRoles(context.Context) ([]domain.Role, error) {
+	return r.roles, nil
+}
+
+// serve sends a GET request with the given Authorization header.
+func serve(t *testing.T, repo Repository, path, auth string) *httptest.ResponseRecorder {
+	t.Helper()
+	s := &Server{log: slog.New(slog.NewTextHandler(io.Discard, nil)), repo: repo}
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	rec := httptest.NewRecorder()
+	s.routes(testToken).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandleEpic(t *testing.T) {
+	repo := newFakeRepo()
+	var scored *domain.EpicDetail
+	for _, d := range repo.epics {
+		if d.Status == domain.StatusScored {
+			scored = d
+		}
+	}
+
+	rec := serve(t, repo, "/api/epics/"+scored.ID.String(), "Bearer "+testToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	for _, key := range []string{
+		"id", "number", "name", "description", "team_id", "status", "scoring_deadline",
+		"created_at", "updated_at", "role_scores", "risks", "final_score",
+	} {
+		if _, ok := raw[key]; !ok {
+			t.Errorf("response has no %q field", key)
+		}
+	}
+
+	var got EpicJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.Number != "EP-1" || got.FinalScore == nil || *got.FinalScore != 23.4 {
+		t.Errorf("epic = %s with final score %v, want EP-1 with 23.4", got.Number, got.FinalScore)
+	}
+	if len(got.RoleScores) != 1 || got.RoleScores[0].RoleName != "Backend" || got.RoleScores[0].WeightedAvg != 18 {
+		t.Errorf("role scores = %+v, want Backend 18", got.RoleScores)
+	}
+	if len(got.Risks) != 2 {
+		t.Fatalf("risks = %+v, want 2", got.Risks)
+	}
+	if got.Risks[0].Coefficient == nil {
+		t.Error("scored risk has no coefficient")
+	}
+	if got.Risks[1].Coefficient != nil || got.Risks[1].WeightedScore != nil {
+		t.Errorf("skipped risk = %+v, want no score or coefficient", got.Risks[1])
+	}
+}
+
+func TestHandleTeamEpics(t *testing.T) {
+	repo := newFakeRepo()
+
+	rec := serve(t, repo, "/api/teams/"+repo.team.ID.String()+"/epics", "Bearer "+testToken)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+	var got []EpicJSON
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d epics, want 2", len(got))
+	}
+	for _, e := range got {
+		if e.Status == domain.StatusNew && (e.FinalScore != nil || e.RoleScores == nil || e.Risks == nil) {
+			t.Errorf("pending epic = %+v, want null final score and empty lists", e)
+		}
+	}
+}
+
+func TestAPIErrors(t *testing.T) {
+	repo := newFakeRepo()
+	tests := []struct {
+		name string
+		path string
+		auth string
+		want int
+	}{
+		{"unknown epic", "/api/epics/" + uuid.NewString(), "Bearer " + testToken, http.StatusNotFound},
+		{"unknown team", "/api/teams/" + uuid.NewString() + "/epics", "Bearer " + testToken, http.StatusNotFound},
+		{"malformed id", "/api/epics/42", "Bearer " + testToken, http.StatusBadRequest},
+		{"no token", "/api/teams/" + repo.team.ID.String() + "/epics", "", http.StatusUnauthorized},
+		{"wrong token", "/api/epics/" + uuid.NewString(), "Bearer nope", http.StatusUnauthorized},
+		{"not a bearer token", "/api/epics/" + uuid.NewString(), testToken, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, repo, tt.path, tt.auth)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] == "" {
+				t.Errorf("body = %s, want an error message", rec.Body)
+			}
+			if tt.want == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") != "Bearer" {
+				t.Error("401 without WWW-Authenticate: Bearer")
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package api
+
+import (
+	"context"
+
+	"EpicScoreBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the data-access contract required by the HTTP API.
+type Repository interface {
+	GetEpicWithRisks(ctx context.Context, epicID uuid.UUID) (*domain.EpicDetail, error)
+	GetEpicsByTeamID(ctx context.Context, teamID uuid.UUID) ([]domain.Epic, error)
+	GetTeamByID(ctx context.Context, teamID uuid.UUID) (*domain.Team, error)
+	GetAllRoles(ctx context.Context) ([]domain.Role, error)
+}
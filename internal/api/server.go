@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/utils/logger/sl"
+)
+
+// Server exposes scoring results as JSON for integrations.
+type Server struct {
+	log  *slog.Logger
+	repo Repository
+	srv  *http.Server
+}
+
+// New creates the API server. Returns nil when APIToken is empty (API
+// disabled).
+func New(logger *slog.Logger, cfg *config.Config, repo Repository) *Server {
+	op := "api.New()"
+	log := logger.With(slog.String("op", op))
+
+	if cfg.HttpServer.APIToken == "" {
+		log.Warn("HTTP API token not set — JSON API disabled")
+		return nil
+	}
+
+	s := &Server{
+		log:  logger.With(slog.String("component", "api")),
+		repo: repo,
+	}
+	addr := net.JoinHostPort(cfg.HttpServer.Address, cfg.HttpServer.Port)
+	s.srv = &http.Server{
+		Addr:         addr,
+		Handler:      s.routes(cfg.HttpServer.APIToken),
+		ReadTimeout:  cfg.HttpServer.Timeout,
+		WriteTimeout: cfg.HttpServer.Timeout,
+	}
+
+	log.Info("JSON API created", slog.String("address", addr))
+	return s
+}
+
+// routes registers the API endpoints behind the bearer token check.
+func (s *Server) routes(token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/epics/{id}", s.handleEpic)
+	mux.HandleFunc("GET /api/teams/{id}/epics", s.handleTeamEpics)
+	return requireToken(token, mux)
+}
+
+// Start serves requests until Shutdown is called.
+func (s *Server) Start() {
+	op := "api.Start()"
+	log := s.log.With(slog.String("op", op))
+
+	log.Info("starting JSON API", slog.String("address", s.srv.Addr))
+	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Error("JSON API stopped", sl.Err(err))
+	}
+}
+
+// Shutdown stops accepting requests and waits for the active ones.
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.srv.Shutdown(ctx)
+}
+
+// requireToken rejects requests without "Authorization: Bearer <token>".
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
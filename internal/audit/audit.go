@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+
+	"EpicScoreBot/internal/utils/logger/sl"
+)
+
+// Actions recorded in the audit log.
+const (
+	ActionTeamCreated      = "team_created"
+	ActionTeamsMerged      = "teams_merged"
+	ActionTeamDeleted      = "team_deleted"
+	ActionTeamUpdated      = "team_updated"
+	ActionTeamArchived     = "team_archived"
+	ActionTeamUnarchived   = "team_unarchived"
+	ActionUserAdded        = "user_added"
+	ActionUserRenamed      = "user_renamed"
+	ActionUserDeleted      = "user_deleted"
+	ActionWeightChanged    = "weight_changed"
+	ActionRoleAssigned     = "role_assigned"
+	ActionRoleUnassigned   = "role_unassigned"
+	ActionRoleChanged      = "role_changed"
+	ActionRoleCreated      = "role_created"
+	ActionRoleDeleted      = "role_deleted"
+	ActionTeamAssigned     = "team_assigned"
+	ActionTeamUnassigned   = "team_unassigned"
+	ActionEpicCreated      = "epic_created"
+	ActionEpicDeleted      = "epic_deleted"
+	ActionEpicsImported    = "epics_imported"
+	ActionRiskCreated      = "risk_created"
+	ActionRiskDeleted      = "risk_deleted"
+	ActionEpicRolesChanged = "epic_roles_changed"
+	ActionScoringStarted   = "scoring_started"
+	ActionScoringClosed    = "scoring_closed"
+	ActionAdminAdded       = "admin_added"
+	ActionAdminRemoved     = "admin_removed"
+	ActionUndone           = "undone"
+	ActionTimezoneChanged  = "timezone_changed"
+	ActionScoresRecomputed = "scores_recomputed"
+)
+
+// Repository defines the data-access contract required by the audit log.
+type Repository interface {
+	CreateAuditEntry(ctx context.Context, actor, action, details string) error
+}
+
+// Recorder writes administrative actions to the audit log.
+type Recorder struct {
+	repo Repository
+	log  *slog.Logger
+}
+
+// New creates a new audit recorder.
+func New(logger *slog.Logger, repo Repository) *Recorder {
+	return &Recorder{
+		repo: repo,
+		log:  logger.With(slog.String("component", "audit")),
+	}
+}
+
+// Record stores an audit entry. A failure is logged and never propagated,
+// so auditing cannot block the action being recorded.
+func (r *Recorder) Record(ctx context.Context, actor, action, details string) {
+	if err := r.repo.CreateAuditEntry(ctx, actor, action, details); err != nil {
+		r.log.Error("failed to write audit entry",
+			slog.String("actor", actor),
+			slog.String("action", action),
+			slog.String("details", details),
+			sl.Err(err))
+	}
+}
@@ -71,8 +71,22 @@ func fetchConfigPath() string {
 	return res
 }
 
+// Write saves the config back to its file. Secrets are written only as
+// they already appear in the file, so a token or password supplied through
+// the environment never ends up on disk.
 func (cfg *Config) Write() error {
-	bufWrite, err := yaml.Marshal(cfg)
+	out := *cfg
+	var onDisk Config
+	if buf, err := os.ReadFile(cfg.configPath); err == nil {
+		// An unreadable file leaves the secrets empty, which is the safe side.
+		_ = yaml.Unmarshal(buf, &onDisk)
+	}
+	dst, src := out.secrets(), onDisk.secrets()
+	for i := range dst {
+		*dst[i] = *src[i]
+	}
+
+	bufWrite, err := yaml.Marshal(&out)
 	if err != nil {
 		return fmt.Errorf("error config.Write() marshall: %w", err)
 	}
@@ -83,3 +97,14 @@ func (cfg *Config) Write() error {
 	}
 	return nil
 }
+
+// secrets returns the fields that Write must not copy from the environment
+// into the file, in a fixed order.
+func (cfg *Config) secrets() []*string {
+	return []*string{
+		&cfg.BotConfig.TgbotApiToken,
+		&cfg.BotConfig.AI.AIApiToken,
+		&cfg.DBConfig.Password,
+		&cfg.HttpServer.APIToken,
+	}
+}
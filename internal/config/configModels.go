@@ -1,21 +1,55 @@
 package config
 
-import "time"
+import (
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
 
 type Config struct {
-	Env            string           `yaml:"env" env-default:"local"`
-	HttpServer     HttpServerConfig `yaml:"httpServer"`
-	DBConfig       DBConfig         `yaml:"db" env-required:"true"`
-	BotConfig      BotConfig        `yaml:"bot" env-required:"true"`
-	ConfigFilePath string           `yaml:"configFilePath" env:"CONFIG_FILEPATH" env-default:""`
-	ConfigFileName string           `yaml:"configFileName" env:"CONFIG_FILENAME" env-default:""`
+	Env        string           `yaml:"env" env-default:"local"`
+	HttpServer HttpServerConfig `yaml:"httpServer"`
+	DBConfig   DBConfig         `yaml:"db" env-required:"true"`
+	BotConfig  BotConfig        `yaml:"bot" env-required:"true"`
+	Scoring    ScoringConfig    `yaml:"scoring"`
+	Limits     LimitsConfig     `yaml:"limits"`
+	// DefaultRoles are created on startup when missing, so the bot always
+	// has roles to assign. Existing roles are left untouched.
+	DefaultRoles   []RoleConfig `yaml:"defaultRoles"`
+	ConfigFilePath string       `yaml:"configFilePath" env:"CONFIG_FILEPATH" env-default:""`
+	ConfigFileName string       `yaml:"configFileName" env:"CONFIG_FILENAME" env-default:""`
 	configPath     string
 }
 
 type HttpServerConfig struct {
 	Address string        `yaml:"address" env-default:"0.0.0.0"`
 	Port    string        `yaml:"port" env-default:"8080"`
-	Timeout time.Duration `yaml:"timeout" env-default:"5"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// APIToken is the bearer token required by the JSON API; empty
+	// disables the API and the server is not started.
+	APIToken string `yaml:"apiToken" env:"HTTP_API_TOKEN" env-default:""`
+}
+
+// LimitsConfig caps the length, in characters, of text users enter when
+// creating epics and risks. A non-positive limit allows any length.
+type LimitsConfig struct {
+	// EpicName caps epic names.
+	EpicName int `yaml:"epicName" env:"LIMIT_EPIC_NAME" env-default:"200"`
+	// Description caps epic and risk descriptions.
+	Description int `yaml:"description" env:"LIMIT_DESCRIPTION" env-default:"2000"`
+}
+
+// ExceedsLimit reports whether s is longer than limit characters. A
+// non-positive limit allows any length.
+func ExceedsLimit(s string, limit int) bool {
+	return limit > 0 && utf8.RuneCountInString(s) > limit
+}
+
+// RoleConfig is a role seeded on startup.
+type RoleConfig struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
 }
 
 type DBConfig struct {
@@ -25,6 +59,25 @@ type DBConfig struct {
 	User     string `yaml:"user" env:"DB_USER" env-default:"user"`
 	Password string `yaml:"password" env:"DB_PASSWORD" env-default:"password"`
 	Schema   string `yaml:"schema" env:"DB_SCHEMA" env-default:"epic_score"`
+	// SSLMode is the libpq sslmode: disable, allow, prefer, require,
+	// verify-ca or verify-full.
+	SSLMode string `yaml:"sslMode" env:"DB_SSLMODE" env-default:"disable"`
+	// SSLRootCert is an optional path to the CA certificate used by the
+	// verify-ca and verify-full modes.
+	SSLRootCert string `yaml:"sslRootCert" env:"DB_SSLROOTCERT" env-default:""`
+	// MaxOpenConns caps open connections to Postgres; 0 means unlimited.
+	MaxOpenConns int `yaml:"maxOpenConns" env:"DB_MAX_OPEN_CONNS" env-default:"10"`
+	// MaxIdleConns caps connections kept idle in the pool.
+	MaxIdleConns int `yaml:"maxIdleConns" env:"DB_MAX_IDLE_CONNS" env-default:"5"`
+	// ConnMaxLifetime closes connections after this age; 0 keeps them forever.
+	ConnMaxLifetime time.Duration `yaml:"connMaxLifetime" env:"DB_CONN_MAX_LIFETIME" env-default:"30m"`
+	// MaxRetries is how many times writes are retried on transient errors.
+	MaxRetries int `yaml:"maxRetries" env:"DB_MAX_RETRIES" env-default:"3"`
+	// RetryDelay is the delay before the first retry; it doubles each time.
+	RetryDelay time.Duration `yaml:"retryDelay" env:"DB_RETRY_DELAY" env-default:"200ms"`
+	// QueryTimeout bounds each repository call, retries included; 0 turns
+	// the limit off.
+	QueryTimeout time.Duration `yaml:"queryTimeout" env:"DB_QUERY_TIMEOUT" env-default:"10s"`
 }
 
 type BotConfig struct {
@@ -32,6 +85,61 @@ type BotConfig struct {
 	SuperAdmins   []string `yaml:"superadmins" env-default:"superadmin"`
 	TgbotApiToken string   `yaml:"tgbot_apitoken" env:"TGBOT_APITOKEN" env-required:"true"`
 	AI            AIConfig `yaml:"AI"`
+	// MaxListedEpics caps how many epics a single picker may show before
+	// the user is asked to narrow the selection.
+	MaxListedEpics int `yaml:"maxListedEpics" env:"BOT_MAX_LISTED_EPICS" env-default:"50"`
+	// AnnounceChatID is the chat where automatic events such as epics
+	// closed by deadline are posted; 0 disables announcements.
+	AnnounceChatID int64 `yaml:"announceChatId" env:"BOT_ANNOUNCE_CHAT_ID" env-default:"0"`
+	// AnnounceThreadID is an optional forum topic within AnnounceChatID.
+	AnnounceThreadID int `yaml:"announceThreadId" env:"BOT_ANNOUNCE_THREAD_ID" env-default:"0"`
+	// MaxButtonLabel caps the length (in characters) of inline keyboard
+	// button labels; longer labels are truncated with an ellipsis.
+	MaxButtonLabel int `yaml:"maxButtonLabel" env:"BOT_MAX_BUTTON_LABEL" env-default:"60"`
+	// DefaultLanguage is the interface language for chats that did not
+	// pick one with /setlang.
+	DefaultLanguage string `yaml:"defaultLanguage" env:"BOT_DEFAULT_LANGUAGE" env-default:"ru"`
+	// RateLimit is how many messages and button taps per second a user
+	// may send on average; 0 disables the limit. Admins are not limited.
+	RateLimit float64 `yaml:"rateLimit" env:"BOT_RATE_LIMIT" env-default:"2"`
+	// RateLimitBurst is how many events a user may send at once before
+	// RateLimit applies.
+	RateLimitBurst int `yaml:"rateLimitBurst" env:"BOT_RATE_LIMIT_BURST" env-default:"5"`
+	// EpicNumberPattern is an optional regular expression every new epic
+	// number must match in full, e.g. EP-\d+; empty allows any number.
+	EpicNumberPattern string `yaml:"epicNumberPattern" env:"BOT_EPIC_NUMBER_PATTERN" env-default:""`
+	// SessionTTL is how long an unfinished dialog (adding a user, an epic
+	// and so on) is kept without activity.
+	SessionTTL time.Duration `yaml:"sessionTTL" env:"BOT_SESSION_TTL" env-default:"5m"`
+	// HideBaselineRisks omits scored risks in the lowest coefficient band
+	// from /results; reports and exports still list every risk. Chats can
+	// override it with /settings.
+	HideBaselineRisks bool `yaml:"hideBaselineRisks" env:"BOT_HIDE_BASELINE_RISKS" env-default:"false"`
+	// PDFExport enables /exportpdf. Builds with the nopdf tag cannot
+	// export PDF whatever it is set to.
+	PDFExport bool `yaml:"pdfExport" env:"BOT_PDF_EXPORT" env-default:"true"`
+}
+
+// defaultMaxListedEpics is used when MaxListedEpics is not positive.
+const defaultMaxListedEpics = 50
+
+// EpicListLimit returns the effective cap on epics listed at once.
+func (b BotConfig) EpicListLimit() int {
+	if b.MaxListedEpics <= 0 {
+		return defaultMaxListedEpics
+	}
+	return b.MaxListedEpics
+}
+
+// defaultMaxButtonLabel is used when MaxButtonLabel is not positive.
+const defaultMaxButtonLabel = 60
+
+// ButtonLabelLimit returns the effective cap on inline button label length.
+func (b BotConfig) ButtonLabelLimit() int {
+	if b.MaxButtonLabel <= 0 {
+		return defaultMaxButtonLabel
+	}
+	return b.MaxButtonLabel
 }
 
 // AIConfig holds configuration for the OpenRouter AI client.
@@ -46,3 +154,111 @@ type AIConfig struct {
 func (a AIConfig) GetTimeout() time.Duration {
 	return time.Duration(a.Timeout) * time.Second
 }
+
+// ScoringConfig holds tunables for the scoring business logic.
+type ScoringConfig struct {
+	// MinScoringQuorum is the percentage (1–100) of team members that must
+	// submit their scores before an epic or risk is finalized automatically.
+	MinScoringQuorum int `yaml:"minScoringQuorum" env:"SCORING_MIN_QUORUM" env-default:"100"`
+	// DeadlineCheckInterval is how often epics past their scoring
+	// deadline are looked up and closed.
+	DeadlineCheckInterval time.Duration `yaml:"deadlineCheckInterval" env:"SCORING_DEADLINE_CHECK_INTERVAL" env-default:"1m"`
+	// RoundingMode is how the final epic score is rounded: round, ceil,
+	// floor, or none to keep one decimal.
+	RoundingMode string `yaml:"roundingMode" env:"SCORING_ROUNDING_MODE" env-default:"round"`
+	// ZeroWeightMode is what a weighted average does when every scorer
+	// has weight 0: mean falls back to the plain mean of the scores,
+	// reject fails the calculation so an administrator can fix weights.
+	ZeroWeightMode string `yaml:"zeroWeightMode" env:"SCORING_ZERO_WEIGHT_MODE" env-default:"mean"`
+	// MaxEffortScore is the largest effort score a user may submit, e.g.
+	// lower for teams estimating in points than in hours.
+	MaxEffortScore int `yaml:"maxEffortScore" env:"SCORING_MAX_EFFORT_SCORE" env-default:"500"`
+	// RolePrecision is how many decimals role averages are rounded to
+	// before they are stored and added up into the base score, so the
+	// shown averages add up to the base; negative keeps full precision.
+	RolePrecision int `yaml:"rolePrecision" env:"SCORING_ROLE_PRECISION" env-default:"2"`
+	// RiskAggregation is how the coefficients of several risks combine
+	// into the multiplier of the base score: product multiplies them,
+	// max takes the largest, sumcapped adds up their increases over 1
+	// up to a cap.
+	RiskAggregation string `yaml:"riskAggregation" env:"SCORING_RISK_AGGREGATION" env-default:"product"`
+}
+
+// defaultMaxEffortScore is used when MaxEffortScore is not positive.
+const defaultMaxEffortScore = 500
+
+// EffortMax returns the effective largest effort score.
+func (s ScoringConfig) EffortMax() int {
+	if s.MaxEffortScore <= 0 {
+		return defaultMaxEffortScore
+	}
+	return s.MaxEffortScore
+}
+
+// Rounding modes for the final epic score.
+const (
+	RoundingRound = "round"
+	RoundingCeil  = "ceil"
+	RoundingFloor = "floor"
+	RoundingNone  = "none"
+)
+
+// Zero-weight modes for weighted averages.
+const (
+	ZeroWeightMean   = "mean"
+	ZeroWeightReject = "reject"
+)
+
+// Risk aggregation modes for combining risk coefficients.
+const (
+	RiskAggregationProduct   = "product"
+	RiskAggregationMax       = "max"
+	RiskAggregationSumCapped = "sumcapped"
+)
+
+// RejectZeroWeight reports whether a weighted average over scorers who all
+// have weight 0 is an error. Unknown modes fall back to the plain mean.
+func (s ScoringConfig) RejectZeroWeight() bool {
+	return strings.EqualFold(s.ZeroWeightMode, ZeroWeightReject)
+}
+
+// scoreSnap is the inverse of the precision final scores are snapped to
+// before rounding, so float error such as 100 × 1.10 = 110.00000000000001
+// does not push ceil or floor to the next whole number.
+const scoreSnap = 1e9
+
+// RoundFinalScore applies the configured rounding mode to a final score.
+// Unknown modes fall back to rounding to the nearest integer.
+func (s ScoringConfig) RoundFinalScore(score float64) float64 {
+	score = math.Round(score*scoreSnap) / scoreSnap
+	switch strings.ToLower(s.RoundingMode) {
+	case RoundingCeil:
+		return math.Ceil(score)
+	case RoundingFloor:
+		return math.Floor(score)
+	case RoundingNone:
+		return math.Round(score*10) / 10
+	default:
+		return math.Round(score)
+	}
+}
+
+// RoundRoleAverage rounds a role average, or a sum of them, to
+// RolePrecision decimals.
+func (s ScoringConfig) RoundRoleAverage(avg float64) float64 {
+	if s.RolePrecision < 0 {
+		return avg
+	}
+	p := math.Pow10(s.RolePrecision)
+	return math.Round(avg*p) / p
+}
+
+// RequiredScores returns how many of teamMembers must submit a score
+// to reach the configured quorum. Out-of-range quorums fall back to 100%.
+func (s ScoringConfig) RequiredScores(teamMembers int) int {
+	quorum := s.MinScoringQuorum
+	if quorum <= 0 || quorum > 100 {
+		quorum = 100
+	}
+	return (teamMembers*quorum + 99) / 100
+}
@@ -0,0 +1,114 @@
+package config
+
+import "testing"
+
+func TestRoundFinalScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		base       float64
+		multiplier float64
+		want       float64
+	}{
+		{"round", RoundingRound, 12.4, 1, 12},
+		{"ceil", RoundingCeil, 12.4, 1, 13},
+		{"floor", RoundingFloor, 12.4, 1, 12},
+		{"none keeps one decimal", RoundingNone, 12.4, 1, 12.4},
+		{"round half up", RoundingRound, 12.5, 1, 13},
+		{"none rounds to one decimal", RoundingNone, 12.46, 1, 12.5},
+		{"mode is case-insensitive", "CEIL", 12.4, 1, 13},
+		{"unknown mode rounds", "unknown", 12.4, 1, 12},
+
+		// Multiplied at run time: 100 × 1.10 is 110.00000000000001 and
+		// 0.29 × 100 is 28.999999999999996 in float64.
+		{"ceil of 100 × 1.10", RoundingCeil, 100, 1.10, 110},
+		{"floor of 100 × 1.10", RoundingFloor, 100, 1.10, 110},
+		{"round of 100 × 1.10", RoundingRound, 100, 1.10, 110},
+		{"none of 100 × 1.10", RoundingNone, 100, 1.10, 110},
+		{"floor of 0.29 × 100", RoundingFloor, 0.29, 100, 29},
+		{"ceil of 0.29 × 100", RoundingCeil, 0.29, 100, 29},
+		{"ceil above a whole number", RoundingCeil, 110.01, 1, 111},
+		{"floor below a whole number", RoundingFloor, 109.99, 1, 109},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ScoringConfig{RoundingMode: tt.mode}
+			score := tt.base * tt.multiplier
+			if got := s.RoundFinalScore(score); got != tt.want {
+				t.Errorf("RoundFinalScore(%v) = %v, want %v", score, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequiredScores(t *testing.T) {
+	tests := []struct {
+		name    string
+		quorum  int
+		members int
+		want    int
+	}{
+		{"everyone by default", 0, 5, 5},
+		{"full quorum", 100, 5, 5},
+		{"rounds up", 60, 5, 3},
+		{"rounds up a fraction", 51, 4, 3},
+		{"exact share", 50, 4, 2},
+		{"one percent still needs one", 1, 5, 1},
+		{"quorum above 100 falls back", 150, 4, 4},
+		{"negative quorum falls back", -10, 4, 4},
+		{"no members", 60, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ScoringConfig{MinScoringQuorum: tt.quorum}
+			if got := s.RequiredScores(tt.members); got != tt.want {
+				t.Errorf("RequiredScores(%d) with quorum %d = %d, want %d", tt.members, tt.quorum, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundRoleAverage(t *testing.T) {
+	tests := []struct {
+		name      string
+		precision int
+		avg       float64
+		want      float64
+	}{
+		{"two decimals", 2, 10.784, 10.78},
+		{"half rounds away from zero", 2, 10.785, 10.79},
+		{"whole numbers", 0, 10.5, 11},
+		{"one decimal", 1, 8.04, 8},
+		{"negative keeps the value", -1, 10.784, 10.784},
+		{"already rounded", 2, 13, 13},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ScoringConfig{RolePrecision: tt.precision}
+			if got := s.RoundRoleAverage(tt.avg); got != tt.want {
+				t.Errorf("RoundRoleAverage(%v) with precision %d = %v, want %v", tt.avg, tt.precision, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExceedsLimit(t *testing.T) {
+	tests := []struct {
+		s     string
+		limit int
+		want  bool
+	}{
+		{"Login", 5, false},
+		{"Login", 4, true},
+		{"Эпик", 4, false},
+		{"Эпик!", 4, true},
+		{"", 1, false},
+		{"Login", 0, false},
+		{"Login", -1, false},
+	}
+	for _, tt := range tests {
+		if got := ExceedsLimit(tt.s, tt.limit); got != tt.want {
+			t.Errorf("ExceedsLimit(%q, %d) = %v, want %v", tt.s, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestWriteKeepsEnvironmentSecretsOffDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	initial := "bot:\n  tgbot_apitoken: file-token\n  admins: [alice]\n"
+	if err := os.WriteFile(path, []byte(initial), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TGBOT_APITOKEN", "env-token")
+	t.Setenv("DB_PASSWORD", "env-password")
+	t.Setenv("HTTP_API_TOKEN", "env-api-token")
+	t.Setenv("AI_API_TOKEN", "env-ai-token")
+
+	cfg := MustLoadPath(path)
+	if cfg.BotConfig.TgbotApiToken != "env-token" || cfg.DBConfig.Password != "env-password" {
+		t.Fatalf("loaded secrets %q, %q, want the environment values",
+			cfg.BotConfig.TgbotApiToken, cfg.DBConfig.Password)
+	}
+	cfg.BotConfig.Admins = append(cfg.BotConfig.Admins, "bob")
+	if err := cfg.Write(); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written Config
+	if err := yaml.Unmarshal(buf, &written); err != nil {
+		t.Fatalf("unmarshal written config: %v", err)
+	}
+	secrets := []struct {
+		name, got, want string
+	}{
+		{"bot token", written.BotConfig.TgbotApiToken, "file-token"},
+		{"AI token", written.BotConfig.AI.AIApiToken, ""},
+		{"DB password", written.DBConfig.Password, ""},
+		{"API token", written.HttpServer.APIToken, ""},
+	}
+	for _, s := range secrets {
+		if s.got != s.want {
+			t.Errorf("written %s = %q, want %q", s.name, s.got, s.want)
+		}
+	}
+	if !slices.Equal(written.BotConfig.Admins, []string{"alice", "bob"}) {
+		t.Errorf("written admins = %v, want the updated list", written.BotConfig.Admins)
+	}
+	if cfg.BotConfig.TgbotApiToken != "env-token" {
+		t.Errorf("Write() changed the loaded token to %q", cfg.BotConfig.TgbotApiToken)
+	}
+}
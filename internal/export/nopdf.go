@@ -0,0 +1,9 @@
+//go:build nopdf
+
+package export
+
+// EpicToPDF is not available in this build; it always returns
+// ErrPDFUnavailable.
+func EpicToPDF(EpicReport) ([]byte, error) {
+	return nil, ErrPDFUnavailable
+}
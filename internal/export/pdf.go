@@ -0,0 +1,246 @@
+//go:build !nopdf
+
+package export
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// DejaVu Sans covers Cyrillic, which the core PDF fonts do not. The fonts
+// add about 1.4 MB to the binary; build with -tags nopdf to leave them out.
+var (
+	//go:embed fonts/DejaVuSans.ttf
+	fontRegular []byte
+	//go:embed fonts/DejaVuSans-Bold.ttf
+	fontBold []byte
+)
+
+const (
+	pdfFont       = "DejaVu"
+	pdfMargin     = 15.0 // mm, all sides
+	pdfLineHeight = 5.0  // mm, body and table text
+	// pdfPending replaces pendingMark: the hourglass has no glyph in
+	// the embedded font.
+	pdfPending = "ожидается"
+)
+
+// EpicToPDF renders an epic report as an A4 PDF document. Long text wraps
+// and continues on the next page; tables repeat their header on every page
+// they span.
+func EpicToPDF(r EpicReport) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.AddUTF8FontFromBytes(pdfFont, "", fontRegular)
+	pdf.AddUTF8FontFromBytes(pdfFont, "B", fontBold)
+	pdf.SetTitle(fmt.Sprintf("Эпик #%s", r.Epic.Number), true)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-pdfMargin + 5)
+		pdf.SetFont(pdfFont, "", 8)
+		pdf.CellFormat(0, 4, fmt.Sprintf("Эпик #%s — стр. %d из {nb}", r.Epic.Number, pdf.PageNo()),
+			"", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont(pdfFont, "B", 16)
+	pdf.MultiCell(0, 8, fmt.Sprintf("Эпик #%s «%s»", r.Epic.Number, r.Epic.Name), "", "L", false)
+	pdf.Ln(2)
+
+	pdfHeading(pdf, "Описание")
+	desc := strings.TrimSpace(r.Epic.Description)
+	if desc == "" {
+		desc = "—"
+	}
+	pdfText(pdf, desc)
+	pdf.Ln(2)
+	pdfText(pdf, "Команда: "+r.TeamName)
+	pdfText(pdf, "Статус: "+string(r.Epic.Status))
+	pdfText(pdf, "Создан: "+r.formatTime(r.Epic.CreatedAt))
+	if r.Epic.ScoringDeadline != nil {
+		pdfText(pdf, "Дедлайн оценки: "+r.formatTime(*r.Epic.ScoringDeadline))
+	}
+
+	pdfHeading(pdf, "Участие")
+	pdfText(pdf, fmt.Sprintf("Оценили трудоёмкость: %d из %d", r.EffortScored, r.EffortScorers))
+
+	pdfHeading(pdf, "Оценки по ролям")
+	if len(r.RoleScores) == 0 {
+		pdfText(pdf, pdfPending)
+	} else {
+		roles := pdfTable{
+			widths: []float64{120, 60},
+			header: []string{"Роль", "Средневзвешенная оценка"},
+		}
+		var base float64
+		rows := make([][]string, len(r.RoleScores))
+		for i, rs := range r.RoleScores {
+			rows[i] = []string{rs.RoleName, fmt.Sprintf("%.2f", rs.WeightedAvg)}
+			base += rs.WeightedAvg
+		}
+		roles.draw(pdf, rows)
+		pdf.Ln(2)
+		pdfText(pdf, fmt.Sprintf("Базовая оценка: %.2f", base))
+	}
+
+	pdfHeading(pdf, "Матрица рисков")
+	if len(r.Risks) == 0 {
+		pdfText(pdf, "Рисков нет.")
+	} else {
+		risks := pdfTable{
+			widths: []float64{60, 19, 19, 14, 18, 17, 16, 17},
+			header: []string{"Риск", "Статус", "Важность", "Оценок", "Вероятн.", "Влияние", "Оценка", "Коэфф."},
+		}
+		rows := make([][]string, len(r.Risks))
+		for i, risk := range r.Risks {
+			score, coeff := pdfPending, pdfPending
+			if risk.WeightedScore != nil {
+				score = fmt.Sprintf("%.2f", *risk.WeightedScore)
+			}
+			if risk.Coefficient != nil {
+				coeff = fmt.Sprintf("%.2f", *risk.Coefficient)
+			}
+			rows[i] = []string{
+				risk.Description, string(risk.Status), string(risk.Importance),
+				strconv.Itoa(risk.Assessments),
+				fmt.Sprintf("%.2f", risk.AvgProbability), fmt.Sprintf("%.2f", risk.AvgImpact),
+				score, coeff,
+			}
+		}
+		risks.draw(pdf, rows)
+	}
+
+	pdfHeading(pdf, "Итоговая оценка")
+	pdf.SetFont(pdfFont, "B", 14)
+	if r.Epic.FinalScore != nil {
+		pdf.MultiCell(0, 7, strconv.FormatFloat(*r.Epic.FinalScore, 'f', -1, 64), "", "L", false)
+	} else {
+		pdf.MultiCell(0, 7, pdfPending, "", "L", false)
+	}
+
+	pdf.Ln(4)
+	pdf.SetFont(pdfFont, "", 8)
+	pdf.MultiCell(0, 4, "Сформировано: "+r.formatTime(r.GeneratedAt), "", "L", false)
+
+	var b bytes.Buffer
+	if err := pdf.Output(&b); err != nil {
+		return nil, fmt.Errorf("export.EpicToPDF: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// pdfHeading starts a report section.
+func pdfHeading(pdf *fpdf.Fpdf, title string) {
+	pdf.Ln(4)
+	pdf.SetFont(pdfFont, "B", 12)
+	pdf.MultiCell(0, 7, title, "", "L", false)
+	pdf.Ln(1)
+}
+
+// pdfText writes a wrapped paragraph in the body font.
+func pdfText(pdf *fpdf.Fpdf, text string) {
+	pdf.SetFont(pdfFont, "", 10)
+	pdf.MultiCell(0, pdfLineHeight, text, "", "L", false)
+}
+
+// pdfTable is a bordered table whose cells wrap their text.
+type pdfTable struct {
+	widths []float64 // mm, one per column
+	header []string
+}
+
+// draw writes the header and rows. A row that does not fit on the current
+// page moves to the next one, after a repeated header. Cells longer than a
+// page are cut with an ellipsis.
+func (t pdfTable) draw(pdf *fpdf.Fpdf, rows [][]string) {
+	auto, bottom := pdf.GetAutoPageBreak()
+	// Page breaks are placed by row here, not by MultiCell mid-row.
+	pdf.SetAutoPageBreak(false, bottom)
+	defer pdf.SetAutoPageBreak(auto, bottom)
+
+	_, pageHeight := pdf.GetPageSize()
+	_, top, _, _ := pdf.GetMargins()
+	limit := pageHeight - bottom
+	headerHeight := t.height(pdf, t.header, true, 0)
+	maxLines := int((limit-top-headerHeight)/pdfLineHeight) - 1
+
+	if pdf.GetY()+headerHeight+pdfLineHeight > limit {
+		pdf.AddPage()
+	}
+	t.row(pdf, t.header, true, 0)
+	for _, cells := range rows {
+		if pdf.GetY()+t.height(pdf, cells, false, maxLines) > limit {
+			pdf.AddPage()
+			t.row(pdf, t.header, true, 0)
+		}
+		t.row(pdf, cells, false, maxLines)
+	}
+}
+
+// setTableFont selects the font of header or body cells.
+func setTableFont(pdf *fpdf.Fpdf, header bool) {
+	if header {
+		pdf.SetFont(pdfFont, "B", 9)
+	} else {
+		pdf.SetFont(pdfFont, "", 9)
+	}
+}
+
+// height returns how tall a row will be.
+func (t pdfTable) height(pdf *fpdf.Fpdf, cells []string, header bool, maxLines int) float64 {
+	setTableFont(pdf, header)
+	lines := 1
+	for i, text := range cells {
+		lines = max(lines, len(t.lines(pdf, text, i, maxLines)))
+	}
+	return float64(lines) * pdfLineHeight
+}
+
+// lines splits a cell into the lines that fit the column, keeping at most
+// maxLines of them when maxLines is positive.
+func (t pdfTable) lines(pdf *fpdf.Fpdf, text string, col, maxLines int) []string {
+	lines := pdf.SplitText(strings.ReplaceAll(text, "\r", ""), t.widths[col])
+	if len(lines) == 0 {
+		return []string{""}
+	}
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := []rune(lines[maxLines-1])
+		lines[maxLines-1] = string(last[:max(len(last)-1, 0)]) + "…"
+	}
+	return lines
+}
+
+// row draws one row at the current position.
+func (t pdfTable) row(pdf *fpdf.Fpdf, cells []string, header bool, maxLines int) {
+	setTableFont(pdf, header)
+	split := make([][]string, len(cells))
+	lines := 1
+	for i, text := range cells {
+		split[i] = t.lines(pdf, text, i, maxLines)
+		lines = max(lines, len(split[i]))
+	}
+	h := float64(lines) * pdfLineHeight
+
+	style := "D"
+	if header {
+		style = "FD"
+		pdf.SetFillColor(230, 230, 230)
+	}
+	x, y := pdf.GetXY()
+	for i, cellLines := range split {
+		w := t.widths[i]
+		pdf.Rect(x, y, w, h, style)
+		pdf.SetXY(x, y)
+		pdf.MultiCell(w, pdfLineHeight, strings.Join(cellLines, "\n"), "", "L", false)
+		x += w
+	}
+	left, _, _, _ := pdf.GetMargins()
+	pdf.SetXY(left, y+h)
+}
@@ -0,0 +1,46 @@
+//go:build !nopdf
+
+package export
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+)
+
+func TestEpicToPDF(t *testing.T) {
+	final := 21.0
+	risk := 6.5
+	coeff := 1.2
+	r := EpicReport{
+		Epic: domain.Epic{
+			Number: "EP-1", Name: "Вход через SSO", Description: "Описание",
+			Status: domain.StatusScored, FinalScore: &final,
+		},
+		TeamName:      "Платформа",
+		EffortScorers: 3,
+		EffortScored:  3,
+		RoleScores:    []RoleScore{{RoleName: "Backend", WeightedAvg: 17.5}},
+		Risks: []RiskRow{
+			{Description: "API", Status: domain.StatusScored, WeightedScore: &risk, Coefficient: &coeff},
+			// Long enough to wrap and push the table onto a second page.
+			{Description: strings.Repeat("Очень длинное описание риска ", 200), Status: domain.StatusScoring},
+		},
+		GeneratedAt: time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC),
+	}
+
+	pdf, err := EpicToPDF(r)
+	if err != nil {
+		t.Fatalf("EpicToPDF() error = %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF")) {
+		t.Errorf("EpicToPDF() starts with %q, want %%PDF", pdf[:min(len(pdf), 8)])
+	}
+	// The embedded font subset alone takes several kilobytes.
+	if len(pdf) < 10_000 {
+		t.Errorf("EpicToPDF() = %d bytes, want a non-trivial document", len(pdf))
+	}
+}
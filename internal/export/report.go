@@ -0,0 +1,134 @@
+package export
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+)
+
+// ErrPDFUnavailable is returned by EpicToPDF in builds with the nopdf tag,
+// which leave out the PDF renderer and its embedded fonts.
+var ErrPDFUnavailable = errors.New("export: PDF support is not built in")
+
+// pendingMark is rendered in place of values that are not calculated yet.
+const pendingMark = "⏳ ожидается"
+
+// RoleScore is a per-role weighted average shown in a report.
+type RoleScore struct {
+	RoleName    string
+	WeightedAvg float64
+}
+
+// RiskRow is a single line of the risk matrix shown in a report.
+type RiskRow struct {
+	Description    string
+	Status         domain.Status
+	Importance     domain.Importance
+	Assessments    int
+	AvgProbability float64
+	AvgImpact      float64
+	WeightedScore  *float64 // nil until the risk is scored
+	Coefficient    *float64 // nil until the risk is scored
+}
+
+// EpicReport is the read-model rendered into a per-epic report.
+type EpicReport struct {
+	Epic          domain.Epic
+	TeamName      string
+	EffortScorers int // members expected to score the effort, see CountEpicScorers
+	EffortScored  int
+	RoleScores    []RoleScore
+	Risks         []RiskRow
+	GeneratedAt   time.Time
+	Location      *time.Location // team timezone for timestamps; UTC if nil
+}
+
+// EpicToMarkdown renders an epic report as a Markdown document.
+// Sections whose data is not calculated yet are marked as pending.
+func EpicToMarkdown(r EpicReport) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "# Эпик #%s «%s»\n\n", r.Epic.Number, r.Epic.Name)
+
+	b.WriteString("## Описание\n\n")
+	if desc := strings.TrimSpace(r.Epic.Description); desc != "" {
+		b.WriteString(desc + "\n\n")
+	} else {
+		b.WriteString("—\n\n")
+	}
+	fmt.Fprintf(&b, "- Команда: %s\n", r.TeamName)
+	fmt.Fprintf(&b, "- Статус: %s\n", r.Epic.Status)
+	fmt.Fprintf(&b, "- Создан: %s\n", r.formatTime(r.Epic.CreatedAt))
+	if r.Epic.ScoringDeadline != nil {
+		fmt.Fprintf(&b, "- Дедлайн оценки: %s\n", r.formatTime(*r.Epic.ScoringDeadline))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Участие\n\n")
+	fmt.Fprintf(&b, "- Оценили трудоёмкость: %d из %d\n\n", r.EffortScored, r.EffortScorers)
+
+	b.WriteString("## Оценки по ролям\n\n")
+	if len(r.RoleScores) == 0 {
+		b.WriteString(pendingMark + "\n\n")
+	} else {
+		b.WriteString("| Роль | Средневзвешенная оценка |\n")
+		b.WriteString("|---|---|\n")
+		var base float64
+		for _, rs := range r.RoleScores {
+			fmt.Fprintf(&b, "| %s | %.2f |\n", cell(rs.RoleName), rs.WeightedAvg)
+			base += rs.WeightedAvg
+		}
+		fmt.Fprintf(&b, "\nБазовая оценка: **%.2f**\n\n", base)
+	}
+
+	b.WriteString("## Матрица рисков\n\n")
+	if len(r.Risks) == 0 {
+		b.WriteString("Рисков нет.\n\n")
+	} else {
+		b.WriteString("| Риск | Статус | Важность | Оценок | Вероятность | Влияние | Оценка | Коэфф. |\n")
+		b.WriteString("|---|---|---|---|---|---|---|---|\n")
+		for _, risk := range r.Risks {
+			score, coeff := pendingMark, pendingMark
+			if risk.WeightedScore != nil {
+				score = fmt.Sprintf("%.2f", *risk.WeightedScore)
+			}
+			if risk.Coefficient != nil {
+				coeff = fmt.Sprintf("%.2f", *risk.Coefficient)
+			}
+			fmt.Fprintf(&b, "| %s | %s | %s | %d | %.2f | %.2f | %s | %s |\n",
+				cell(risk.Description), risk.Status, risk.Importance, risk.Assessments,
+				risk.AvgProbability, risk.AvgImpact, score, coeff)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Итоговая оценка\n\n")
+	if r.Epic.FinalScore != nil {
+		fmt.Fprintf(&b, "**%s**\n\n", strconv.FormatFloat(*r.Epic.FinalScore, 'f', -1, 64))
+	} else {
+		b.WriteString(pendingMark + "\n\n")
+	}
+
+	fmt.Fprintf(&b, "_Сформировано: %s_\n", r.formatTime(r.GeneratedAt))
+	return b.Bytes()
+}
+
+// cell makes a value safe to embed in a Markdown table cell.
+func cell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// formatTime renders a timestamp in the report's timezone.
+func (r EpicReport) formatTime(ts time.Time) string {
+	loc := r.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return ts.In(loc).Format("2006-01-02 15:04 MST")
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+)
+
+func TestEpicToMarkdown(t *testing.T) {
+	final := 21.5
+	riskScore, coeff := 6.5, 1.2
+	generated := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		report  EpicReport
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "scored",
+			report: EpicReport{
+				Epic: domain.Epic{
+					Number: "EP-1", Name: "Login", Status: domain.StatusScored, FinalScore: &final,
+				},
+				TeamName:      "Platform",
+				EffortScorers: 3,
+				EffortScored:  3,
+				RoleScores:    []RoleScore{{RoleName: "Backend", WeightedAvg: 12}, {RoleName: "QA", WeightedAvg: 5.5}},
+				Risks: []RiskRow{{
+					Description: "API | v2", Status: domain.StatusScored, Assessments: 2,
+					AvgProbability: 2, AvgImpact: 3.25, WeightedScore: &riskScore, Coefficient: &coeff,
+				}},
+				GeneratedAt: generated,
+			},
+			want: []string{
+				"# Эпик #EP-1 «Login»",
+				"- Оценили трудоёмкость: 3 из 3",
+				"| Backend | 12.00 |",
+				"Базовая оценка: **17.50**",
+				`| API \| v2 | SCORED |`,
+				"| 6.50 | 1.20 |",
+				"## Итоговая оценка\n\n**21.5**",
+				"_Сформировано: 2026-01-02 03:04 UTC_",
+			},
+			notWant: []string{pendingMark},
+		},
+		{
+			name: "pending",
+			report: EpicReport{
+				Epic:          domain.Epic{Number: "EP-2", Name: "Logout", Status: domain.StatusScoring},
+				EffortScorers: 4,
+				Risks:         []RiskRow{{Description: "DB", Status: domain.StatusScoring}},
+				GeneratedAt:   generated,
+			},
+			want: []string{
+				"- Оценили трудоёмкость: 0 из 4",
+				"## Оценки по ролям\n\n" + pendingMark,
+				"| " + pendingMark + " | " + pendingMark + " |",
+				"## Итоговая оценка\n\n" + pendingMark,
+			},
+			notWant: []string{"Базовая оценка"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := string(EpicToMarkdown(tt.report))
+			if doc == "" {
+				t.Fatal("EpicToMarkdown() returned an empty document")
+			}
+			for _, s := range tt.want {
+				if !strings.Contains(doc, s) {
+					t.Errorf("document does not contain %q:\n%s", s, doc)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(doc, s) {
+					t.Errorf("document contains %q:\n%s", s, doc)
+				}
+			}
+		})
+	}
+}
+
+func TestEpicReportFormatTime(t *testing.T) {
+	ts := time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC)
+	moscow := time.FixedZone("MSK", 3*60*60)
+	if got := (EpicReport{}).formatTime(ts); got != "2026-01-02 03:04 UTC" {
+		t.Errorf("formatTime() without a location = %q", got)
+	}
+	if got := (EpicReport{Location: moscow}).formatTime(ts); got != "2026-01-02 06:04 MSK" {
+		t.Errorf("formatTime() in MSK = %q", got)
+	}
+}
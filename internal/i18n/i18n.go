@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed locales/*.yml
+var localesFS embed.FS
+
+// Localizer renders user-facing messages from per-language catalogs.
+type Localizer struct {
+	catalogs    map[string]map[string]string
+	defaultLang string
+}
+
+// New loads the embedded catalogs. defaultLang is used for chats without
+// a stored choice and as the fallback for keys missing in a catalog.
+func New(defaultLang string) (*Localizer, error) {
+	op := "i18n.New"
+	entries, err := localesFS.ReadDir("locales")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	l := &Localizer{
+		catalogs:    make(map[string]map[string]string),
+		defaultLang: defaultLang,
+	}
+	for _, entry := range entries {
+		data, err := localesFS.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("%s: read %s: %w", op, entry.Name(), err)
+		}
+		var tree map[string]any
+		if err := yaml.Unmarshal(data, &tree); err != nil {
+			return nil, fmt.Errorf("%s: parse %s: %w", op, entry.Name(), err)
+		}
+		catalog := make(map[string]string)
+		flatten("", tree, catalog)
+		l.catalogs[strings.TrimSuffix(entry.Name(), ".yml")] = catalog
+	}
+	if _, ok := l.catalogs[defaultLang]; !ok {
+		return nil, fmt.Errorf("%s: no catalog for default language %q", op, defaultLang)
+	}
+	return l, nil
+}
+
+// flatten turns nested YAML maps into dot-separated keys.
+func flatten(prefix string, tree map[string]any, out map[string]string) {
+	for k, v := range tree {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		switch val := v.(type) {
+		case map[string]any:
+			flatten(key, val, out)
+		default:
+			out[key] = fmt.Sprint(val)
+		}
+	}
+}
+
+// T renders the message for key in lang, formatting args with fmt verbs.
+// Keys missing in lang fall back to the default language and then to the
+// key name itself.
+func (l *Localizer) T(lang, key string, args ...any) string {
+	msg, ok := l.catalogs[lang][key]
+	if !ok {
+		msg, ok = l.catalogs[l.defaultLang][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// DefaultLanguage returns the language used when a chat has no choice.
+func (l *Localizer) DefaultLanguage() string {
+	return l.defaultLang
+}
+
+// Supported reports whether a catalog exists for lang.
+func (l *Localizer) Supported(lang string) bool {
+	_, ok := l.catalogs[lang]
+	return ok
+}
+
+// Languages returns the available language codes, sorted.
+func (l *Localizer) Languages() []string {
+	langs := make([]string, 0, len(l.catalogs))
+	for lang := range l.catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestT(t *testing.T) {
+	l, err := New("ru")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tests := []struct {
+		name string
+		lang string
+		key  string
+		args []any
+		want string
+	}{
+		{"english", "en", "epic.created", []any{"7", "Login"}, "✅ Epic #7 «Login» created (status: NEW)"},
+		{"russian", "ru", "epic.created", []any{"7", "Login"}, "✅ Эпик #7 «Login» создан (статус: NEW)"},
+		{"no args keeps the text", "en", "access.admin_only", nil, "⛔ Administrators only."},
+		{"unknown language uses the default", "de", "access.admin_only", nil, l.T("ru", "access.admin_only")},
+		{"missing key renders the key", "en", "no.such.key", []any{1}, "no.such.key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := l.T(tt.lang, tt.key, tt.args...); got != tt.want {
+				t.Errorf("T(%q, %q) = %q, want %q", tt.lang, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTFallsBackToDefaultLanguage(t *testing.T) {
+	l := &Localizer{
+		catalogs: map[string]map[string]string{
+			"ru": {"only.ru": "только %s"},
+			"en": {},
+		},
+		defaultLang: "ru",
+	}
+	if got := l.T("en", "only.ru", "ru"); got != "только ru" {
+		t.Errorf("T() = %q, want the default language text", got)
+	}
+}
+
+func TestNewRejectsUnknownDefault(t *testing.T) {
+	if _, err := New("xx"); err == nil {
+		t.Error("New(\"xx\") error = nil, want an error")
+	}
+}
+
+// TestCatalogsHaveSameKeys keeps the catalogs in sync, so no language
+// silently falls back to another one.
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	l, err := New("ru")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if got := l.Languages(); len(got) < 2 {
+		t.Fatalf("Languages() = %v, want at least en and ru", got)
+	}
+	def := l.catalogs[l.DefaultLanguage()]
+	for lang, catalog := range l.catalogs {
+		var missing, extra []string
+		for key := range def {
+			if _, ok := catalog[key]; !ok {
+				missing = append(missing, key)
+			}
+		}
+		for key := range catalog {
+			if _, ok := def[key]; !ok {
+				extra = append(extra, key)
+			}
+		}
+		sort.Strings(missing)
+		sort.Strings(extra)
+		if len(missing) > 0 || len(extra) > 0 {
+			t.Errorf("%s: missing keys %v, keys not in %s %v", lang, missing, l.DefaultLanguage(), extra)
+		}
+	}
+}
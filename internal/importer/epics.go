@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// EpicRow is a valid epic parsed from an import file.
+type EpicRow struct {
+	Line        int
+	Number      string
+	Name        string
+	Description string
+}
+
+// RowError describes why a line of an import file was rejected.
+type RowError struct {
+	Line   int
+	Reason string
+}
+
+// ParseEpicsCSV reads epics from CSV with the columns number,name and an
+// optional description. A leading header row is skipped. Rows with an
+// empty number or name, or a number repeated within the file, are
+// returned as row errors; the remaining rows are returned as valid.
+func ParseEpicsCSV(r io.Reader) ([]EpicRow, []RowError, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []EpicRow
+	var rowErrs []RowError
+	seen := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrs = append(rowErrs, RowError{Line: parseErr.Line, Reason: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("importer.ParseEpicsCSV: %w", err)
+		}
+
+		if len(record) > 0 && line == 1 {
+			record[0] = strings.TrimPrefix(record[0], "\uFEFF")
+			if isHeader(record) {
+				continue
+			}
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue // blank line
+		}
+		if len(record) < 2 || len(record) > 3 {
+			rowErrs = append(rowErrs, RowError{Line: line,
+				Reason: fmt.Sprintf("ожидается 2–3 колонки, получено %d", len(record))})
+			continue
+		}
+
+		row := EpicRow{
+			Line:   line,
+			Number: strings.TrimPrefix(strings.TrimSpace(record[0]), "#"),
+			Name:   strings.TrimSpace(record[1]),
+		}
+		if len(record) == 3 {
+			row.Description = strings.TrimSpace(record[2])
+		}
+
+		switch {
+		case row.Number == "":
+			rowErrs = append(rowErrs, RowError{Line: line, Reason: "пустой номер"})
+		case row.Name == "":
+			rowErrs = append(rowErrs, RowError{Line: line, Reason: "пустое название"})
+		case seen[row.Number] != 0:
+			rowErrs = append(rowErrs, RowError{Line: line,
+				Reason: fmt.Sprintf("номер %s повторяет строку %d", row.Number, seen[row.Number])})
+		default:
+			seen[row.Number] = line
+			rows = append(rows, row)
+		}
+	}
+	return rows, rowErrs, nil
+}
+
+// isHeader reports whether a record is the number,name[,description] header.
+func isHeader(record []string) bool {
+	return len(record) >= 2 &&
+		strings.EqualFold(strings.TrimSpace(record[0]), "number") &&
+		strings.EqualFold(strings.TrimSpace(record[1]), "name")
+}
@@ -0,0 +1,87 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEpicsCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantRows []EpicRow
+		wantErrs []RowError
+	}{
+		{
+			name:  "header and description are optional",
+			input: "number,name,description\nE-1,Login,Sign in by e-mail\n#E-2, Logout \n",
+			wantRows: []EpicRow{
+				{Line: 2, Number: "E-1", Name: "Login", Description: "Sign in by e-mail"},
+				{Line: 3, Number: "E-2", Name: "Logout"},
+			},
+		},
+		{
+			name:  "header with byte order mark",
+			input: "\uFEFFNumber,Name\n1,First\n",
+			wantRows: []EpicRow{
+				{Line: 2, Number: "1", Name: "First"},
+			},
+		},
+		{
+			name:  "no header",
+			input: "1,First\n\n2,Second,\"Quoted, with comma\"\n",
+			wantRows: []EpicRow{
+				{Line: 1, Number: "1", Name: "First"},
+				{Line: 3, Number: "2", Name: "Second", Description: "Quoted, with comma"},
+			},
+		},
+		{
+			name:  "invalid rows are reported with their lines",
+			input: "1,First\n,No number\n2,\n3\n4,a,b,c\n1,Again\n5,Fifth\n",
+			wantRows: []EpicRow{
+				{Line: 1, Number: "1", Name: "First"},
+				{Line: 7, Number: "5", Name: "Fifth"},
+			},
+			wantErrs: []RowError{
+				{Line: 2, Reason: "пустой номер"},
+				{Line: 3, Reason: "пустое название"},
+				{Line: 4, Reason: "ожидается 2–3 колонки, получено 1"},
+				{Line: 5, Reason: "ожидается 2–3 колонки, получено 4"},
+				{Line: 6, Reason: "номер 1 повторяет строку 1"},
+			},
+		},
+		{
+			name:     "empty file",
+			input:    "",
+			wantRows: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, rowErrs, err := ParseEpicsCSV(strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatalf("ParseEpicsCSV() error = %v", err)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("rows = %+v, want %+v", rows, tt.wantRows)
+			}
+			if !reflect.DeepEqual(rowErrs, tt.wantErrs) {
+				t.Errorf("row errors = %+v, want %+v", rowErrs, tt.wantErrs)
+			}
+		})
+	}
+}
+
+func TestParseEpicsCSVMalformedQuote(t *testing.T) {
+	rows, rowErrs, err := ParseEpicsCSV(strings.NewReader("1,First\n2,\"Broken\n"))
+	if err != nil {
+		t.Fatalf("ParseEpicsCSV() error = %v", err)
+	}
+	if len(rows) != 1 || rows[0].Number != "1" {
+		t.Errorf("rows = %+v, want only line 1", rows)
+	}
+	if len(rowErrs) != 1 {
+		t.Fatalf("row errors = %+v, want one parse error", rowErrs)
+	}
+}
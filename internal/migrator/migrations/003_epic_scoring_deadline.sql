@@ -0,0 +1,7 @@
+-- Migration 003: optional deadline after which epic scoring is closed
+-- automatically with the votes submitted so far.
+ALTER TABLE epics
+ADD COLUMN IF NOT EXISTS scoring_deadline TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_epics_scoring_deadline ON epics (scoring_deadline)
+WHERE scoring_deadline IS NOT NULL;
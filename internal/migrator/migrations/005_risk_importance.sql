@@ -0,0 +1,5 @@
+-- Migration 005: per-risk importance that scales how much the risk
+-- coefficient moves the final score. Existing risks keep full weight.
+ALTER TABLE risks
+ADD COLUMN IF NOT EXISTS importance TEXT NOT NULL DEFAULT 'MEDIUM'
+CHECK (importance IN ('LOW', 'MEDIUM', 'HIGH'));
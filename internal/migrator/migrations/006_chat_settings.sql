@@ -0,0 +1,6 @@
+-- Migration 006: per-chat settings such as the interface language.
+CREATE TABLE IF NOT EXISTS chat_settings (
+    chat_id BIGINT PRIMARY KEY,
+    language TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+-- Migration 007: IANA timezone used to display a team's timestamps.
+ALTER TABLE teams
+ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,9 @@
+-- Migration 008: risks a team member marked as not applicable to them.
+-- A skip counts towards the completion quorum but not the weighted score.
+CREATE TABLE IF NOT EXISTS risk_skips (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    risk_id UUID NOT NULL REFERENCES risks (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (risk_id, user_id)
+);
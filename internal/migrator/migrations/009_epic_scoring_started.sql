@@ -0,0 +1,8 @@
+-- Migration 009: when scoring of an epic was last started, so stalled
+-- epics can be surfaced. Epics already in scoring fall back to their last
+-- update.
+ALTER TABLE epics
+ADD COLUMN IF NOT EXISTS scoring_started_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE epics SET scoring_started_at = updated_at
+WHERE status = 'SCORING' AND scoring_started_at IS NULL;
@@ -0,0 +1,7 @@
+-- Migration 010: roles whose members estimate an epic's effort. An epic
+-- without rows here is estimated by every member of its team.
+CREATE TABLE IF NOT EXISTS epic_required_roles (
+    epic_id UUID NOT NULL REFERENCES epics (id) ON DELETE CASCADE,
+    role_id UUID NOT NULL REFERENCES roles (id) ON DELETE CASCADE,
+    PRIMARY KEY (epic_id, role_id)
+);
@@ -0,0 +1,4 @@
+-- Migration 011: private chat with the bot, recorded when a user sends
+-- /start there, so the bot can message them directly.
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS chat_id BIGINT;
@@ -0,0 +1,9 @@
+-- Migration 012: when scoring of an epic completed, so the time from
+-- starting to finishing scoring can be reported. Epics scored since
+-- scoring_started_at was introduced were last updated when they were
+-- scored.
+ALTER TABLE epics
+ADD COLUMN IF NOT EXISTS scored_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE epics SET scored_at = updated_at
+WHERE status = 'SCORED' AND scoring_started_at IS NOT NULL AND scored_at IS NULL;
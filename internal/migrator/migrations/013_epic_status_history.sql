@@ -0,0 +1,28 @@
+-- Migration 013: status transitions of epics, e.g. NEW → SCORING → SCORED.
+-- Transitions that happened before are recovered where their time is
+-- known.
+CREATE TABLE IF NOT EXISTS epic_status_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    epic_id UUID NOT NULL REFERENCES epics (id) ON DELETE CASCADE,
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_epic_status_history_epic_id
+ON epic_status_history (epic_id, changed_at);
+
+INSERT INTO epic_status_history (epic_id, from_status, to_status, changed_at)
+SELECT id, 'NEW', 'SCORING', scoring_started_at
+FROM epics
+WHERE scoring_started_at IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM epic_status_history h WHERE h.epic_id = epics.id);
+
+INSERT INTO epic_status_history (epic_id, from_status, to_status, changed_at)
+SELECT id, 'SCORING', 'SCORED', scored_at
+FROM epics
+WHERE scored_at IS NOT NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM epic_status_history h
+      WHERE h.epic_id = epics.id AND h.to_status = 'SCORED'
+  );
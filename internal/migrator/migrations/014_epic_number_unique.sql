@@ -0,0 +1,11 @@
+-- Migration 014: epic numbers are unique, so concurrent /addepic calls
+-- cannot create the same epic twice. Duplicates left by earlier races keep
+-- the number on the oldest epic; later ones get a suffix from their ID.
+UPDATE epics e SET number = e.number || '-dup-' || LEFT(e.id::text, 8)
+WHERE EXISTS (
+    SELECT 1 FROM epics o
+    WHERE o.number = e.number
+      AND (o.created_at, o.id) < (e.created_at, e.id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_epics_number ON epics (number);
@@ -0,0 +1,4 @@
+-- Migration 015: archived teams are hidden from team pickers but keep their
+-- epics and members for reports.
+ALTER TABLE teams
+ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
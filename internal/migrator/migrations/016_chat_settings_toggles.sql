@@ -0,0 +1,4 @@
+-- Migration 016: per-chat feature toggles set with /settings. NULL follows
+-- the bot configuration. An empty language means the default language.
+ALTER TABLE chat_settings
+ADD COLUMN IF NOT EXISTS hide_baseline_risks BOOLEAN;
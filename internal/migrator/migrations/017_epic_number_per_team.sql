@@ -0,0 +1,6 @@
+-- Migration 017: epic numbers are unique within a team rather than
+-- globally, so two teams can each have their own EP-1.
+DROP INDEX IF EXISTS idx_epics_number;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_epics_team_number ON epics (team_id, number);
+CREATE INDEX IF NOT EXISTS idx_epics_number ON epics (number);
@@ -0,0 +1,24 @@
+-- Migration 018: Telegram usernames are case-insensitive, so they are
+-- stored in lower case and a username may be registered only once in any
+-- case. Users whose usernames differ only in case must be merged or
+-- renamed by hand first; the migration stops and lists them.
+DO $$
+DECLARE
+    conflicts TEXT;
+BEGIN
+    SELECT string_agg(names, '; ') INTO conflicts
+    FROM (
+        SELECT string_agg(telegram_id, ', ' ORDER BY telegram_id) AS names
+        FROM users
+        GROUP BY LOWER(telegram_id)
+        HAVING COUNT(*) > 1
+    ) c;
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'users.telegram_id values collide when lowercased: %. Delete or rename the extra users and restart.', conflicts;
+    END IF;
+END $$;
+
+UPDATE users SET telegram_id = LOWER(telegram_id)
+WHERE telegram_id <> LOWER(telegram_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_telegram_id_lower ON users (LOWER(telegram_id));
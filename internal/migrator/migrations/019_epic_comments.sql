@@ -0,0 +1,11 @@
+-- Migration 019: free-text comments participants leave on an epic, e.g.
+-- the assumptions behind their estimate.
+CREATE TABLE IF NOT EXISTS epic_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid (),
+    epic_id UUID NOT NULL REFERENCES epics (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_epic_comments_epic ON epic_comments (epic_id, created_at);
@@ -0,0 +1,10 @@
+-- Migration 020: chats to notify once an epic's final score is known,
+-- subscribed with /watchepic. A subscription is removed when it has been
+-- notified.
+CREATE TABLE IF NOT EXISTS epic_watchers (
+    epic_id UUID NOT NULL REFERENCES epics (id) ON DELETE CASCADE,
+    chat_id BIGINT NOT NULL,
+    thread_id INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (epic_id, chat_id, thread_id)
+);
@@ -1,19 +1,26 @@
 package migrator
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"embed"
 	"fmt"
 	"log/slog"
 	"sort"
 	"strings"
 
+	"EpicScoreBot/internal/utils/logger/sl"
+
 	"github.com/jmoiron/sqlx"
 )
 
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
+// migrationLockKey identifies the advisory lock held while migrations run.
+const migrationLockKey int64 = 0x45707363 // "Epsc"
+
 // Migrator manages database migrations.
 type Migrator struct {
 	db     *sqlx.DB
@@ -30,12 +37,22 @@ func NewMigrator(db *sqlx.DB, log *slog.Logger, schema string) *Migrator {
 	}
 }
 
-// Run executes all pending migrations.
+// Run executes all pending migrations. Instances starting at the same time
+// take turns through a Postgres advisory lock, so a later one finds the
+// migrations already applied. The migrations run on the connection holding
+// the lock, so a pool of a single connection does not deadlock.
 func (m *Migrator) Run() error {
 	op := "migrator.Run"
 	m.log.Info("starting database migrations")
 
-	if err := m.createMigrationsTable(); err != nil {
+	ctx := context.Background()
+	conn, unlock, err := m.lock(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: failed to take migration lock: %w", op, err)
+	}
+	defer unlock()
+
+	if err := m.createMigrationsTable(ctx, conn); err != nil {
 		return fmt.Errorf("%s: failed to create migrations table: %w", op, err)
 	}
 
@@ -45,7 +62,7 @@ func (m *Migrator) Run() error {
 	}
 
 	for _, migration := range migrations {
-		if err := m.runMigration(migration); err != nil {
+		if err := m.runMigration(ctx, conn, migration); err != nil {
 			return fmt.Errorf("%s: failed to run migration %s: %w", op, migration, err)
 		}
 	}
@@ -54,9 +71,68 @@ func (m *Migrator) Run() error {
 	return nil
 }
 
-func (m *Migrator) createMigrationsTable() error {
+// lock waits for the migration advisory lock and returns the connection
+// holding it with the function releasing it. The lock belongs to a database
+// session, so it is taken on a dedicated connection that is held until
+// release.
+func (m *Migrator) lock(ctx context.Context) (*sqlx.Conn, func(), error) {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockKey); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+	return conn, func() {
+		defer conn.Close()
+		if _, err := conn.ExecContext(context.Background(),
+			`SELECT pg_advisory_unlock($1)`, migrationLockKey); err != nil {
+			m.log.Warn("failed to release migration lock, dropping the connection", sl.Err(err))
+			// A dropped session releases its advisory locks; one returned
+			// to the pool would keep holding it.
+			conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}, nil
+}
+
+// DryRun returns the versions of the migrations Run would apply, in order,
+// without executing or recording anything. A database that was never
+// migrated has all migrations pending.
+func (m *Migrator) DryRun() ([]string, error) {
+	op := "migrator.DryRun"
+
+	var table sql.NullString
+	if err := m.db.Get(&table, `SELECT to_regclass($1)::text`, m.schema+".schema_migrations"); err != nil {
+		return nil, fmt.Errorf("%s: failed to look up migrations table: %w", op, err)
+	}
+	applied := make(map[string]bool)
+	if table.Valid {
+		versions, err := m.GetAppliedMigrations()
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to get applied migrations: %w", op, err)
+		}
+		for _, v := range versions {
+			applied[v] = true
+		}
+	}
+
+	migrations, err := m.getMigrationFiles()
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to get migration files: %w", op, err)
+	}
+	var pending []string
+	for _, migration := range migrations {
+		if version := strings.TrimSuffix(migration, ".sql"); !applied[version] {
+			pending = append(pending, version)
+		}
+	}
+	return pending, nil
+}
+
+func (m *Migrator) createMigrationsTable(ctx context.Context, conn *sqlx.Conn) error {
 	schemaQuery := fmt.Sprintf(`CREATE SCHEMA IF NOT EXISTS %s`, m.schema)
-	if _, err := m.db.Exec(schemaQuery); err != nil {
+	if _, err := conn.ExecContext(ctx, schemaQuery); err != nil {
 		return err
 	}
 
@@ -66,7 +142,7 @@ func (m *Migrator) createMigrationsTable() error {
 			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 		)
 	`, m.schema)
-	_, err := m.db.Exec(query)
+	_, err := conn.ExecContext(ctx, query)
 	return err
 }
 
@@ -87,20 +163,20 @@ func (m *Migrator) getMigrationFiles() ([]string, error) {
 	return migrations, nil
 }
 
-func (m *Migrator) isMigrationApplied(version string) (bool, error) {
+func (m *Migrator) isMigrationApplied(ctx context.Context, conn *sqlx.Conn, version string) (bool, error) {
 	var count int
 	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s.schema_migrations WHERE version = $1`, m.schema)
-	err := m.db.Get(&count, query, version)
+	err := conn.GetContext(ctx, &count, query, version)
 	if err != nil {
 		return false, err
 	}
 	return count > 0, nil
 }
 
-func (m *Migrator) runMigration(filename string) error {
+func (m *Migrator) runMigration(ctx context.Context, conn *sqlx.Conn, filename string) error {
 	version := strings.TrimSuffix(filename, ".sql")
 
-	applied, err := m.isMigrationApplied(version)
+	applied, err := m.isMigrationApplied(ctx, conn, version)
 	if err != nil {
 		return err
 	}
@@ -117,7 +193,7 @@ func (m *Migrator) runMigration(filename string) error {
 		return fmt.Errorf("failed to read migration file: %w", err)
 	}
 
-	tx, err := m.db.Begin()
+	tx, err := conn.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -127,8 +203,9 @@ func (m *Migrator) runMigration(filename string) error {
 		}
 	}()
 
-	// Set search_path for this transaction
-	if _, err = tx.Exec(fmt.Sprintf("SET search_path TO %s, public", m.schema)); err != nil {
+	// Set search_path for this transaction only; the connection goes back
+	// to the pool afterwards.
+	if _, err = tx.Exec(fmt.Sprintf("SET LOCAL search_path TO %s, public", m.schema)); err != nil {
 		return fmt.Errorf("failed to set search_path: %w", err)
 	}
 
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,6 +14,18 @@ const (
 	StatusNew     Status = "NEW"
 	StatusScoring Status = "SCORING"
 	StatusScored  Status = "SCORED"
+	// StatusSkipped marks a risk that nobody scored before scoring was
+	// closed manually; it does not contribute to the final score.
+	StatusSkipped Status = "SKIPPED"
+)
+
+// Importance ranks how strongly a risk affects the final epic score.
+type Importance string
+
+const (
+	ImportanceLow    Importance = "LOW"
+	ImportanceMedium Importance = "MEDIUM"
+	ImportanceHigh   Importance = "HIGH"
 )
 
 // Team represents a development team.
@@ -20,10 +33,25 @@ type Team struct {
 	ID          uuid.UUID
 	Name        string
 	Description string
+	Timezone    string     // IANA name, e.g. "Europe/Moscow"
+	ArchivedAt  *time.Time // nil while the team is active
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
+// Location returns the team's timezone, falling back to UTC when it is
+// unset or unknown.
+func (t *Team) Location() *time.Location {
+	if t == nil || t.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(t.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 // Role represents a team role (e.g. IT-leader, analyst, BE developer, etc.).
 type Role struct {
 	ID          uuid.UUID
@@ -31,6 +59,37 @@ type Role struct {
 	Description string
 }
 
+// RoleWithUserCount is a role together with the number of users assigned to it.
+type RoleWithUserCount struct {
+	Role
+	UserCount int
+}
+
+// RoleReferences counts the rows that still refer to a role.
+type RoleReferences struct {
+	Users          int
+	EpicScores     int
+	EpicRoleScores int
+}
+
+// ChatSettings holds the options a chat can change with /settings.
+type ChatSettings struct {
+	ChatID   int64
+	Language string // empty for the configured default
+	// HideBaselineRisks overrides BotConfig.HideBaselineRisks when not nil.
+	HideBaselineRisks *bool
+}
+
+// SystemCounts holds the row counts shown by /stats.
+type SystemCounts struct {
+	Teams      int
+	Users      int
+	Roles      int
+	Risks      int
+	EpicScores int
+	RiskScores int
+}
+
 // User represents a scoring participant.
 type User struct {
 	ID         uuid.UUID
@@ -38,8 +97,11 @@ type User struct {
 	LastName   string
 	TelegramID string
 	Weight     int // 0–100 percent
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	// ChatID is the user's private chat with the bot, 0 if unknown. Only
+	// GetTeamMembersWithChatID loads it.
+	ChatID    int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 // Epic represents a development epic to be scored.
@@ -51,8 +113,10 @@ type Epic struct {
 	TeamID      uuid.UUID
 	Status      Status
 	FinalScore  *float64 // nullable until scored
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	// ScoringDeadline is when scoring closes automatically; nil if unset.
+	ScoringDeadline *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
 }
 
 // Risk represents a risk associated with an epic.
@@ -61,6 +125,7 @@ type Risk struct {
 	Description   string
 	EpicID        uuid.UUID
 	Status        Status
+	Importance    Importance
 	WeightedScore *float64 // nullable until scored
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
@@ -76,6 +141,26 @@ type EpicScore struct {
 	CreatedAt time.Time
 }
 
+// EpicComment is a note a participant left on an epic, such as an
+// assumption behind their estimate.
+type EpicComment struct {
+	ID        uuid.UUID
+	EpicID    uuid.UUID
+	UserID    uuid.UUID
+	FirstName string // author
+	LastName  string // author
+	Text      string
+	CreatedAt time.Time
+}
+
+// EpicWatcher is a chat, or a forum topic in it, waiting to be told the
+// final score of an epic.
+type EpicWatcher struct {
+	EpicID   uuid.UUID
+	ChatID   int64
+	ThreadID int // 0 outside forum topics
+}
+
 // EpicRoleScore stores the weighted average score per role for an epic.
 type EpicRoleScore struct {
 	ID          uuid.UUID
@@ -93,3 +178,83 @@ type RiskScore struct {
 	Impact      int // 1–4
 	CreatedAt   time.Time
 }
+
+// EpicDetail is an epic together with its risks and per-role results.
+type EpicDetail struct {
+	Epic
+	Risks      []Risk
+	RoleScores []EpicRoleScore
+}
+
+// TableRows are the rows of one table captured before a deletion, as a
+// JSON array with one object per row and one field per column.
+type TableRows struct {
+	Table string
+	Rows  json.RawMessage
+}
+
+// RiskSnapshot is a risk together with its scores and skips, captured
+// when the risk is deleted so the deletion can be undone.
+type RiskSnapshot struct {
+	Tables []TableRows
+}
+
+// EpicSnapshot is an epic together with every row depending on it, such
+// as risks, scores, required roles, status history, comments and
+// watchers, captured when the epic is deleted so the deletion can be
+// undone.
+type EpicSnapshot struct {
+	Tables []TableRows
+}
+
+// UserSnapshot is a user together with their roles, team memberships,
+// scores, skips and comments, captured when the user is deleted so the
+// deletion can be undone.
+type UserSnapshot struct {
+	Tables []TableRows
+}
+
+// UserAgreement summarizes how a user's effort scores deviated from the
+// role consensus of scored epics.
+type UserAgreement struct {
+	Epics           int     // scores compared
+	AvgDeviation    float64 // mean of (score − consensus); positive means overestimating
+	AvgAbsDeviation float64 // mean of |score − consensus|
+}
+
+// ScoringProgress summarizes how far scoring of an epic has got.
+type ScoringProgress struct {
+	EpicID       uuid.UUID
+	StartedAt    time.Time // when scoring was last started
+	Members      int       // members of the epic's team
+	EffortScores int       // effort scores submitted
+	Risks        int       // risks of the epic
+	RisksScored  int       // risks whose scoring is complete
+}
+
+// EpicStatusChange is a recorded transition of an epic between statuses.
+type EpicStatusChange struct {
+	From      Status
+	To        Status
+	ChangedAt time.Time
+}
+
+// TeamScoringTime summarizes how long scoring of a team's epics took, from
+// starting scoring to completing it.
+type TeamScoringTime struct {
+	TeamID   uuid.UUID
+	TeamName string
+	Epics    int // scored epics with known times
+	Avg      time.Duration
+	Min      time.Duration
+	Max      time.Duration
+}
+
+// AuditEntry is a recorded administrative action.
+type AuditEntry struct {
+	ID        uuid.UUID
+	Actor     string // Telegram username of the admin
+	Action    string
+	Details   string
+	CreatedAt time.Time
+}
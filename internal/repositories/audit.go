@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"EpicScoreBot/internal/models/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// CreateAuditEntry records an administrative action.
+func (r *Repository) CreateAuditEntry(ctx context.Context, actor, action, details string) error {
+	op := "Repository.CreateAuditEntry"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `INSERT INTO audit_log (id, actor, action, details)
+		VALUES ($1, $2, $3, $4)`
+	_, err := r.DB.ExecContext(ctx, query, uuid.New(), actor, action, details)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GetRecentAuditEntries returns the latest audit entries, newest first.
+func (r *Repository) GetRecentAuditEntries(ctx context.Context, limit int) ([]domain.AuditEntry, error) {
+	op := "Repository.GetRecentAuditEntries"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT id, actor, action, details, created_at
+		FROM audit_log ORDER BY created_at DESC LIMIT $1`
+	rows, err := r.DB.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var entries []domain.AuditEntry
+	for rows.Next() {
+		var e domain.AuditEntry
+		if err := rows.Scan(&e.ID, &e.Actor, &e.Action, &e.Details, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"EpicScoreBot/internal/models/domain"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// GetChatLanguage returns the language chosen for a chat, or an empty
+// string when the chat has no stored choice.
+func (r *Repository) GetChatLanguage(ctx context.Context, chatID int64) (string, error) {
+	op := "Repository.GetChatLanguage"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var lang string
+	query := `SELECT language FROM chat_settings WHERE chat_id = $1`
+	err := r.DB.QueryRowContext(ctx, query, chatID).Scan(&lang)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+	return lang, nil
+}
+
+// SetChatLanguage stores the language chosen for a chat.
+func (r *Repository) SetChatLanguage(ctx context.Context, chatID int64, lang string) error {
+	op := "Repository.SetChatLanguage"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `INSERT INTO chat_settings (chat_id, language) VALUES ($1, $2)
+		ON CONFLICT (chat_id) DO UPDATE
+		SET language = EXCLUDED.language, updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.DB.ExecContext(ctx, query, chatID, lang); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// GetChatSettings returns the settings of a chat. A chat without stored
+// settings gets the zero value, which follows the bot configuration.
+func (r *Repository) GetChatSettings(ctx context.Context, chatID int64) (domain.ChatSettings, error) {
+	op := "Repository.GetChatSettings"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	s := domain.ChatSettings{ChatID: chatID}
+	query := `SELECT language, hide_baseline_risks FROM chat_settings WHERE chat_id = $1`
+	err := r.DB.QueryRowContext(ctx, query, chatID).Scan(&s.Language, &s.HideBaselineRisks)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return s, nil
+		}
+		return s, fmt.Errorf("%s: %w", op, err)
+	}
+	return s, nil
+}
+
+// SaveChatSettings stores all settings of a chat.
+func (r *Repository) SaveChatSettings(ctx context.Context, s domain.ChatSettings) error {
+	op := "Repository.SaveChatSettings"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `INSERT INTO chat_settings (chat_id, language, hide_baseline_risks) VALUES ($1, $2, $3)
+		ON CONFLICT (chat_id) DO UPDATE
+		SET language = EXCLUDED.language,
+			hide_baseline_risks = EXCLUDED.hide_baseline_risks,
+			updated_at = CURRENT_TIMESTAMP`
+	if _, err := r.DB.ExecContext(ctx, query, s.ChatID, s.Language, s.HideBaselineRisks); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/models/domain"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// CreateEpicComment stores a user's comment on an epic. The text is
+// trimmed; ErrInvalidInput is returned when it is empty or longer than the
+// description limit.
+func (r *Repository) CreateEpicComment(ctx context.Context, epicID, userID uuid.UUID, text string) (*domain.EpicComment, error) {
+	op := "Repository.CreateEpicComment"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	text = strings.TrimSpace(text)
+	switch {
+	case text == "":
+		return nil, fmt.Errorf("%s: empty text: %w", op, ErrInvalidInput)
+	case config.ExceedsLimit(text, r.limits.Description):
+		return nil, fmt.Errorf("%s: text longer than %d characters: %w",
+			op, r.limits.Description, ErrInvalidInput)
+	}
+
+	c := &domain.EpicComment{
+		ID:     uuid.New(),
+		EpicID: epicID,
+		UserID: userID,
+		Text:   text,
+	}
+	query := `INSERT INTO epic_comments (id, epic_id, user_id, text)
+		VALUES ($1, $2, $3, $4)
+		RETURNING created_at`
+	err := r.withRetry(ctx, func() error {
+		return r.DB.QueryRowContext(ctx, query, c.ID, c.EpicID, c.UserID, c.Text).Scan(&c.CreatedAt)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return c, nil
+}
+
+// GetCommentsByEpicID returns the comments on an epic with their authors'
+// names, oldest first.
+func (r *Repository) GetCommentsByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.EpicComment, error) {
+	op := "Repository.GetCommentsByEpicID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT c.id, c.epic_id, c.user_id, u.first_name, u.last_name,
+		c.text, c.created_at
+		FROM epic_comments c
+		JOIN users u ON u.id = c.user_id
+		WHERE c.epic_id = $1
+		ORDER BY c.created_at, c.id`
+	rows, err := r.DB.QueryContext(ctx, query, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var comments []domain.EpicComment
+	for rows.Next() {
+		var c domain.EpicComment
+		if err := rows.Scan(&c.ID, &c.EpicID, &c.UserID, &c.FirstName, &c.LastName,
+			&c.Text, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		comments = append(comments, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return comments, nil
+}
@@ -1,16 +1,30 @@
 package repositories
 
 import (
+	"EpicScoreBot/internal/config"
 	"EpicScoreBot/internal/models/domain"
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
-// CreateEpic inserts a new epic.
+// CreateEpic inserts a new epic. Name and description are trimmed.
+// Returns ErrAlreadyExists when the team already has an epic with the same
+// number and ErrInvalidInput when the name is empty or either text is over
+// its configured limit.
 func (r *Repository) CreateEpic(ctx context.Context, number, name, description string, teamID uuid.UUID) (*domain.Epic, error) {
 	op := "Repository.CreateEpic"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	name, description = strings.TrimSpace(name), strings.TrimSpace(description)
+	if err := r.checkEpicText(name, description); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	epic := &domain.Epic{
 		ID:          uuid.New(),
 		Number:      number,
@@ -28,6 +42,9 @@ func (r *Repository) CreateEpic(ctx context.Context, number, name, description s
 		epic.TeamID, string(epic.Status)).
 		Scan(&epic.CreatedAt, &epic.UpdatedAt)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%s: %w", op, ErrAlreadyExists)
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	return epic, nil
@@ -36,33 +53,101 @@ func (r *Repository) CreateEpic(ctx context.Context, number, name, description s
 // GetEpicByID returns an epic by ID.
 func (r *Repository) GetEpicByID(ctx context.Context, epicID uuid.UUID) (*domain.Epic, error) {
 	op := "Repository.GetEpicByID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var epic domain.Epic
 	query := `SELECT id, number, name, description, team_id, status,
-		final_score, created_at, updated_at
+		final_score, scoring_deadline, created_at, updated_at
 		FROM epics WHERE id = $1`
 	err := r.DB.QueryRowContext(ctx, query, epicID).
 		Scan(&epic.ID, &epic.Number, &epic.Name, &epic.Description,
 			&epic.TeamID, &epic.Status,
-			&epic.FinalScore, &epic.CreatedAt, &epic.UpdatedAt)
+			&epic.FinalScore, &epic.ScoringDeadline, &epic.CreatedAt, &epic.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &epic, nil
 }
 
-// GetEpicByNumber returns an epic by its number.
+// GetEpicWithRisks returns an epic together with its risks and per-role
+// results.
+func (r *Repository) GetEpicWithRisks(ctx context.Context, epicID uuid.UUID) (*domain.EpicDetail, error) {
+	op := "Repository.GetEpicWithRisks"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	epic, err := r.GetEpicByID(ctx, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	risks, err := r.GetRisksByEpicID(ctx, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	roleScores, err := r.GetEpicRoleScoresByEpicID(ctx, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return &domain.EpicDetail{
+		Epic:       *epic,
+		Risks:      risks,
+		RoleScores: roleScores,
+	}, nil
+}
+
+// GetEpicByNumber returns the epic with the given number. Numbers are
+// unique only within a team; when several teams use the number it returns
+// ErrAmbiguous, and GetEpicByNumberAndTeam must be used instead.
 func (r *Repository) GetEpicByNumber(ctx context.Context, number string) (*domain.Epic, error) {
 	op := "Repository.GetEpicByNumber"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT id, number, name, description, team_id, status,
+		final_score, scoring_deadline, created_at, updated_at
+		FROM epics WHERE number = $1 LIMIT 2`
+	rows, err := r.DB.QueryContext(ctx, query, number)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var epics []domain.Epic
+	for rows.Next() {
+		var epic domain.Epic
+		if err := rows.Scan(&epic.ID, &epic.Number, &epic.Name, &epic.Description,
+			&epic.TeamID, &epic.Status,
+			&epic.FinalScore, &epic.ScoringDeadline, &epic.CreatedAt, &epic.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		epics = append(epics, epic)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	switch len(epics) {
+	case 0:
+		return nil, fmt.Errorf("%s: %w", op, ErrNotFound)
+	case 1:
+		return &epics[0], nil
+	default:
+		return nil, fmt.Errorf("%s: %w", op, ErrAmbiguous)
+	}
+}
+
+// GetEpicByNumberAndTeam returns the epic with the given number in a team.
+func (r *Repository) GetEpicByNumberAndTeam(ctx context.Context, number string, teamID uuid.UUID) (*domain.Epic, error) {
+	op := "Repository.GetEpicByNumberAndTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var epic domain.Epic
 	query := `SELECT id, number, name, description, team_id, status,
-		final_score, created_at, updated_at
-		FROM epics WHERE number = $1`
-	err := r.DB.QueryRowContext(ctx, query, number).
+		final_score, scoring_deadline, created_at, updated_at
+		FROM epics WHERE number = $1 AND team_id = $2`
+	err := r.DB.QueryRowContext(ctx, query, number, teamID).
 		Scan(&epic.ID, &epic.Number, &epic.Name, &epic.Description,
 			&epic.TeamID, &epic.Status,
-			&epic.FinalScore, &epic.CreatedAt, &epic.UpdatedAt)
+			&epic.FinalScore, &epic.ScoringDeadline, &epic.CreatedAt, &epic.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &epic, nil
 }
@@ -70,9 +155,11 @@ func (r *Repository) GetEpicByNumber(ctx context.Context, number string) (*domai
 // GetEpicsByTeamIDAndStatus returns epics filtered by team and status.
 func (r *Repository) GetEpicsByTeamIDAndStatus(ctx context.Context, teamID uuid.UUID, status domain.Status) ([]domain.Epic, error) {
 	op := "Repository.GetEpicsByTeamIDAndStatus"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var epics []domain.Epic
 	query := `SELECT id, number, name, description, team_id, status,
-		final_score, created_at, updated_at
+		final_score, scoring_deadline, created_at, updated_at
 		FROM epics WHERE team_id = $1 AND status = $2
 		ORDER BY number`
 	rows, err := r.DB.QueryContext(ctx, query, teamID, string(status))
@@ -85,7 +172,35 @@ func (r *Repository) GetEpicsByTeamIDAndStatus(ctx context.Context, teamID uuid.
 		var e domain.Epic
 		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
 			&e.TeamID, &e.Status,
-			&e.FinalScore, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			&e.FinalScore, &e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		epics = append(epics, e)
+	}
+	return epics, nil
+}
+
+// GetEpicsByTeamID returns every epic of a team regardless of status.
+func (r *Repository) GetEpicsByTeamID(ctx context.Context, teamID uuid.UUID) ([]domain.Epic, error) {
+	op := "Repository.GetEpicsByTeamID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var epics []domain.Epic
+	query := `SELECT id, number, name, description, team_id, status,
+		final_score, scoring_deadline, created_at, updated_at
+		FROM epics WHERE team_id = $1
+		ORDER BY number`
+	rows, err := r.DB.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e domain.Epic
+		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
+			&e.TeamID, &e.Status,
+			&e.FinalScore, &e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
 		epics = append(epics, e)
@@ -93,49 +208,298 @@ func (r *Repository) GetEpicsByTeamIDAndStatus(ctx context.Context, teamID uuid.
 	return epics, nil
 }
 
-// UpdateEpicStatus sets the status of an epic.
+// UpdateEpicStatus changes the status of an epic and records the
+// transition in its history. Starting scoring also records when it started.
 func (r *Repository) UpdateEpicStatus(ctx context.Context, epicID uuid.UUID, status domain.Status) error {
 	op := "Repository.UpdateEpicStatus"
-	query := `UPDATE epics SET status = $1, updated_at = CURRENT_TIMESTAMP
-		WHERE id = $2`
-	_, err := r.DB.ExecContext(ctx, query, string(status), epicID)
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	err := r.withRetry(ctx, func() error {
+		return r.withTx(ctx, func(tx *sqlx.Tx) error {
+			if err := recordStatusChange(ctx, tx, epicID, status); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx,
+				`UPDATE epics SET status = $1, updated_at = CURRENT_TIMESTAMP,
+				scoring_started_at = CASE WHEN $1 = 'SCORING'
+					THEN CURRENT_TIMESTAMP ELSE scoring_started_at END
+				WHERE id = $2`,
+				string(status), epicID)
+			return err
+		})
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
 }
 
-// SetEpicFinalScore sets the final score and status of an epic.
-func (r *Repository) SetEpicFinalScore(ctx context.Context, epicID uuid.UUID, score float64) error {
-	op := "Repository.SetEpicFinalScore"
-	query := `UPDATE epics SET final_score = $1, status = $2,
+// StartEpicScoring moves a NEW epic and all its risks to SCORING in one
+// transaction and returns the number of risks. It reports false, changing
+// nothing, if the epic is not NEW.
+func (r *Repository) StartEpicScoring(ctx context.Context, epicID uuid.UUID) (int, bool, error) {
+	op := "Repository.StartEpicScoring"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var risks int
+	var started bool
+	err := r.withRetry(ctx, func() error {
+		return r.withTx(ctx, func(tx *sqlx.Tx) error {
+			var err error
+			risks, started, err = startScoringTx(ctx, tx, epicID)
+			return err
+		})
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	return risks, started, nil
+}
+
+// StartTeamScoring moves every NEW epic of a team and their risks to
+// SCORING in one transaction. It returns the numbers of the started epics,
+// ordered by number, and how many risks they have in total.
+func (r *Repository) StartTeamScoring(ctx context.Context, teamID uuid.UUID) ([]string, int, error) {
+	op := "Repository.StartTeamScoring"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var numbers []string
+	var risks int
+	err := r.withRetry(ctx, func() error {
+		numbers, risks = nil, 0
+		return r.withTx(ctx, func(tx *sqlx.Tx) error {
+			// All rows are read before the updates: the connection
+			// cannot run statements while a result set is open.
+			rows, err := tx.QueryContext(ctx,
+				`SELECT id, number FROM epics
+				WHERE team_id = $1 AND status = $2
+				ORDER BY number FOR UPDATE`,
+				teamID, string(domain.StatusNew))
+			if err != nil {
+				return err
+			}
+			var epics []domain.Epic
+			for rows.Next() {
+				var e domain.Epic
+				if err := rows.Scan(&e.ID, &e.Number); err != nil {
+					rows.Close()
+					return fmt.Errorf("scan: %w", err)
+				}
+				epics = append(epics, e)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			for _, e := range epics {
+				n, started, err := startScoringTx(ctx, tx, e.ID)
+				if err != nil {
+					return err
+				}
+				if started {
+					numbers = append(numbers, e.Number)
+					risks += n
+				}
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return numbers, risks, nil
+}
+
+// startScoringTx moves a NEW epic and its risks to SCORING within tx and
+// records the transition. It returns the number of risks, or false if the
+// epic is not NEW.
+func startScoringTx(ctx context.Context, tx *sqlx.Tx, epicID uuid.UUID) (int, bool, error) {
+	var status domain.Status
+	err := tx.QueryRowContext(ctx,
+		`SELECT status FROM epics WHERE id = $1 FOR UPDATE`, epicID).Scan(&status)
+	if err != nil {
+		return 0, false, notFound(err)
+	}
+	if status != domain.StatusNew {
+		return 0, false, nil
+	}
+	if err := recordStatusChange(ctx, tx, epicID, domain.StatusScoring); err != nil {
+		return 0, false, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE epics SET status = $1, scoring_started_at = CURRENT_TIMESTAMP,
 		updated_at = CURRENT_TIMESTAMP
-		WHERE id = $3`
-	_, err := r.DB.ExecContext(ctx, query, score, string(domain.StatusScored), epicID)
+		WHERE id = $2`,
+		string(domain.StatusScoring), epicID); err != nil {
+		return 0, false, err
+	}
+	res, err := tx.ExecContext(ctx,
+		`UPDATE risks SET status = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE epic_id = $2`,
+		string(domain.StatusScoring), epicID)
+	if err != nil {
+		return 0, false, err
+	}
+	risks, err := res.RowsAffected()
+	if err != nil {
+		return 0, false, err
+	}
+	return int(risks), true, nil
+}
+
+// SetEpicFinalScore sets the final score and status of an epic and
+// records the transition in its history. It returns when scoring was
+// started, nil if unknown, and when it completed.
+func (r *Repository) SetEpicFinalScore(ctx context.Context, epicID uuid.UUID, score float64) (*time.Time, time.Time, error) {
+	op := "Repository.SetEpicFinalScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var startedAt *time.Time
+	var scoredAt time.Time
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if err := recordStatusChange(ctx, tx, epicID, domain.StatusScored); err != nil {
+			return err
+		}
+		return tx.QueryRowContext(ctx,
+			`UPDATE epics SET final_score = $1, status = $2,
+			scored_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $3
+			RETURNING scoring_started_at, scored_at`,
+			score, string(domain.StatusScored), epicID).
+			Scan(&startedAt, &scoredAt)
+	})
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%s: %w", op, notFound(err))
+	}
+	return startedAt, scoredAt, nil
+}
+
+// recordStatusChange adds a transition of the epic to status to its
+// history, unless the epic already has that status. It must run in the
+// transaction that changes the status; the epic row stays locked until
+// the transaction ends so concurrent changes are recorded in order.
+func recordStatusChange(ctx context.Context, tx *sqlx.Tx, epicID uuid.UUID, status domain.Status) error {
+	var from domain.Status
+	err := tx.QueryRowContext(ctx,
+		`SELECT status FROM epics WHERE id = $1 FOR UPDATE`, epicID).Scan(&from)
+	if err != nil {
+		return notFound(err)
+	}
+	if from == status {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO epic_status_history (id, epic_id, from_status, to_status)
+		VALUES ($1, $2, $3, $4)`,
+		uuid.New(), epicID, string(from), string(status))
+	return err
+}
+
+// GetEpicStatusHistory returns the status transitions of an epic, oldest
+// first.
+func (r *Repository) GetEpicStatusHistory(ctx context.Context, epicID uuid.UUID) ([]domain.EpicStatusChange, error) {
+	op := "Repository.GetEpicStatusHistory"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT from_status, to_status, changed_at
+		FROM epic_status_history WHERE epic_id = $1
+		ORDER BY changed_at`
+	rows, err := r.DB.QueryContext(ctx, query, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var history []domain.EpicStatusChange
+	for rows.Next() {
+		var c domain.EpicStatusChange
+		if err := rows.Scan(&c.From, &c.To, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		history = append(history, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return history, nil
+}
+
+// SetEpicScoringDeadline sets or clears (nil) the scoring deadline of an epic.
+func (r *Repository) SetEpicScoringDeadline(ctx context.Context, epicID uuid.UUID, deadline *time.Time) error {
+	op := "Repository.SetEpicScoringDeadline"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `UPDATE epics SET scoring_deadline = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2`
+	_, err := r.DB.ExecContext(ctx, query, deadline, epicID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
 }
 
+// GetExpiredScoringEpics returns SCORING epics whose deadline is at or
+// before now.
+func (r *Repository) GetExpiredScoringEpics(ctx context.Context, now time.Time) ([]domain.Epic, error) {
+	op := "Repository.GetExpiredScoringEpics"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var epics []domain.Epic
+	query := `SELECT id, number, name, description, team_id, status,
+		final_score, scoring_deadline, created_at, updated_at
+		FROM epics WHERE status = $1 AND scoring_deadline <= $2
+		ORDER BY scoring_deadline`
+	rows, err := r.DB.QueryContext(ctx, query, string(domain.StatusScoring), now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e domain.Epic
+		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
+			&e.TeamID, &e.Status, &e.FinalScore,
+			&e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		epics = append(epics, e)
+	}
+	return epics, nil
+}
+
 // GetUnscoredEpicsByUser returns SCORING epics in a team where the user
 // still has outstanding work: either the epic effort is not yet scored,
 // or one or more of its SCORING risks are not scored by this user.
 func (r *Repository) GetUnscoredEpicsByUser(ctx context.Context, userID uuid.UUID, teamID uuid.UUID) ([]domain.Epic, error) {
 	op := "Repository.GetUnscoredEpicsByUser"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `SELECT e.id, e.number, e.name, e.description,
 		e.team_id, e.status, e.final_score,
-		e.created_at, e.updated_at
+		e.scoring_deadline, e.created_at, e.updated_at
 		FROM epics e
 		WHERE e.team_id = $1 AND e.status = $2
 		AND (
-			-- effort not yet scored by this user
-			NOT EXISTS (
-				SELECT 1 FROM epic_scores es
-				WHERE es.epic_id = e.id AND es.user_id = $3
+			-- effort not yet scored by this user, who holds a required role
+			-- or the epic has none
+			(
+				NOT EXISTS (
+					SELECT 1 FROM epic_scores es
+					WHERE es.epic_id = e.id AND es.user_id = $3
+				)
+				AND (
+					NOT EXISTS (
+						SELECT 1 FROM epic_required_roles er WHERE er.epic_id = e.id
+					)
+					OR EXISTS (
+						SELECT 1 FROM epic_required_roles er
+						JOIN user_roles ur ON ur.role_id = er.role_id
+						WHERE er.epic_id = e.id AND ur.user_id = $3
+					)
+				)
 			)
 			OR
-			-- at least one SCORING risk not scored by this user
+			-- at least one SCORING risk neither scored nor skipped by this user
 			EXISTS (
 				SELECT 1 FROM risks ri
 				WHERE ri.epic_id = e.id AND ri.status = $2
@@ -143,6 +507,10 @@ func (r *Repository) GetUnscoredEpicsByUser(ctx context.Context, userID uuid.UUI
 					SELECT 1 FROM risk_scores rs
 					WHERE rs.risk_id = ri.id AND rs.user_id = $3
 				)
+				AND NOT EXISTS (
+					SELECT 1 FROM risk_skips sk
+					WHERE sk.risk_id = ri.id AND sk.user_id = $3
+				)
 			)
 		)
 		ORDER BY e.number`
@@ -157,7 +525,7 @@ func (r *Repository) GetUnscoredEpicsByUser(ctx context.Context, userID uuid.UUI
 		var e domain.Epic
 		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
 			&e.TeamID, &e.Status, &e.FinalScore,
-			&e.CreatedAt, &e.UpdatedAt); err != nil {
+			&e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
 		epics = append(epics, e)
@@ -165,12 +533,50 @@ func (r *Repository) GetUnscoredEpicsByUser(ctx context.Context, userID uuid.UUI
 	return epics, nil
 }
 
+// GetScoringEpicsScoredByUser returns the SCORING epics of the user's
+// teams whose effort the user has already scored, ordered by number.
+func (r *Repository) GetScoringEpicsScoredByUser(ctx context.Context, userID uuid.UUID) ([]domain.Epic, error) {
+	op := "Repository.GetScoringEpicsScoredByUser"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT e.id, e.number, e.name, e.description,
+		e.team_id, e.status, e.final_score,
+		e.scoring_deadline, e.created_at, e.updated_at
+		FROM epics e
+		JOIN epic_scores es ON es.epic_id = e.id AND es.user_id = $1
+		JOIN user_teams ut ON ut.team_id = e.team_id AND ut.user_id = $1
+		WHERE e.status = $2
+		ORDER BY e.number`
+	rows, err := r.DB.QueryContext(ctx, query, userID, string(domain.StatusScoring))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var epics []domain.Epic
+	for rows.Next() {
+		var e domain.Epic
+		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
+			&e.TeamID, &e.Status, &e.FinalScore,
+			&e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		epics = append(epics, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return epics, nil
+}
+
 // GetAllEpics returns every epic ordered by number.
 func (r *Repository) GetAllEpics(ctx context.Context) ([]domain.Epic, error) {
 	op := "Repository.GetAllEpics"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var epics []domain.Epic
 	query := `SELECT id, number, name, description, team_id, status,
-		final_score, created_at, updated_at
+		final_score, scoring_deadline, created_at, updated_at
 		FROM epics ORDER BY number`
 	rows, err := r.DB.QueryContext(ctx, query)
 	if err != nil {
@@ -182,7 +588,7 @@ func (r *Repository) GetAllEpics(ctx context.Context) ([]domain.Epic, error) {
 		var e domain.Epic
 		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
 			&e.TeamID, &e.Status, &e.FinalScore,
-			&e.CreatedAt, &e.UpdatedAt); err != nil {
+			&e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
 		epics = append(epics, e)
@@ -193,9 +599,11 @@ func (r *Repository) GetAllEpics(ctx context.Context) ([]domain.Epic, error) {
 // GetEpicsByStatus returns all epics with a given status.
 func (r *Repository) GetEpicsByStatus(ctx context.Context, status domain.Status) ([]domain.Epic, error) {
 	op := "Repository.GetEpicsByStatus"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var epics []domain.Epic
 	query := `SELECT id, number, name, description, team_id, status,
-		final_score, created_at, updated_at
+		final_score, scoring_deadline, created_at, updated_at
 		FROM epics WHERE status = $1 ORDER BY number`
 	rows, err := r.DB.QueryContext(ctx, query, string(status))
 	if err != nil {
@@ -207,7 +615,7 @@ func (r *Repository) GetEpicsByStatus(ctx context.Context, status domain.Status)
 		var e domain.Epic
 		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
 			&e.TeamID, &e.Status, &e.FinalScore,
-			&e.CreatedAt, &e.UpdatedAt); err != nil {
+			&e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
 		epics = append(epics, e)
@@ -215,13 +623,502 @@ func (r *Repository) GetEpicsByStatus(ctx context.Context, status domain.Status)
 	return epics, nil
 }
 
-// DeleteEpic permanently removes an epic and all related data (cascade).
-func (r *Repository) DeleteEpic(ctx context.Context, epicID uuid.UUID) error {
-	op := "Repository.DeleteEpic"
-	query := `DELETE FROM epics WHERE id = $1`
-	_, err := r.DB.ExecContext(ctx, query, epicID)
+// SetEpicRequiredRoles replaces the roles whose members estimate the
+// epic's effort. An empty list lets every team member estimate it.
+func (r *Repository) SetEpicRequiredRoles(ctx context.Context, epicID uuid.UUID, roleIDs []uuid.UUID) error {
+	op := "Repository.SetEpicRequiredRoles"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM epic_required_roles WHERE epic_id = $1`, epicID); err != nil {
+			return err
+		}
+		for _, roleID := range roleIDs {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO epic_required_roles (epic_id, role_id) VALUES ($1, $2)
+				ON CONFLICT DO NOTHING`, epicID, roleID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
 }
+
+// GetEpicRequiredRoles returns the roles whose members estimate the epic's
+// effort, ordered by name. An empty result means every team member does.
+func (r *Repository) GetEpicRequiredRoles(ctx context.Context, epicID uuid.UUID) ([]domain.Role, error) {
+	op := "Repository.GetEpicRequiredRoles"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT ro.id, ro.name, ro.description
+		FROM epic_required_roles er
+		JOIN roles ro ON ro.id = er.role_id
+		WHERE er.epic_id = $1
+		ORDER BY ro.name`
+	rows, err := r.DB.QueryContext(ctx, query, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var roles []domain.Role
+	for rows.Next() {
+		var role domain.Role
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// GetScoringProgress returns the scoring progress of the given epics keyed
+// by epic ID in one query. IDs without an epic are absent from the map.
+func (r *Repository) GetScoringProgress(ctx context.Context, epicIDs []uuid.UUID) (map[uuid.UUID]domain.ScoringProgress, error) {
+	op := "Repository.GetScoringProgress"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	strIDs := make([]string, len(epicIDs))
+	for i, id := range epicIDs {
+		strIDs[i] = id.String()
+	}
+	query := `SELECT e.id, COALESCE(e.scoring_started_at, e.updated_at),
+		(SELECT COUNT(*) FROM user_teams ut WHERE ut.team_id = e.team_id
+			AND (
+				NOT EXISTS (SELECT 1 FROM epic_required_roles er WHERE er.epic_id = e.id)
+				OR EXISTS (
+					SELECT 1 FROM epic_required_roles er
+					JOIN user_roles ur ON ur.role_id = er.role_id
+					WHERE er.epic_id = e.id AND ur.user_id = ut.user_id
+				)
+			)),
+		(SELECT COUNT(*) FROM epic_scores es WHERE es.epic_id = e.id
+			AND (
+				NOT EXISTS (SELECT 1 FROM epic_required_roles er WHERE er.epic_id = e.id)
+				OR es.role_id IN (SELECT role_id FROM epic_required_roles WHERE epic_id = e.id)
+			)),
+		(SELECT COUNT(*) FROM risks r WHERE r.epic_id = e.id),
+		(SELECT COUNT(*) FROM risks r WHERE r.epic_id = e.id AND r.status = $2)
+		FROM epics e WHERE e.id = ANY($1::uuid[])`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(strIDs), string(domain.StatusScored))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	progress := make(map[uuid.UUID]domain.ScoringProgress, len(epicIDs))
+	for rows.Next() {
+		var p domain.ScoringProgress
+		if err := rows.Scan(&p.EpicID, &p.StartedAt, &p.Members,
+			&p.EffortScores, &p.Risks, &p.RisksScored); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		progress[p.EpicID] = p
+	}
+	return progress, nil
+}
+
+// GetScoringTimeStats returns, per team, how long scoring of its scored
+// epics took from starting to completing. Epics scored before the times
+// were recorded are left out; teams without such epics are omitted.
+func (r *Repository) GetScoringTimeStats(ctx context.Context) ([]domain.TeamScoringTime, error) {
+	op := "Repository.GetScoringTimeStats"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT t.id, t.name, COUNT(*),
+		EXTRACT(EPOCH FROM AVG(e.scored_at - e.scoring_started_at)),
+		EXTRACT(EPOCH FROM MIN(e.scored_at - e.scoring_started_at)),
+		EXTRACT(EPOCH FROM MAX(e.scored_at - e.scoring_started_at))
+		FROM epics e JOIN teams t ON t.id = e.team_id
+		WHERE e.status = $1 AND e.scoring_started_at IS NOT NULL
+			AND e.scored_at >= e.scoring_started_at
+		GROUP BY t.id, t.name
+		ORDER BY t.name`
+	rows, err := r.DB.QueryContext(ctx, query, string(domain.StatusScored))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var stats []domain.TeamScoringTime
+	for rows.Next() {
+		var st domain.TeamScoringTime
+		var avg, minimum, maximum float64
+		if err := rows.Scan(&st.TeamID, &st.TeamName, &st.Epics, &avg, &minimum, &maximum); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		st.Avg = secondsToDuration(avg)
+		st.Min = secondsToDuration(minimum)
+		st.Max = secondsToDuration(maximum)
+		stats = append(stats, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return stats, nil
+}
+
+// secondsToDuration converts seconds as returned by EXTRACT(EPOCH ...).
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// GetEpicsPage returns up to limit epics starting at offset, ordered by
+// number, together with the total number of matching epics. An empty
+// status matches epics in any status.
+func (r *Repository) GetEpicsPage(
+	ctx context.Context,
+	limit, offset int,
+	status domain.Status,
+) ([]domain.Epic, int, error) {
+	op := "Repository.GetEpicsPage"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var total int
+	countQuery := `SELECT COUNT(*) FROM epics WHERE ($1 = '' OR status = $1)`
+	if err := r.DB.QueryRowContext(ctx, countQuery, string(status)).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("%s: count: %w", op, err)
+	}
+
+	var epics []domain.Epic
+	query := `SELECT id, number, name, description, team_id, status,
+		final_score, scoring_deadline, created_at, updated_at
+		FROM epics WHERE ($1 = '' OR status = $1)
+		ORDER BY number, id
+		LIMIT $2 OFFSET $3`
+	rows, err := r.DB.QueryContext(ctx, query, string(status), limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e domain.Epic
+		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
+			&e.TeamID, &e.Status, &e.FinalScore,
+			&e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, 0, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		epics = append(epics, e)
+	}
+	return epics, total, nil
+}
+
+// CountEpicsByStatusForTeam returns the number of a team's epics in each
+// status. Statuses without epics are absent from the map.
+func (r *Repository) CountEpicsByStatusForTeam(ctx context.Context, teamID uuid.UUID) (map[domain.Status]int, error) {
+	op := "Repository.CountEpicsByStatusForTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	counts := make(map[domain.Status]int)
+	query := `SELECT status, COUNT(*) FROM epics WHERE team_id = $1 GROUP BY status`
+	rows, err := r.DB.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status domain.Status
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		counts[status] = n
+	}
+	return counts, nil
+}
+
+// CountAllEpicsByStatus returns the number of epics in each status across
+// all teams. Statuses without epics are absent from the map.
+func (r *Repository) CountAllEpicsByStatus(ctx context.Context) (map[domain.Status]int, error) {
+	op := "Repository.CountAllEpicsByStatus"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	counts := make(map[domain.Status]int)
+	query := `SELECT status, COUNT(*) FROM epics GROUP BY status`
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var status domain.Status
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		counts[status] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return counts, nil
+}
+
+// GetSystemCounts counts teams, users, roles, risks and recorded scores in
+// one round trip.
+func (r *Repository) GetSystemCounts(ctx context.Context) (domain.SystemCounts, error) {
+	op := "Repository.GetSystemCounts"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var c domain.SystemCounts
+	query := `SELECT
+		(SELECT COUNT(*) FROM teams),
+		(SELECT COUNT(*) FROM users),
+		(SELECT COUNT(*) FROM roles),
+		(SELECT COUNT(*) FROM risks),
+		(SELECT COUNT(*) FROM epic_scores),
+		(SELECT COUNT(*) FROM risk_scores)`
+	err := r.DB.QueryRowContext(ctx, query).
+		Scan(&c.Teams, &c.Users, &c.Roles, &c.Risks, &c.EpicScores, &c.RiskScores)
+	if err != nil {
+		return c, fmt.Errorf("%s: %w", op, err)
+	}
+	return c, nil
+}
+
+// SearchEpics returns epics whose number, name or description contains
+// the query, case-insensitively, ordered by number.
+func (r *Repository) SearchEpics(ctx context.Context, query string) ([]domain.Epic, error) {
+	op := "Repository.SearchEpics"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var epics []domain.Epic
+	pattern := "%" + likeEscaper.Replace(query) + "%"
+	q := `SELECT id, number, name, description, team_id, status,
+		final_score, scoring_deadline, created_at, updated_at
+		FROM epics
+		WHERE number ILIKE $1 OR name ILIKE $1 OR description ILIKE $1
+		ORDER BY number`
+	rows, err := r.DB.QueryContext(ctx, q, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e domain.Epic
+		if err := rows.Scan(&e.ID, &e.Number, &e.Name, &e.Description,
+			&e.TeamID, &e.Status, &e.FinalScore,
+			&e.ScoringDeadline, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		epics = append(epics, e)
+	}
+	return epics, nil
+}
+
+// likeEscaper escapes LIKE wildcards so user input is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// GetExistingEpicNumbers returns which of the given epic numbers a team
+// already uses.
+func (r *Repository) GetExistingEpicNumbers(ctx context.Context, teamID uuid.UUID, numbers []string) ([]string, error) {
+	op := "Repository.GetExistingEpicNumbers"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT number FROM epics WHERE team_id = $1 AND number = ANY($2) ORDER BY number`
+	rows, err := r.DB.QueryContext(ctx, query, teamID, pq.Array(numbers))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var existing []string
+	for rows.Next() {
+		var n string
+		if err := rows.Scan(&n); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		existing = append(existing, n)
+	}
+	return existing, nil
+}
+
+// ImportEpics creates the given epics for a team in one transaction.
+// With upsert set, epics of the team whose number already exists get their
+// name and description updated instead. Returns created and updated counts.
+func (r *Repository) ImportEpics(ctx context.Context, teamID uuid.UUID, epics []domain.Epic, upsert bool) (int, int, error) {
+	op := "Repository.ImportEpics"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var created, updated int
+
+	for _, e := range epics {
+		if err := r.checkEpicText(e.Name, e.Description); err != nil {
+			return 0, 0, fmt.Errorf("%s: #%s: %w", op, e.Number, err)
+		}
+	}
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		for _, e := range epics {
+			if upsert {
+				res, err := tx.ExecContext(ctx,
+					`UPDATE epics SET name = $2, description = $3,
+					updated_at = CURRENT_TIMESTAMP
+					WHERE number = $1 AND team_id = $4`,
+					e.Number, e.Name, e.Description, teamID)
+				if err != nil {
+					return fmt.Errorf("update #%s: %w", e.Number, err)
+				}
+				if n, err := res.RowsAffected(); err == nil && n > 0 {
+					updated++
+					continue
+				}
+			}
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO epics (id, number, name, description, team_id, status)
+				VALUES ($1, $2, $3, $4, $5, $6)`,
+				uuid.New(), e.Number, e.Name, e.Description, teamID,
+				string(domain.StatusNew)); err != nil {
+				if isUniqueViolation(err) {
+					err = ErrAlreadyExists
+				}
+				return fmt.Errorf("insert #%s: %w", e.Number, err)
+			}
+			created++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return created, updated, nil
+}
+
+// CloneEpic copies an epic into a team under a new number in one
+// transaction. The copy starts in NEW with fresh IDs and keeps the name,
+// description and required roles; its risks are copied as NEW without
+// scores. It returns the copy and the number of risks copied.
+// Returns ErrAlreadyExists when the target team already has an epic with
+// newNumber and ErrNotFound when the source epic does not.
+func (r *Repository) CloneEpic(
+	ctx context.Context,
+	srcID uuid.UUID,
+	newNumber string,
+	targetTeamID uuid.UUID,
+) (*domain.Epic, int, error) {
+	op := "Repository.CloneEpic"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	epic := &domain.Epic{
+		ID:     uuid.New(),
+		Number: newNumber,
+		TeamID: targetTeamID,
+		Status: domain.StatusNew,
+	}
+	var risksCopied int64
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM epics WHERE number = $1 AND team_id = $2)`,
+			newNumber, targetTeamID).
+			Scan(&exists); err != nil {
+			return fmt.Errorf("check number: %w", err)
+		}
+		if exists {
+			return ErrAlreadyExists
+		}
+
+		err := tx.QueryRowContext(ctx,
+			`INSERT INTO epics (id, number, name, description, team_id, status)
+			SELECT $2, $3, name, description, $4, $5 FROM epics WHERE id = $1
+			RETURNING name, description, created_at, updated_at`,
+			srcID, epic.ID, newNumber, targetTeamID, string(domain.StatusNew)).
+			Scan(&epic.Name, &epic.Description, &epic.CreatedAt, &epic.UpdatedAt)
+		if err != nil {
+			if isUniqueViolation(err) {
+				return ErrAlreadyExists
+			}
+			return fmt.Errorf("copy epic: %w", notFound(err))
+		}
+
+		res, err := tx.ExecContext(ctx,
+			`INSERT INTO risks (description, epic_id, status, importance)
+			SELECT description, $2, $3, importance FROM risks
+			WHERE epic_id = $1 ORDER BY created_at`,
+			srcID, epic.ID, string(domain.StatusNew))
+		if err != nil {
+			return fmt.Errorf("copy risks: %w", err)
+		}
+		if risksCopied, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("copy risks: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO epic_required_roles (epic_id, role_id)
+			SELECT $2, role_id FROM epic_required_roles WHERE epic_id = $1`,
+			srcID, epic.ID); err != nil {
+			return fmt.Errorf("copy required roles: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return epic, int(risksCopied), nil
+}
+
+// DeleteEpicTx permanently removes an epic with its risks and scores in one
+// transaction and returns what it removed for RestoreEpic. Dependent rows
+// are deleted explicitly so nothing is left behind even where the schema
+// lacks ON DELETE CASCADE.
+func (r *Repository) DeleteEpicTx(ctx context.Context, epicID uuid.UUID) (domain.EpicSnapshot, error) {
+	op := "Repository.DeleteEpicTx"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var snap domain.EpicSnapshot
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var err error
+		// Locked so no score or risk is added between capture and delete.
+		if err = execAll(ctx, tx, []any{epicID},
+			`SELECT 1 FROM epics WHERE id = $1 FOR UPDATE`,
+			`SELECT 1 FROM risks WHERE epic_id = $1 FOR UPDATE`,
+		); err != nil {
+			return err
+		}
+		if snap.Tables, err = captureRows(ctx, tx, epicSnapshotTables, epicID); err != nil {
+			return err
+		}
+		return execAll(ctx, tx, []any{epicID},
+			`DELETE FROM risk_scores
+			WHERE risk_id IN (SELECT id FROM risks WHERE epic_id = $1)`,
+			`DELETE FROM risk_skips
+			WHERE risk_id IN (SELECT id FROM risks WHERE epic_id = $1)`,
+			`DELETE FROM risks WHERE epic_id = $1`,
+			`DELETE FROM epic_scores WHERE epic_id = $1`,
+			`DELETE FROM epic_role_scores WHERE epic_id = $1`,
+			`DELETE FROM epic_required_roles WHERE epic_id = $1`,
+			`DELETE FROM epic_status_history WHERE epic_id = $1`,
+			`DELETE FROM epic_comments WHERE epic_id = $1`,
+			`DELETE FROM epic_watchers WHERE epic_id = $1`,
+			`DELETE FROM epics WHERE id = $1`,
+		)
+	})
+	if err != nil {
+		return domain.EpicSnapshot{}, fmt.Errorf("%s: %w", op, err)
+	}
+	return snap, nil
+}
+
+// checkEpicText validates the name and description of a new epic against
+// the configured limits.
+func (r *Repository) checkEpicText(name, description string) error {
+	switch {
+	case name == "":
+		return fmt.Errorf("empty name: %w", ErrInvalidInput)
+	case config.ExceedsLimit(name, r.limits.EpicName):
+		return fmt.Errorf("name longer than %d characters: %w", r.limits.EpicName, ErrInvalidInput)
+	case config.ExceedsLimit(description, r.limits.Description):
+		return fmt.Errorf("description longer than %d characters: %w", r.limits.Description, ErrInvalidInput)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateEpicConcurrentSameNumber(t *testing.T) {
+	repo := openTestRepo(t)
+	team := uuid.New()
+	if _, err := repo.DB.Exec(`INSERT INTO teams (id, name) VALUES ($1, 'Team')`, team); err != nil {
+		t.Fatalf("seed team: %v", err)
+	}
+
+	const admins = 5
+	errs := make([]error, admins)
+	var wg sync.WaitGroup
+	for i := range admins {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = repo.CreateEpic(context.Background(), "EP-1", "Login", "", team)
+		}()
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, ErrAlreadyExists):
+			t.Errorf("CreateEpic() error = %v, want nil or ErrAlreadyExists", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("created %d epics, want 1", created)
+	}
+}
@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// ErrNotFound is returned when a requested row does not exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists is returned when an insert violates a unique constraint.
+var ErrAlreadyExists = errors.New("already exists")
+
+// ErrAmbiguous is returned when a lookup by a value that is not unique
+// matches more than one row.
+var ErrAmbiguous = errors.New("ambiguous")
+
+// ErrWrongStatus is returned when an epic is not in the status an
+// operation requires.
+var ErrWrongStatus = errors.New("wrong status")
+
+// ErrInvalidInput is returned when a value is rejected before it reaches
+// the database.
+var ErrInvalidInput = errors.New("invalid input")
+
+// uniqueViolation is the PostgreSQL error code for unique_violation.
+const uniqueViolation = "23505"
+
+// isUniqueViolation reports whether err is a PostgreSQL unique violation.
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// notFound translates sql.ErrNoRows into ErrNotFound so that callers can
+// tell a missing row from a failed query. Other errors pass through.
+func notFound(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// affectedOne returns ErrNotFound when an update or delete matched no row.
+func affectedOne(op string, res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: %w", op, ErrNotFound)
+	}
+	return nil
+}
@@ -3,10 +3,11 @@ package repositories
 import (
 	"EpicScoreBot/internal/config"
 	"EpicScoreBot/internal/migrator"
-	"EpicScoreBot/internal/utils/logger/sl"
 	"context"
+	"database/sql"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
@@ -17,49 +18,145 @@ type Repository struct {
 	DB     *sqlx.DB
 	log    *slog.Logger
 	schema string
+	retry  retryPolicy
+	// queryTimeout bounds each repository call; 0 means no limit.
+	queryTimeout time.Duration
+	limits       config.LimitsConfig
 }
 
 // New creates a new repository, connects to the database, and runs migrations.
-func New(logger *slog.Logger, cfg *config.Config) *Repository {
+func New(logger *slog.Logger, cfg *config.Config) (*Repository, error) {
 	op := "repositories.New()"
 	log := logger.With(
 		slog.String("op", op))
 
-	username := cfg.DBConfig.User
-	password := cfg.DBConfig.Password
-	dbName := cfg.DBConfig.Name
-	dbHost := cfg.DBConfig.Host
-	dbPort := cfg.DBConfig.Port
 	schema := cfg.DBConfig.Schema
 
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s dbname=%s sslmode=disable password=%s search_path=%s",
-		dbHost, dbPort, username, dbName, password, schema)
-
-	conn, err := sqlx.Connect("postgres", dsn)
+	conn, err := connect(cfg.DBConfig)
 	if err != nil {
-		log.Error("error connecting to database", sl.Err(err))
-		panic("error connecting to database")
-	}
-
-	if err := conn.Ping(); err != nil {
-		log.Error("error pinging database", sl.Err(err))
-		panic("error pinging database")
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Debug("sqlx connected to database")
+	log.Debug("sqlx connected to database",
+		slog.Int("max_open_conns", cfg.DBConfig.MaxOpenConns),
+		slog.Int("max_idle_conns", cfg.DBConfig.MaxIdleConns),
+		slog.Duration("conn_max_lifetime", cfg.DBConfig.ConnMaxLifetime))
 
 	m := migrator.NewMigrator(conn, log, schema)
 	if err := m.Run(); err != nil {
-		log.Error("error running database migrations", sl.Err(err))
-		panic("error running database migrations")
+		conn.Close()
+		return nil, fmt.Errorf("%s: migrations: %w", op, err)
 	}
 
-	return &Repository{
+	repo := &Repository{
 		DB:     conn,
 		log:    log,
 		schema: schema,
+		retry: retryPolicy{
+			maxRetries: cfg.DBConfig.MaxRetries,
+			baseDelay:  cfg.DBConfig.RetryDelay,
+		},
+		queryTimeout: cfg.DBConfig.QueryTimeout,
+		limits:       cfg.Limits,
+	}
+
+	created, err := repo.SeedRoles(context.Background(), cfg.DefaultRoles)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%s: seed roles: %w", op, err)
+	}
+	if created > 0 {
+		log.Info("default roles created", slog.Int("count", created))
+	}
+
+	return repo, nil
+}
+
+// PendingMigrations connects to the database and returns the migrations
+// New would apply, without applying them.
+func PendingMigrations(logger *slog.Logger, cfg *config.Config) ([]string, error) {
+	op := "repositories.PendingMigrations()"
+	conn, err := connect(cfg.DBConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer conn.Close()
+
+	pending, err := migrator.NewMigrator(conn, logger, cfg.DBConfig.Schema).DryRun()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return pending, nil
+}
+
+// connect opens a connection pool configured from cfg and checks that the
+// database is reachable.
+func connect(cfg config.DBConfig) (*sqlx.DB, error) {
+	dsn, err := buildDSN(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database config: %w", err)
+	}
+
+	conn, err := sqlx.Connect("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+
+	applyPoolSettings(conn.DB, cfg)
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("ping: %w", err)
+	}
+	return conn, nil
+}
+
+// sslModes are the sslmode values accepted by lib/pq.
+var sslModes = map[string]bool{
+	"disable":     true,
+	"allow":       true,
+	"prefer":      true,
+	"require":     true,
+	"verify-ca":   true,
+	"verify-full": true,
+}
+
+// buildDSN assembles the connection string from the database config.
+func buildDSN(cfg config.DBConfig) (string, error) {
+	sslMode := cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+	if !sslModes[sslMode] {
+		return "", fmt.Errorf("unsupported sslmode %q", sslMode)
+	}
+	dsn := fmt.Sprintf(
+		"host=%s port=%s user=%s dbname=%s sslmode=%s password=%s search_path=%s",
+		cfg.Host, cfg.Port, cfg.User, cfg.Name, sslMode, cfg.Password, cfg.Schema)
+	if cfg.SSLRootCert != "" {
+		dsn += " sslrootcert=" + cfg.SSLRootCert
+	}
+	return dsn, nil
+}
+
+// applyPoolSettings configures the connection pool limits of db.
+func applyPoolSettings(db *sql.DB, cfg config.DBConfig) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+}
+
+// HealthCheck pings the database and reports how long the round trip
+// took.
+func (r *Repository) HealthCheck(ctx context.Context) (time.Duration, error) {
+	op := "Repository.HealthCheck"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	start := time.Now()
+	if err := r.DB.PingContext(ctx); err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
+	return time.Since(start), nil
 }
 
 // Shutdown closes the database connection.
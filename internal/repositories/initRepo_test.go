@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"EpicScoreBot/internal/config"
+)
+
+func TestBuildDSN(t *testing.T) {
+	base := config.DBConfig{
+		Host: "db", Port: "5432", User: "bot", Name: "epics", Password: "secret", Schema: "epic_score",
+	}
+	tests := []struct {
+		name     string
+		sslMode  string
+		rootCert string
+		want     string
+		wantErr  bool
+	}{
+		{name: "defaults to disable", want: "sslmode=disable"},
+		{name: "require", sslMode: "require", want: "sslmode=require"},
+		{name: "verify-full with a root cert", sslMode: "verify-full", rootCert: "/certs/ca.pem",
+			want: "sslmode=verify-full password=secret search_path=epic_score sslrootcert=/certs/ca.pem"},
+		{name: "unknown mode", sslMode: "on", wantErr: true},
+		{name: "modes are case-sensitive", sslMode: "Require", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.SSLMode = tt.sslMode
+			cfg.SSLRootCert = tt.rootCert
+			dsn, err := buildDSN(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildDSN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !strings.HasPrefix(dsn, "host=db port=5432 user=bot dbname=epics ") {
+				t.Errorf("buildDSN() = %q, want the connection fields first", dsn)
+			}
+			if !strings.Contains(dsn, tt.want) {
+				t.Errorf("buildDSN() = %q, want it to contain %q", dsn, tt.want)
+			}
+			if tt.rootCert == "" && strings.Contains(dsn, "sslrootcert") {
+				t.Errorf("buildDSN() = %q, want no sslrootcert", dsn)
+			}
+		})
+	}
+}
+
+// TestNewWithSingleConnection migrates through a pool of one connection,
+// which the migration lock holds for the whole run.
+func TestNewWithSingleConnection(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.DBConfig.MaxOpenConns = 1
+
+	type result struct {
+		repo *Repository
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		repo, err := New(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg)
+		done <- result{repo, err}
+	}()
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Fatalf("New() error = %v", res.err)
+		}
+		res.repo.DB.Exec(`DROP SCHEMA ` + cfg.DBConfig.Schema + ` CASCADE`)
+		res.repo.DB.Close()
+	case <-time.After(30 * time.Second):
+		t.Fatal("New() did not return with MaxOpenConns = 1")
+	}
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"EpicScoreBot/internal/migrator"
+
+	"github.com/lib/pq"
+)
+
+// migrationVersions lists the shipped migrations in the order they run.
+func migrationVersions(t *testing.T) []string {
+	t.Helper()
+	entries, err := os.ReadDir("../migrator/migrations")
+	if err != nil {
+		t.Fatalf("read migrations: %v", err)
+	}
+	var versions []string
+	for _, e := range entries {
+		if name, ok := strings.CutSuffix(e.Name(), ".sql"); ok {
+			versions = append(versions, name)
+		}
+	}
+	slices.Sort(versions)
+	return versions
+}
+
+func TestPendingMigrations(t *testing.T) {
+	cfg := testConfig(t)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	all := migrationVersions(t)
+
+	pending, err := PendingMigrations(log, cfg)
+	if err != nil {
+		t.Fatalf("PendingMigrations() on a new schema error = %v", err)
+	}
+	if !slices.Equal(pending, all) {
+		t.Errorf("pending on a new schema = %v, want all %d migrations", pending, len(all))
+	}
+
+	repo := openTestRepoWith(t, cfg)
+	if len(all) < 2 {
+		t.Fatalf("only %d migrations shipped", len(all))
+	}
+	forgotten := all[len(all)-2:]
+	if _, err := repo.DB.Exec(`DELETE FROM schema_migrations WHERE version = ANY($1)`,
+		pq.Array(forgotten)); err != nil {
+		t.Fatalf("forget migrations: %v", err)
+	}
+
+	pending, err = PendingMigrations(log, cfg)
+	if err != nil {
+		t.Fatalf("PendingMigrations() error = %v", err)
+	}
+	if !slices.Equal(pending, forgotten) {
+		t.Errorf("pending = %v, want %v", pending, forgotten)
+	}
+	var recorded int
+	if err := repo.DB.Get(&recorded, `SELECT COUNT(*) FROM schema_migrations`); err != nil {
+		t.Fatalf("count migrations: %v", err)
+	}
+	if recorded != len(all)-len(forgotten) {
+		t.Errorf("a dry run recorded migrations: %d rows, want %d", recorded, len(all)-len(forgotten))
+	}
+}
+
+func TestMigratorRunConcurrent(t *testing.T) {
+	cfg := testConfig(t)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	conn, err := connect(cfg.DBConfig)
+	if err != nil {
+		t.Fatalf("connect() error = %v", err)
+	}
+	t.Cleanup(func() {
+		conn.Exec(`DROP SCHEMA IF EXISTS ` + cfg.DBConfig.Schema + ` CASCADE`)
+		conn.Close()
+	})
+
+	// Two instances starting at once take turns through the advisory
+	// lock; the second finds everything applied.
+	const instances = 2
+	errs := make([]error, instances)
+	var wg sync.WaitGroup
+	for i := range instances {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = migrator.NewMigrator(conn, log, cfg.DBConfig.Schema).Run()
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("Run() #%d error = %v", i, err)
+		}
+	}
+
+	applied, err := migrator.NewMigrator(conn, log, cfg.DBConfig.Schema).GetAppliedMigrations()
+	if err != nil {
+		t.Fatalf("GetAppliedMigrations() error = %v", err)
+	}
+	slices.Sort(applied)
+	if want := migrationVersions(t); !slices.Equal(applied, want) {
+		t.Errorf("applied = %v, want each of %v once", applied, want)
+	}
+}
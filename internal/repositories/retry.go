@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"EpicScoreBot/internal/utils/logger/sl"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log/slog"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// retryPolicy controls how write methods retry transient database errors.
+type retryPolicy struct {
+	maxRetries int           // retries after the first attempt
+	baseDelay  time.Duration // delay before the first retry, doubled each time
+}
+
+// withRetry runs fn and retries it with exponential backoff while it fails
+// with a transient error, e.g. during a brief Postgres restart. Other errors
+// are returned immediately. fn must be safe to repeat, as the upserts and
+// status updates it wraps are.
+func (r *Repository) withRetry(ctx context.Context, fn func() error) error {
+	delay := r.retry.baseDelay
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.retry.maxRetries || !isTransient(err) {
+			return err
+		}
+		r.log.Warn("transient database error, retrying",
+			slog.Int("attempt", attempt+1), slog.Duration("delay", delay), sl.Err(err))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// transientCodes are PostgreSQL error codes worth retrying: the server is
+// shutting down or not yet accepting connections.
+var transientCodes = map[pq.ErrorCode]bool{
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// isTransient reports whether err is a connection-level failure that may
+// succeed when retried.
+func isTransient(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// Class 08 — connection exception.
+		return pqErr.Code.Class() == "08" || transientCodes[pqErr.Code]
+	}
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
@@ -0,0 +1,111 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func newRetryRepo(maxRetries int) *Repository {
+	return &Repository{
+		log:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		retry: retryPolicy{maxRetries: maxRetries, baseDelay: time.Millisecond},
+	}
+}
+
+func TestWithRetryRecoversFromTransientErrors(t *testing.T) {
+	r := newRetryRepo(3)
+	calls := 0
+	err := r.withRetry(context.Background(), func() error {
+		calls++
+		if calls <= 2 {
+			return fmt.Errorf("Repository.CreateEpicScore: %w", io.ErrUnexpectedEOF)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+}
+
+func TestWithRetryGivesUp(t *testing.T) {
+	r := newRetryRepo(2)
+	calls := 0
+	err := r.withRetry(context.Background(), func() error {
+		calls++
+		return &pq.Error{Code: "57P01"}
+	})
+	if err == nil {
+		t.Fatal("withRetry() error = nil, want the last transient error")
+	}
+	if calls != 3 {
+		t.Errorf("fn called %d times, want 3", calls)
+	}
+}
+
+func TestWithRetryDoesNotRetryOtherErrors(t *testing.T) {
+	r := newRetryRepo(3)
+	calls := 0
+	uniqueViolation := &pq.Error{Code: "23505"}
+	err := r.withRetry(context.Background(), func() error {
+		calls++
+		return uniqueViolation
+	})
+	if !errors.Is(err, uniqueViolation) {
+		t.Fatalf("withRetry() error = %v, want the unique violation", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
+
+func TestWithRetryStopsOnCancel(t *testing.T) {
+	r := newRetryRepo(3)
+	r.retry.baseDelay = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := r.withRetry(ctx, func() error {
+		calls++
+		return syscall.ECONNRESET
+	})
+	if !errors.Is(err, syscall.ECONNRESET) {
+		t.Fatalf("withRetry() error = %v, want ECONNRESET", err)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"connection exception class", &pq.Error{Code: "08006"}, true},
+		{"admin shutdown", &pq.Error{Code: "57P01"}, true},
+		{"unique violation", &pq.Error{Code: "23505"}, false},
+		{"wrapped EOF", fmt.Errorf("query: %w", io.ErrUnexpectedEOF), true},
+		{"connection refused", syscall.ECONNREFUSED, true},
+		{"not found", ErrNotFound, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransient(tt.err); got != tt.want {
+				t.Errorf("isTransient(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,28 +1,48 @@
 package repositories
 
 import (
+	"EpicScoreBot/internal/config"
 	"EpicScoreBot/internal/models/domain"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
 // CreateRisk inserts a new risk for an epic.
-func (r *Repository) CreateRisk(ctx context.Context, description string, epicID uuid.UUID) (*domain.Risk, error) {
+func (r *Repository) CreateRisk(
+	ctx context.Context,
+	description string,
+	epicID uuid.UUID,
+	importance domain.Importance,
+) (*domain.Risk, error) {
 	op := "Repository.CreateRisk"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	description = strings.TrimSpace(description)
+	switch {
+	case description == "":
+		return nil, fmt.Errorf("%s: empty description: %w", op, ErrInvalidInput)
+	case config.ExceedsLimit(description, r.limits.Description):
+		return nil, fmt.Errorf("%s: description longer than %d characters: %w",
+			op, r.limits.Description, ErrInvalidInput)
+	}
 	risk := &domain.Risk{
 		ID:          uuid.New(),
 		Description: description,
 		EpicID:      epicID,
 		Status:      domain.StatusNew,
+		Importance:  importance,
 	}
 
-	query := `INSERT INTO risks (id, description, epic_id, status)
-		VALUES ($1, $2, $3, $4)
+	query := `INSERT INTO risks (id, description, epic_id, status, importance)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING created_at, updated_at`
 	err := r.DB.QueryRowContext(ctx, query,
-		risk.ID, risk.Description, risk.EpicID, string(risk.Status)).
+		risk.ID, risk.Description, risk.EpicID, string(risk.Status),
+		string(risk.Importance)).
 		Scan(&risk.CreatedAt, &risk.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -33,8 +53,10 @@ func (r *Repository) CreateRisk(ctx context.Context, description string, epicID
 // GetRisksByEpicID returns all risks for an epic.
 func (r *Repository) GetRisksByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.Risk, error) {
 	op := "Repository.GetRisksByEpicID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var risks []domain.Risk
-	query := `SELECT id, description, epic_id, status, weighted_score,
+	query := `SELECT id, description, epic_id, status, importance, weighted_score,
 		created_at, updated_at
 		FROM risks WHERE epic_id = $1
 		ORDER BY created_at`
@@ -47,7 +69,7 @@ func (r *Repository) GetRisksByEpicID(ctx context.Context, epicID uuid.UUID) ([]
 	for rows.Next() {
 		var risk domain.Risk
 		if err := rows.Scan(&risk.ID, &risk.Description, &risk.EpicID,
-			&risk.Status, &risk.WeightedScore,
+			&risk.Status, &risk.Importance, &risk.WeightedScore,
 			&risk.CreatedAt, &risk.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
@@ -59,16 +81,18 @@ func (r *Repository) GetRisksByEpicID(ctx context.Context, epicID uuid.UUID) ([]
 // GetRiskByID returns a risk by ID.
 func (r *Repository) GetRiskByID(ctx context.Context, riskID uuid.UUID) (*domain.Risk, error) {
 	op := "Repository.GetRiskByID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var risk domain.Risk
-	query := `SELECT id, description, epic_id, status, weighted_score,
+	query := `SELECT id, description, epic_id, status, importance, weighted_score,
 		created_at, updated_at
 		FROM risks WHERE id = $1`
 	err := r.DB.QueryRowContext(ctx, query, riskID).
 		Scan(&risk.ID, &risk.Description, &risk.EpicID,
-			&risk.Status, &risk.WeightedScore,
+			&risk.Status, &risk.Importance, &risk.WeightedScore,
 			&risk.CreatedAt, &risk.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &risk, nil
 }
@@ -76,9 +100,14 @@ func (r *Repository) GetRiskByID(ctx context.Context, riskID uuid.UUID) (*domain
 // UpdateRiskStatus sets the status of a risk.
 func (r *Repository) UpdateRiskStatus(ctx context.Context, riskID uuid.UUID, status domain.Status) error {
 	op := "Repository.UpdateRiskStatus"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `UPDATE risks SET status = $1, updated_at = CURRENT_TIMESTAMP
 		WHERE id = $2`
-	_, err := r.DB.ExecContext(ctx, query, string(status), riskID)
+	err := r.withRetry(ctx, func() error {
+		_, err := r.DB.ExecContext(ctx, query, string(status), riskID)
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -88,6 +117,8 @@ func (r *Repository) UpdateRiskStatus(ctx context.Context, riskID uuid.UUID, sta
 // SetRiskWeightedScore saves the weighted score and sets status to SCORED.
 func (r *Repository) SetRiskWeightedScore(ctx context.Context, riskID uuid.UUID, score float64) error {
 	op := "Repository.SetRiskWeightedScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `UPDATE risks SET weighted_score = $1, status = $2,
 		updated_at = CURRENT_TIMESTAMP
 		WHERE id = $3`
@@ -99,17 +130,23 @@ func (r *Repository) SetRiskWeightedScore(ctx context.Context, riskID uuid.UUID,
 }
 
 // GetUnscoredRisksByUser returns SCORING risks for an epic
-// that the user has not yet scored.
+// that the user has neither scored nor skipped.
 func (r *Repository) GetUnscoredRisksByUser(ctx context.Context, userID, epicID uuid.UUID) ([]domain.Risk, error) {
 	op := "Repository.GetUnscoredRisksByUser"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `SELECT ri.id, ri.description, ri.epic_id, ri.status,
-		ri.weighted_score, ri.created_at, ri.updated_at
+		ri.importance, ri.weighted_score, ri.created_at, ri.updated_at
 		FROM risks ri
 		WHERE ri.epic_id = $1 AND ri.status = $2
 		AND NOT EXISTS (
 			SELECT 1 FROM risk_scores rs
 			WHERE rs.risk_id = ri.id AND rs.user_id = $3
 		)
+		AND NOT EXISTS (
+			SELECT 1 FROM risk_skips sk
+			WHERE sk.risk_id = ri.id AND sk.user_id = $3
+		)
 		ORDER BY ri.created_at`
 	rows, err := r.DB.QueryContext(ctx, query, epicID, string(domain.StatusScoring), userID)
 	if err != nil {
@@ -121,7 +158,7 @@ func (r *Repository) GetUnscoredRisksByUser(ctx context.Context, userID, epicID
 	for rows.Next() {
 		var risk domain.Risk
 		if err := rows.Scan(&risk.ID, &risk.Description, &risk.EpicID,
-			&risk.Status, &risk.WeightedScore,
+			&risk.Status, &risk.Importance, &risk.WeightedScore,
 			&risk.CreatedAt, &risk.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
@@ -130,13 +167,38 @@ func (r *Repository) GetUnscoredRisksByUser(ctx context.Context, userID, epicID
 	return risks, nil
 }
 
-// DeleteRisk permanently removes a risk and its scores (cascade).
-func (r *Repository) DeleteRisk(ctx context.Context, riskID uuid.UUID) error {
+// DeleteRisk permanently removes a risk with its scores and skips in one
+// transaction and returns what it removed for RestoreRisk. Returns
+// ErrNotFound when the risk does not exist.
+func (r *Repository) DeleteRisk(ctx context.Context, riskID uuid.UUID) (domain.RiskSnapshot, error) {
 	op := "Repository.DeleteRisk"
-	query := `DELETE FROM risks WHERE id = $1`
-	_, err := r.DB.ExecContext(ctx, query, riskID)
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var snap domain.RiskSnapshot
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var err error
+		if err = execAll(ctx, tx, []any{riskID},
+			`SELECT 1 FROM risks WHERE id = $1 FOR UPDATE`,
+		); err != nil {
+			return err
+		}
+		if snap.Tables, err = captureRows(ctx, tx, riskSnapshotTables, riskID); err != nil {
+			return err
+		}
+		if err := execAll(ctx, tx, []any{riskID},
+			`DELETE FROM risk_scores WHERE risk_id = $1`,
+			`DELETE FROM risk_skips WHERE risk_id = $1`,
+		); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM risks WHERE id = $1`, riskID)
+		if err != nil {
+			return err
+		}
+		return affectedOne("delete risk", res)
+	})
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return domain.RiskSnapshot{}, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+	return snap, nil
 }
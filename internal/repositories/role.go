@@ -1,9 +1,12 @@
 package repositories
 
 import (
+	"EpicScoreBot/internal/config"
 	"EpicScoreBot/internal/models/domain"
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -11,6 +14,8 @@ import (
 // GetAllRoles returns all roles.
 func (r *Repository) GetAllRoles(ctx context.Context) ([]domain.Role, error) {
 	op := "Repository.GetAllRoles"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var roles []domain.Role
 	query := `SELECT id, name, description FROM roles ORDER BY name`
 	rows, err := r.DB.QueryContext(ctx, query)
@@ -29,15 +34,100 @@ func (r *Repository) GetAllRoles(ctx context.Context) ([]domain.Role, error) {
 	return roles, nil
 }
 
+// CreateRole inserts a new role. It returns ErrAlreadyExists when a role
+// with the same name exists.
+func (r *Repository) CreateRole(ctx context.Context, name, description string) (*domain.Role, error) {
+	op := "Repository.CreateRole"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	role := &domain.Role{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: description,
+	}
+
+	query := `INSERT INTO roles (id, name, description) VALUES ($1, $2, $3)`
+	_, err := r.DB.ExecContext(ctx, query, role.ID, role.Name, role.Description)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%s: %w", op, ErrAlreadyExists)
+		}
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return role, nil
+}
+
+// SeedRoles creates the roles that do not exist yet and returns how many
+// were created. Running it again creates nothing; roles are matched by
+// name and blank names are skipped.
+func (r *Repository) SeedRoles(ctx context.Context, roles []config.RoleConfig) (int, error) {
+	op := "Repository.SeedRoles"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	created := 0
+	for _, seed := range roles {
+		name := strings.TrimSpace(seed.Name)
+		if name == "" {
+			continue
+		}
+		_, err := r.GetRoleByName(ctx, name)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return created, fmt.Errorf("%s: %w", op, err)
+		}
+		if _, err := r.CreateRole(ctx, name, strings.TrimSpace(seed.Description)); err != nil {
+			// Another instance created it in the meantime.
+			if errors.Is(err, ErrAlreadyExists) {
+				continue
+			}
+			return created, fmt.Errorf("%s: %w", op, err)
+		}
+		created++
+	}
+	return created, nil
+}
+
+// GetRolesWithUserCounts returns all roles with the number of users
+// assigned to each, including roles nobody holds.
+func (r *Repository) GetRolesWithUserCounts(ctx context.Context) ([]domain.RoleWithUserCount, error) {
+	op := "Repository.GetRolesWithUserCounts"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var roles []domain.RoleWithUserCount
+	query := `SELECT r.id, r.name, r.description, COUNT(ur.user_id)
+		FROM roles r
+		LEFT JOIN user_roles ur ON ur.role_id = r.id
+		GROUP BY r.id, r.name, r.description
+		ORDER BY r.name`
+	rows, err := r.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var role domain.RoleWithUserCount
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, &role.UserCount); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
 // GetRoleByID returns a role by ID.
 func (r *Repository) GetRoleByID(ctx context.Context, roleID uuid.UUID) (*domain.Role, error) {
 	op := "Repository.GetRoleByID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var role domain.Role
 	query := `SELECT id, name, description FROM roles WHERE id = $1`
 	err := r.DB.QueryRowContext(ctx, query, roleID).
 		Scan(&role.ID, &role.Name, &role.Description)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &role, nil
 }
@@ -45,12 +135,14 @@ func (r *Repository) GetRoleByID(ctx context.Context, roleID uuid.UUID) (*domain
 // GetRoleByName returns a role by name.
 func (r *Repository) GetRoleByName(ctx context.Context, name string) (*domain.Role, error) {
 	op := "Repository.GetRoleByName"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var role domain.Role
 	query := `SELECT id, name, description FROM roles WHERE name = $1`
 	err := r.DB.QueryRowContext(ctx, query, name).
 		Scan(&role.ID, &role.Name, &role.Description)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &role, nil
 }
@@ -59,6 +151,8 @@ func (r *Repository) GetRoleByName(ctx context.Context, name string) (*domain.Ro
 // A user can only have one role at a time.
 func (r *Repository) GetRoleByUserID(ctx context.Context, userID uuid.UUID) (*domain.Role, error) {
 	op := "Repository.GetRoleByUserID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var role domain.Role
 	query := `SELECT r.id, r.name, r.description
 		FROM roles r
@@ -68,7 +162,51 @@ func (r *Repository) GetRoleByUserID(ctx context.Context, userID uuid.UUID) (*do
 	err := r.DB.QueryRowContext(ctx, query, userID).
 		Scan(&role.ID, &role.Name, &role.Description)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &role, nil
 }
+
+// GetRoleReferences counts user assignments and scores that refer to a role.
+func (r *Repository) GetRoleReferences(ctx context.Context, roleID uuid.UUID) (domain.RoleReferences, error) {
+	op := "Repository.GetRoleReferences"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var refs domain.RoleReferences
+	query := `SELECT
+		(SELECT COUNT(*) FROM user_roles WHERE role_id = $1),
+		(SELECT COUNT(*) FROM epic_scores WHERE role_id = $1),
+		(SELECT COUNT(*) FROM epic_role_scores WHERE role_id = $1)`
+	err := r.DB.QueryRowContext(ctx, query, roleID).
+		Scan(&refs.Users, &refs.EpicScores, &refs.EpicRoleScores)
+	if err != nil {
+		return refs, fmt.Errorf("%s: %w", op, err)
+	}
+	return refs, nil
+}
+
+// DeleteRole deletes a role that is no longer referenced. The foreign keys
+// cascade, so the delete is refused if any assignment or score still uses
+// the role to avoid wiping historical data.
+func (r *Repository) DeleteRole(ctx context.Context, roleID uuid.UUID) error {
+	op := "Repository.DeleteRole"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `DELETE FROM roles
+		WHERE id = $1
+		AND NOT EXISTS (SELECT 1 FROM user_roles WHERE role_id = $1)
+		AND NOT EXISTS (SELECT 1 FROM epic_scores WHERE role_id = $1)
+		AND NOT EXISTS (SELECT 1 FROM epic_role_scores WHERE role_id = $1)`
+	res, err := r.DB.ExecContext(ctx, query, roleID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("%s: role not found or still in use", op)
+	}
+	return nil
+}
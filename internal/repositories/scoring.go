@@ -6,24 +6,35 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
-// CreateEpicScore inserts a user's score for an epic.
-func (r *Repository) CreateEpicScore(ctx context.Context, epicID, userID, roleID uuid.UUID, score int) error {
+// CreateEpicScore inserts or updates a user's score for an epic.
+// Reports true when a new score was inserted and false when an existing
+// one was changed.
+func (r *Repository) CreateEpicScore(ctx context.Context, epicID, userID, roleID uuid.UUID, score int) (bool, error) {
 	op := "Repository.CreateEpicScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `INSERT INTO epic_scores (id, epic_id, user_id, role_id, score)
 		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (epic_id, user_id) DO UPDATE SET score = $5, role_id = $4`
-	_, err := r.DB.ExecContext(ctx, query, uuid.New(), epicID, userID, roleID, score)
+		ON CONFLICT (epic_id, user_id) DO UPDATE SET score = $5, role_id = $4
+		RETURNING (xmax = 0)`
+	var inserted bool
+	err := r.withRetry(ctx, func() error {
+		return r.DB.QueryRowContext(ctx, query, uuid.New(), epicID, userID, roleID, score).Scan(&inserted)
+	})
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return false, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+	return inserted, nil
 }
 
 // GetEpicScoresByEpicID returns all scores for an epic.
 func (r *Repository) GetEpicScoresByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.EpicScore, error) {
 	op := "Repository.GetEpicScoresByEpicID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `SELECT id, epic_id, user_id, role_id, score, created_at
 		FROM epic_scores WHERE epic_id = $1`
 	rows, err := r.DB.QueryContext(ctx, query, epicID)
@@ -47,6 +58,8 @@ func (r *Repository) GetEpicScoresByEpicID(ctx context.Context, epicID uuid.UUID
 // GetEpicScoresByEpicIDAndRoleID returns scores for an epic filtered by role.
 func (r *Repository) GetEpicScoresByEpicIDAndRoleID(ctx context.Context, epicID, roleID uuid.UUID) ([]domain.EpicScore, error) {
 	op := "Repository.GetEpicScoresByEpicIDAndRoleID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `SELECT es.id, es.epic_id, es.user_id, es.role_id, es.score, es.created_at
 		FROM epic_scores es WHERE es.epic_id = $1 AND es.role_id = $2`
 	rows, err := r.DB.QueryContext(ctx, query, epicID, roleID)
@@ -70,6 +83,8 @@ func (r *Repository) GetEpicScoresByEpicIDAndRoleID(ctx context.Context, epicID,
 // HasUserScoredEpic checks if a user has already scored an epic.
 func (r *Repository) HasUserScoredEpic(ctx context.Context, epicID, userID uuid.UUID) (bool, error) {
 	op := "Repository.HasUserScoredEpic"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var count int
 	query := `SELECT COUNT(*) FROM epic_scores
 		WHERE epic_id = $1 AND user_id = $2`
@@ -80,9 +95,27 @@ func (r *Repository) HasUserScoredEpic(ctx context.Context, epicID, userID uuid.
 	return count > 0, nil
 }
 
+// GetUserEpicScore returns a user's score for an epic.
+func (r *Repository) GetUserEpicScore(ctx context.Context, epicID, userID uuid.UUID) (*domain.EpicScore, error) {
+	op := "Repository.GetUserEpicScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var s domain.EpicScore
+	query := `SELECT id, epic_id, user_id, role_id, score, created_at
+		FROM epic_scores WHERE epic_id = $1 AND user_id = $2`
+	err := r.DB.QueryRowContext(ctx, query, epicID, userID).
+		Scan(&s.ID, &s.EpicID, &s.UserID, &s.RoleID, &s.Score, &s.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
+	}
+	return &s, nil
+}
+
 // DeleteEpicScore removes all scores for a given epic.
 func (r *Repository) DeleteEpicScore(ctx context.Context, epicID uuid.UUID) error {
 	op := "Repository.DeleteEpicScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `DELETE FROM epic_scores WHERE epic_id = $1`
 	_, err := r.DB.ExecContext(ctx, query, epicID)
 	if err != nil {
@@ -91,22 +124,67 @@ func (r *Repository) DeleteEpicScore(ctx context.Context, epicID uuid.UUID) erro
 	return nil
 }
 
-// CreateRiskScore inserts a user's risk assessment.
-func (r *Repository) CreateRiskScore(ctx context.Context, riskID, userID uuid.UUID, probability, impact int) error {
-	op := "Repository.CreateRiskScore"
-	query := `INSERT INTO risk_scores (id, risk_id, user_id, probability, impact)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (risk_id, user_id) DO UPDATE SET probability = $4, impact = $5`
-	_, err := r.DB.ExecContext(ctx, query, uuid.New(), riskID, userID, probability, impact)
+// DeleteUserEpicScore removes a user's effort score for an epic so that it
+// can be entered again. The epic is locked for the duration, so the score
+// cannot disappear while scoring is being completed. Returns
+// ErrWrongStatus when the epic is not SCORING and ErrNotFound when the
+// epic or the score does not exist.
+func (r *Repository) DeleteUserEpicScore(ctx context.Context, epicID, userID uuid.UUID) error {
+	op := "Repository.DeleteUserEpicScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var status domain.Status
+		err := tx.QueryRowContext(ctx,
+			`SELECT status FROM epics WHERE id = $1 FOR UPDATE`, epicID).Scan(&status)
+		if err != nil {
+			return notFound(err)
+		}
+		if status != domain.StatusScoring {
+			return ErrWrongStatus
+		}
+		res, err := tx.ExecContext(ctx,
+			`DELETE FROM epic_scores WHERE epic_id = $1 AND user_id = $2`, epicID, userID)
+		if err != nil {
+			return err
+		}
+		return affectedOne("epic score", res)
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 	return nil
 }
 
+// CreateRiskScore inserts or updates a user's risk assessment, replacing
+// an earlier skip of the risk. Reports true when a new assessment was
+// inserted and false when an existing one was changed.
+func (r *Repository) CreateRiskScore(ctx context.Context, riskID, userID uuid.UUID, probability, impact int) (bool, error) {
+	op := "Repository.CreateRiskScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `WITH unskip AS (
+			DELETE FROM risk_skips WHERE risk_id = $2 AND user_id = $3
+		)
+		INSERT INTO risk_scores (id, risk_id, user_id, probability, impact)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (risk_id, user_id) DO UPDATE SET probability = $4, impact = $5
+		RETURNING (xmax = 0)`
+	var inserted bool
+	err := r.withRetry(ctx, func() error {
+		return r.DB.QueryRowContext(ctx, query, uuid.New(), riskID, userID, probability, impact).Scan(&inserted)
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return inserted, nil
+}
+
 // GetRiskScoresByRiskID returns all scores for a risk.
 func (r *Repository) GetRiskScoresByRiskID(ctx context.Context, riskID uuid.UUID) ([]domain.RiskScore, error) {
 	op := "Repository.GetRiskScoresByRiskID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `SELECT id, risk_id, user_id, probability, impact, created_at
 		FROM risk_scores WHERE risk_id = $1`
 	rows, err := r.DB.QueryContext(ctx, query, riskID)
@@ -127,9 +205,56 @@ func (r *Repository) GetRiskScoresByRiskID(ctx context.Context, riskID uuid.UUID
 	return scores, nil
 }
 
+// GetRiskScoresByEpicID returns the scores of all risks of an epic in one
+// query, ordered by risk.
+func (r *Repository) GetRiskScoresByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.RiskScore, error) {
+	op := "Repository.GetRiskScoresByEpicID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT rs.id, rs.risk_id, rs.user_id, rs.probability, rs.impact, rs.created_at
+		FROM risk_scores rs
+		INNER JOIN risks r ON r.id = rs.risk_id
+		WHERE r.epic_id = $1
+		ORDER BY rs.risk_id, rs.created_at`
+	rows, err := r.DB.QueryContext(ctx, query, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var scores []domain.RiskScore
+	for rows.Next() {
+		var s domain.RiskScore
+		if err := rows.Scan(&s.ID, &s.RiskID, &s.UserID,
+			&s.Probability, &s.Impact, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		scores = append(scores, s)
+	}
+	return scores, nil
+}
+
+// GetUserRiskScore returns a user's assessment of a risk.
+func (r *Repository) GetUserRiskScore(ctx context.Context, riskID, userID uuid.UUID) (*domain.RiskScore, error) {
+	op := "Repository.GetUserRiskScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var s domain.RiskScore
+	query := `SELECT id, risk_id, user_id, probability, impact, created_at
+		FROM risk_scores WHERE risk_id = $1 AND user_id = $2`
+	err := r.DB.QueryRowContext(ctx, query, riskID, userID).
+		Scan(&s.ID, &s.RiskID, &s.UserID, &s.Probability, &s.Impact, &s.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
+	}
+	return &s, nil
+}
+
 // HasUserScoredRisk checks if a user has already scored a risk.
 func (r *Repository) HasUserScoredRisk(ctx context.Context, riskID, userID uuid.UUID) (bool, error) {
 	op := "Repository.HasUserScoredRisk"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var count int
 	query := `SELECT COUNT(*) FROM risk_scores
 		WHERE risk_id = $1 AND user_id = $2`
@@ -143,6 +268,8 @@ func (r *Repository) HasUserScoredRisk(ctx context.Context, riskID, userID uuid.
 // DeleteRiskScore removes a single risk score by its ID.
 func (r *Repository) DeleteRiskScore(ctx context.Context, riskID uuid.UUID) error {
 	op := "Repository.DeleteRiskScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `DELETE FROM risk_scores WHERE risk_id = $1`
 	_, err := r.DB.ExecContext(ctx, query, riskID)
 	if err != nil {
@@ -154,6 +281,8 @@ func (r *Repository) DeleteRiskScore(ctx context.Context, riskID uuid.UUID) erro
 // UpsertEpicRoleScore inserts or updates the weighted average for a role.
 func (r *Repository) UpsertEpicRoleScore(ctx context.Context, epicID, roleID uuid.UUID, weightedAvg float64) error {
 	op := "Repository.UpsertEpicRoleScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `INSERT INTO epic_role_scores (id, epic_id, role_id, weighted_avg)
 		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (epic_id, role_id) DO UPDATE SET weighted_avg = $4`
@@ -164,9 +293,54 @@ func (r *Repository) UpsertEpicRoleScore(ctx context.Context, epicID, roleID uui
 	return nil
 }
 
+// ReplaceEpicResults replaces the per-role averages of a scored epic and
+// sets its final score in one transaction. The status and scored_at are
+// kept, so recalculating does not count as scoring the epic again.
+func (r *Repository) ReplaceEpicResults(
+	ctx context.Context,
+	epicID uuid.UUID,
+	roleAvgs map[uuid.UUID]float64,
+	finalScore float64,
+) error {
+	op := "Repository.ReplaceEpicResults"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE epics SET final_score = $1, updated_at = CURRENT_TIMESTAMP
+			WHERE id = $2`,
+			finalScore, epicID)
+		if err != nil {
+			return err
+		}
+		if err := affectedOne("update epic", res); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM epic_role_scores WHERE epic_id = $1`, epicID); err != nil {
+			return err
+		}
+		for roleID, avg := range roleAvgs {
+			if _, err := tx.ExecContext(ctx,
+				`INSERT INTO epic_role_scores (id, epic_id, role_id, weighted_avg)
+				VALUES ($1, $2, $3, $4)`,
+				uuid.New(), epicID, roleID, avg); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 // DeleteEpicRoleScore removes all role-level scores for a given epic.
 func (r *Repository) DeleteEpicRoleScore(ctx context.Context, epicID uuid.UUID) error {
 	op := "Repository.DeleteEpicRoleScore"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `DELETE FROM epic_role_scores WHERE epic_id = $1`
 	_, err := r.DB.ExecContext(ctx, query, epicID)
 	if err != nil {
@@ -178,6 +352,8 @@ func (r *Repository) DeleteEpicRoleScore(ctx context.Context, epicID uuid.UUID)
 // GetEpicRoleScoresByEpicID returns all role-level weighted averages for an epic.
 func (r *Repository) GetEpicRoleScoresByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.EpicRoleScore, error) {
 	op := "Repository.GetEpicRoleScoresByEpicID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `SELECT id, epic_id, role_id, weighted_avg
 		FROM epic_role_scores WHERE epic_id = $1`
 	rows, err := r.DB.QueryContext(ctx, query, epicID)
@@ -200,6 +376,8 @@ func (r *Repository) GetEpicRoleScoresByEpicID(ctx context.Context, epicID uuid.
 // CountTeamMembers returns the number of users in a team.
 func (r *Repository) CountTeamMembers(ctx context.Context, teamID uuid.UUID) (int, error) {
 	op := "Repository.CountTeamMembers"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var count int
 	query := `SELECT COUNT(*) FROM user_teams WHERE team_id = $1`
 	err := r.DB.QueryRowContext(ctx, query, teamID).Scan(&count)
@@ -209,11 +387,106 @@ func (r *Repository) CountTeamMembers(ctx context.Context, teamID uuid.UUID) (in
 	return count, nil
 }
 
-// CountEpicScores returns the number of scores for an epic.
+// CountEpicScorers returns the number of team members expected to
+// estimate the epic's effort: those holding one of its required roles, or
+// the whole team when the epic has none.
+func (r *Repository) CountEpicScorers(ctx context.Context, epicID uuid.UUID) (int, error) {
+	op := "Repository.CountEpicScorers"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var count int
+	query := `SELECT COUNT(*) FROM user_teams ut
+		JOIN epics e ON e.team_id = ut.team_id
+		WHERE e.id = $1
+		AND (
+			NOT EXISTS (SELECT 1 FROM epic_required_roles er WHERE er.epic_id = e.id)
+			OR EXISTS (
+				SELECT 1 FROM epic_required_roles er
+				JOIN user_roles ur ON ur.role_id = er.role_id
+				WHERE er.epic_id = e.id AND ur.user_id = ut.user_id
+			)
+		)`
+	err := r.DB.QueryRowContext(ctx, query, epicID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
+// GetEpicScorers returns the team members expected to estimate the epic's
+// effort, ordered by last name. See CountEpicScorers.
+func (r *Repository) GetEpicScorers(ctx context.Context, epicID uuid.UUID) ([]domain.User, error) {
+	op := "Repository.GetEpicScorers"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT u.id, u.first_name, u.last_name, u.telegram_id, u.weight,
+		u.created_at, u.updated_at
+		FROM users u
+		JOIN user_teams ut ON ut.user_id = u.id
+		JOIN epics e ON e.team_id = ut.team_id
+		WHERE e.id = $1
+		AND (
+			NOT EXISTS (SELECT 1 FROM epic_required_roles er WHERE er.epic_id = e.id)
+			OR EXISTS (
+				SELECT 1 FROM epic_required_roles er
+				JOIN user_roles ur ON ur.role_id = er.role_id
+				WHERE er.epic_id = e.id AND ur.user_id = u.id
+			)
+		)
+		ORDER BY u.last_name, u.first_name`
+	rows, err := r.DB.QueryContext(ctx, query, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var users []domain.User
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.FirstName, &u.LastName,
+			&u.TelegramID, &u.Weight,
+			&u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// IsUserEpicScorer reports whether the user is expected to estimate the
+// epic's effort. Team membership is not checked.
+func (r *Repository) IsUserEpicScorer(ctx context.Context, epicID, userID uuid.UUID) (bool, error) {
+	op := "Repository.IsUserEpicScorer"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var ok bool
+	query := `SELECT
+		NOT EXISTS (SELECT 1 FROM epic_required_roles WHERE epic_id = $1)
+		OR EXISTS (
+			SELECT 1 FROM epic_required_roles er
+			JOIN user_roles ur ON ur.role_id = er.role_id
+			WHERE er.epic_id = $1 AND ur.user_id = $2
+		)`
+	err := r.DB.QueryRowContext(ctx, query, epicID, userID).Scan(&ok)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return ok, nil
+}
+
+// CountEpicScores returns the number of scores for an epic. When the epic
+// has required roles, only scores given in those roles are counted.
 func (r *Repository) CountEpicScores(ctx context.Context, epicID uuid.UUID) (int, error) {
 	op := "Repository.CountEpicScores"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var count int
-	query := `SELECT COUNT(*) FROM epic_scores WHERE epic_id = $1`
+	query := `SELECT COUNT(*) FROM epic_scores es
+		WHERE es.epic_id = $1
+		AND (
+			NOT EXISTS (SELECT 1 FROM epic_required_roles er WHERE er.epic_id = es.epic_id)
+			OR es.role_id IN (SELECT role_id FROM epic_required_roles WHERE epic_id = es.epic_id)
+		)`
 	err := r.DB.QueryRowContext(ctx, query, epicID).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("%s: %w", op, err)
@@ -221,9 +494,57 @@ func (r *Repository) CountEpicScores(ctx context.Context, epicID uuid.UUID) (int
 	return count, nil
 }
 
+// SkipRisk records that a risk is not applicable to the user, replacing
+// their assessment if they had one. Reports true when a new skip was
+// recorded and false when the risk was already skipped.
+func (r *Repository) SkipRisk(ctx context.Context, riskID, userID uuid.UUID) (bool, error) {
+	op := "Repository.SkipRisk"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var inserted bool
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM risk_scores WHERE risk_id = $1 AND user_id = $2`, riskID, userID); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `INSERT INTO risk_skips (id, risk_id, user_id)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (risk_id, user_id) DO NOTHING`, uuid.New(), riskID, userID)
+		if err != nil {
+			return err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		inserted = n > 0
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return inserted, nil
+}
+
+// CountRiskSkips returns the number of users who skipped a risk.
+func (r *Repository) CountRiskSkips(ctx context.Context, riskID uuid.UUID) (int, error) {
+	op := "Repository.CountRiskSkips"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var count int
+	query := `SELECT COUNT(*) FROM risk_skips WHERE risk_id = $1`
+	err := r.DB.QueryRowContext(ctx, query, riskID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
 // CountRiskScores returns the number of scores for a risk.
 func (r *Repository) CountRiskScores(ctx context.Context, riskID uuid.UUID) (int, error) {
 	op := "Repository.CountRiskScores"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var count int
 	query := `SELECT COUNT(*) FROM risk_scores WHERE risk_id = $1`
 	err := r.DB.QueryRowContext(ctx, query, riskID).Scan(&count)
@@ -234,10 +555,18 @@ func (r *Repository) CountRiskScores(ctx context.Context, riskID uuid.UUID) (int
 }
 
 // GetDistinctRoleIDsForEpicScores returns the distinct role IDs
-// that have scores for a given epic.
+// that have scores for a given epic. When the epic has required roles,
+// other roles are left out.
 func (r *Repository) GetDistinctRoleIDsForEpicScores(ctx context.Context, epicID uuid.UUID) ([]uuid.UUID, error) {
 	op := "Repository.GetDistinctRoleIDsForEpicScores"
-	query := `SELECT DISTINCT role_id FROM epic_scores WHERE epic_id = $1`
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `SELECT DISTINCT es.role_id FROM epic_scores es
+		WHERE es.epic_id = $1
+		AND (
+			NOT EXISTS (SELECT 1 FROM epic_required_roles er WHERE er.epic_id = es.epic_id)
+			OR es.role_id IN (SELECT role_id FROM epic_required_roles WHERE epic_id = es.epic_id)
+		)`
 	rows, err := r.DB.QueryContext(ctx, query, epicID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -255,14 +584,19 @@ func (r *Repository) GetDistinctRoleIDsForEpicScores(ctx context.Context, epicID
 	return roleIDs, nil
 }
 
-// GetUsersWhoScoredEpic returns users who have submitted an epic score.
+// GetUsersWhoScoredEpic returns users who have submitted an epic score,
+// ordered by last name. A user scores an epic at most once, so each
+// appears once.
 func (r *Repository) GetUsersWhoScoredEpic(ctx context.Context, epicID uuid.UUID) ([]domain.User, error) {
 	op := "Repository.GetUsersWhoScoredEpic"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `SELECT u.id, u.first_name, u.last_name, u.telegram_id,
 		u.weight, u.created_at, u.updated_at
 		FROM users u
 		INNER JOIN epic_scores es ON es.user_id = u.id
-		WHERE es.epic_id = $1`
+		WHERE es.epic_id = $1
+		ORDER BY u.last_name, u.first_name`
 	rows, err := r.DB.QueryContext(ctx, query, epicID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -282,14 +616,18 @@ func (r *Repository) GetUsersWhoScoredEpic(ctx context.Context, epicID uuid.UUID
 	return users, nil
 }
 
-// GetUsersWhoScoredRisk returns users who have submitted a risk score.
+// GetUsersWhoScoredRisk returns users who have submitted a risk score,
+// ordered by last name.
 func (r *Repository) GetUsersWhoScoredRisk(ctx context.Context, riskID uuid.UUID) ([]domain.User, error) {
 	op := "Repository.GetUsersWhoScoredRisk"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `SELECT u.id, u.first_name, u.last_name, u.telegram_id,
 		u.weight, u.created_at, u.updated_at
 		FROM users u
 		INNER JOIN risk_scores rs ON rs.user_id = u.id
-		WHERE rs.risk_id = $1`
+		WHERE rs.risk_id = $1
+		ORDER BY u.last_name, u.first_name`
 	rows, err := r.DB.QueryContext(ctx, query, riskID)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -308,3 +646,28 @@ func (r *Repository) GetUsersWhoScoredRisk(ctx context.Context, riskID uuid.UUID
 	}
 	return users, nil
 }
+
+// GetUserAgreement compares a user's effort scores on SCORED epics with the
+// weighted role average of the same epic and role. When teamID is not nil
+// only epics of that team are considered.
+func (r *Repository) GetUserAgreement(ctx context.Context, userID uuid.UUID, teamID *uuid.UUID) (domain.UserAgreement, error) {
+	op := "Repository.GetUserAgreement"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var a domain.UserAgreement
+	query := `SELECT COUNT(*),
+		COALESCE(AVG(es.score - ers.weighted_avg), 0),
+		COALESCE(AVG(ABS(es.score - ers.weighted_avg)), 0)
+		FROM epic_scores es
+		INNER JOIN epics e ON e.id = es.epic_id
+		INNER JOIN epic_role_scores ers
+			ON ers.epic_id = es.epic_id AND ers.role_id = es.role_id
+		WHERE es.user_id = $1 AND e.status = $2
+		AND ($3::uuid IS NULL OR e.team_id = $3)`
+	err := r.DB.QueryRowContext(ctx, query, userID, string(domain.StatusScored), teamID).
+		Scan(&a.Epics, &a.AvgDeviation, &a.AvgAbsDeviation)
+	if err != nil {
+		return a, fmt.Errorf("%s: %w", op, err)
+	}
+	return a, nil
+}
@@ -0,0 +1,73 @@
+package repositories
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestGetUserAgreement(t *testing.T) {
+	repo := openTestRepo(t)
+	teamA, teamB, role := uuid.New(), uuid.New(), uuid.New()
+	ann, bob, newcomer := uuid.New(), uuid.New(), uuid.New()
+	scoredA1, scoredA2, scoredB, scoringA := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	stmts := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO teams (id, name) VALUES ($1, 'A'), ($2, 'B')`, []any{teamA, teamB}},
+		{`INSERT INTO roles (id, name) VALUES ($1, 'Agreement role')`, []any{role}},
+		{`INSERT INTO users (id, first_name, last_name, telegram_id, weight)
+			VALUES ($1, 'Ann', 'A', 'ann', 100), ($2, 'Bob', 'B', 'bob', 100), ($3, 'New', 'N', 'new', 100)`,
+			[]any{ann, bob, newcomer}},
+		{`INSERT INTO epics (id, number, name, team_id, status) VALUES
+			($1, 'EP-1', 'A1', $5, 'SCORED'), ($2, 'EP-2', 'A2', $5, 'SCORED'),
+			($3, 'EP-3', 'B1', $6, 'SCORED'), ($4, 'EP-4', 'A3', $5, 'SCORING')`,
+			[]any{scoredA1, scoredA2, scoredB, scoringA, teamA, teamB}},
+		// Ann: +2 and -3 in team A, +3 in team B; the epic still being
+		// scored does not count.
+		{`INSERT INTO epic_scores (epic_id, user_id, role_id, score) VALUES
+			($1, $5, $7, 10), ($2, $5, $7, 5), ($3, $5, $7, 12), ($4, $5, $7, 100),
+			($1, $6, $7, 6), ($2, $6, $7, 11)`,
+			[]any{scoredA1, scoredA2, scoredB, scoringA, ann, bob, role}},
+		{`INSERT INTO epic_role_scores (epic_id, role_id, weighted_avg) VALUES
+			($1, $5, 8), ($2, $5, 8), ($3, $5, 9), ($4, $5, 50)`,
+			[]any{scoredA1, scoredA2, scoredB, scoringA, role}},
+	}
+	for _, stmt := range stmts {
+		if _, err := repo.DB.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("seed %q: %v", stmt.query, err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		user       uuid.UUID
+		team       *uuid.UUID
+		wantEpics  int
+		wantAvg    float64
+		wantAbsAvg float64
+	}{
+		{"all teams", ann, nil, 3, 2.0 / 3, 8.0 / 3},
+		{"one team", ann, &teamA, 2, -0.5, 2.5},
+		{"other user", bob, nil, 2, 0.5, 2.5},
+		{"no history", newcomer, nil, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := repo.GetUserAgreement(context.Background(), tt.user, tt.team)
+			if err != nil {
+				t.Fatalf("GetUserAgreement() error = %v", err)
+			}
+			if a.Epics != tt.wantEpics ||
+				math.Abs(a.AvgDeviation-tt.wantAvg) > 1e-9 ||
+				math.Abs(a.AvgAbsDeviation-tt.wantAbsAvg) > 1e-9 {
+				t.Errorf("GetUserAgreement() = %+v, want %d scores, %.4f, %.4f",
+					a, tt.wantEpics, tt.wantAvg, tt.wantAbsAvg)
+			}
+		})
+	}
+}
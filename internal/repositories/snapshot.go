@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"EpicScoreBot/internal/models/domain"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// snapshotTable is a table whose rows are captured before a deletion and
+// re-inserted when it is undone. Whole rows are captured, so columns
+// added later are restored too.
+type snapshotTable struct {
+	name string
+	// where selects the captured rows; $1 is the ID of the deleted row.
+	where string
+	// guard, if set, skips restoring rows whose references were deleted
+	// in the meantime; the restored row is aliased r.
+	guard string
+}
+
+// Guards for rows referencing rows that may be gone by the time of undo.
+const (
+	userExists = `EXISTS (SELECT 1 FROM users WHERE id = r.user_id)`
+	roleExists = `EXISTS (SELECT 1 FROM roles WHERE id = r.role_id)`
+	teamExists = `EXISTS (SELECT 1 FROM teams WHERE id = r.team_id)`
+	epicExists = `EXISTS (SELECT 1 FROM epics WHERE id = r.epic_id)`
+	riskExists = `EXISTS (SELECT 1 FROM risks WHERE id = r.risk_id)`
+)
+
+// riskSnapshotTables are the rows deleted with a risk, parents first.
+var riskSnapshotTables = []snapshotTable{
+	{name: "risks", where: `id = $1`},
+	{name: "risk_scores", where: `risk_id = $1`, guard: userExists},
+	{name: "risk_skips", where: `risk_id = $1`, guard: userExists},
+}
+
+// epicSnapshotTables are the rows deleted with an epic, parents first.
+var epicSnapshotTables = []snapshotTable{
+	{name: "epics", where: `id = $1`},
+	{name: "epic_required_roles", where: `epic_id = $1`, guard: roleExists},
+	{name: "epic_status_history", where: `epic_id = $1`},
+	{name: "risks", where: `epic_id = $1`},
+	{name: "risk_scores", where: `risk_id IN (SELECT id FROM risks WHERE epic_id = $1)`, guard: userExists},
+	{name: "risk_skips", where: `risk_id IN (SELECT id FROM risks WHERE epic_id = $1)`, guard: userExists},
+	{name: "epic_scores", where: `epic_id = $1`, guard: userExists + ` AND ` + roleExists},
+	{name: "epic_role_scores", where: `epic_id = $1`, guard: roleExists},
+	{name: "epic_comments", where: `epic_id = $1`, guard: userExists},
+	{name: "epic_watchers", where: `epic_id = $1`},
+}
+
+// userSnapshotTables are the rows deleted with a user, parents first.
+var userSnapshotTables = []snapshotTable{
+	{name: "users", where: `id = $1`},
+	{name: "user_roles", where: `user_id = $1`, guard: roleExists},
+	{name: "user_teams", where: `user_id = $1`, guard: teamExists},
+	{name: "epic_scores", where: `user_id = $1`, guard: epicExists + ` AND ` + roleExists},
+	{name: "risk_scores", where: `user_id = $1`, guard: riskExists},
+	{name: "risk_skips", where: `user_id = $1`, guard: riskExists},
+	{name: "epic_comments", where: `user_id = $1`, guard: epicExists},
+}
+
+// captureRows reads the rows of tables selected by id within tx. The
+// caller locks the deleted row first, so no dependent row can be added
+// between capturing and deleting.
+func captureRows(ctx context.Context, tx *sqlx.Tx, tables []snapshotTable, id uuid.UUID) ([]domain.TableRows, error) {
+	captured := make([]domain.TableRows, 0, len(tables))
+	for _, t := range tables {
+		query := fmt.Sprintf(`SELECT COALESCE(jsonb_agg(to_jsonb(t)), '[]'::jsonb) FROM %s t WHERE %s`,
+			t.name, t.where)
+		var rows []byte
+		if err := tx.QueryRowContext(ctx, query, id).Scan(&rows); err != nil {
+			return nil, fmt.Errorf("capture %s: %w", t.name, err)
+		}
+		captured = append(captured, domain.TableRows{Table: t.name, Rows: json.RawMessage(rows)})
+	}
+	return captured, nil
+}
+
+// restoreRows re-inserts captured rows with their original IDs and
+// columns, in the order of tables.
+func restoreRows(ctx context.Context, tx *sqlx.Tx, tables []snapshotTable, captured []domain.TableRows) error {
+	byTable := make(map[string]json.RawMessage, len(captured))
+	for _, c := range captured {
+		byTable[c.Table] = c.Rows
+	}
+	for _, t := range tables {
+		rows, ok := byTable[t.name]
+		if !ok {
+			continue
+		}
+		guard := t.guard
+		if guard == "" {
+			guard = "TRUE"
+		}
+		query := fmt.Sprintf(`INSERT INTO %s
+			SELECT r.* FROM jsonb_populate_recordset(NULL::%s, $1::jsonb) r WHERE %s`,
+			t.name, t.name, guard)
+		if _, err := tx.ExecContext(ctx, query, string(rows)); err != nil {
+			return fmt.Errorf("restore %s: %w", t.name, err)
+		}
+	}
+	return nil
+}
+
+// restore re-inserts a snapshot in one transaction. A row that was created
+// again in the meantime, such as a user with the same username, makes it
+// fail with ErrAlreadyExists.
+func (r *Repository) restore(ctx context.Context, op string, tables []snapshotTable, captured []domain.TableRows) error {
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		return restoreRows(ctx, tx, tables, captured)
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, ErrAlreadyExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// RestoreRisk re-inserts a risk deleted by DeleteRisk with its scores and
+// skips. Rows of users deleted in the meantime are skipped.
+func (r *Repository) RestoreRisk(ctx context.Context, snap domain.RiskSnapshot) error {
+	return r.restore(ctx, "Repository.RestoreRisk", riskSnapshotTables, snap.Tables)
+}
+
+// RestoreEpic re-inserts an epic deleted by DeleteEpicTx with every row
+// that depended on it. Rows of users or roles deleted in the meantime are
+// skipped.
+func (r *Repository) RestoreEpic(ctx context.Context, snap domain.EpicSnapshot) error {
+	return r.restore(ctx, "Repository.RestoreEpic", epicSnapshotTables, snap.Tables)
+}
+
+// RestoreUser re-inserts a user deleted by DeleteUserTx with their roles,
+// team memberships, scores, skips and comments. Rows referencing roles,
+// teams, epics or risks deleted in the meantime are skipped.
+func (r *Repository) RestoreUser(ctx context.Context, snap domain.UserSnapshot) error {
+	return r.restore(ctx, "Repository.RestoreUser", userSnapshotTables, snap.Tables)
+}
@@ -0,0 +1,170 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"EpicScoreBot/internal/config"
+
+	"github.com/google/uuid"
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+// testConfig reads the database configured by the DB_* variables and picks
+// a schema of its own. The tests are skipped unless EPICSCOREBOT_DB_TESTS
+// is set.
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	if os.Getenv("EPICSCOREBOT_DB_TESTS") == "" {
+		t.Skip("set EPICSCOREBOT_DB_TESTS and DB_* to run database tests")
+	}
+	var cfg config.Config
+	if err := cleanenv.ReadEnv(&cfg.DBConfig); err != nil {
+		t.Fatalf("read DB config: %v", err)
+	}
+	cfg.DBConfig.Schema = "epic_score_test_" + uuid.NewString()[:8]
+	return &cfg
+}
+
+// openTestRepo migrates a schema of its own, dropped when the test ends.
+func openTestRepo(t *testing.T) *Repository {
+	t.Helper()
+	return openTestRepoWith(t, testConfig(t))
+}
+
+// openTestRepoWith is openTestRepo for a config from testConfig.
+func openTestRepoWith(t *testing.T, cfg *config.Config) *Repository {
+	t.Helper()
+	repo, err := New(slog.New(slog.NewTextHandler(io.Discard, nil)), cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	t.Cleanup(func() {
+		repo.DB.Exec(`DROP SCHEMA ` + cfg.DBConfig.Schema + ` CASCADE`)
+		repo.DB.Close()
+	})
+	return repo
+}
+
+// fixture is a scored team with every kind of row an epic or a user has.
+type fixture struct {
+	team, role, epic, risk uuid.UUID
+	scorer, skipper        uuid.UUID
+}
+
+func seedFixture(t *testing.T, repo *Repository) fixture {
+	t.Helper()
+	f := fixture{
+		team: uuid.New(), role: uuid.New(), epic: uuid.New(), risk: uuid.New(),
+		scorer: uuid.New(), skipper: uuid.New(),
+	}
+	stmts := []struct {
+		query string
+		args  []any
+	}{
+		{`INSERT INTO teams (id, name) VALUES ($1, 'Team')`, []any{f.team}},
+		{`INSERT INTO roles (id, name) VALUES ($1, 'Snapshot role')`, []any{f.role}},
+		{`INSERT INTO users (id, first_name, last_name, telegram_id, weight, chat_id)
+			VALUES ($1, 'Ann', 'Scorer', 'ann', 80, 1001), ($2, 'Bob', 'Skipper', 'bob', 100, NULL)`,
+			[]any{f.scorer, f.skipper}},
+		{`INSERT INTO user_roles (user_id, role_id) VALUES ($1, $3), ($2, $3)`, []any{f.scorer, f.skipper, f.role}},
+		{`INSERT INTO user_teams (user_id, team_id) VALUES ($1, $3), ($2, $3)`, []any{f.scorer, f.skipper, f.team}},
+		{`INSERT INTO epics (id, number, name, description, team_id, status, scoring_deadline, scoring_started_at)
+			VALUES ($1, 'EP-1', 'Epic', 'Desc', $2, 'SCORING', NOW() + INTERVAL '1 day', NOW() - INTERVAL '1 hour')`,
+			[]any{f.epic, f.team}},
+		{`INSERT INTO epic_required_roles (epic_id, role_id) VALUES ($1, $2)`, []any{f.epic, f.role}},
+		{`INSERT INTO epic_status_history (epic_id, from_status, to_status) VALUES ($1, 'NEW', 'SCORING')`,
+			[]any{f.epic}},
+		{`INSERT INTO risks (id, description, epic_id, status, importance, weighted_score)
+			VALUES ($1, 'Risk', $2, 'SCORED', 'HIGH', 6.5)`, []any{f.risk, f.epic}},
+		{`INSERT INTO risk_scores (risk_id, user_id, probability, impact) VALUES ($1, $2, 2, 3)`,
+			[]any{f.risk, f.scorer}},
+		{`INSERT INTO risk_skips (risk_id, user_id) VALUES ($1, $2)`, []any{f.risk, f.skipper}},
+		{`INSERT INTO epic_scores (epic_id, user_id, role_id, score) VALUES ($1, $2, $4, 13), ($1, $3, $4, 8)`,
+			[]any{f.epic, f.scorer, f.skipper, f.role}},
+		{`INSERT INTO epic_role_scores (epic_id, role_id, weighted_avg) VALUES ($1, $2, 10.78)`,
+			[]any{f.epic, f.role}},
+		{`INSERT INTO epic_comments (epic_id, user_id, text) VALUES ($1, $2, 'Assumes the API exists')`,
+			[]any{f.epic, f.scorer}},
+		{`INSERT INTO epic_watchers (epic_id, chat_id, thread_id) VALUES ($1, -100, 7)`, []any{f.epic}},
+	}
+	for _, stmt := range stmts {
+		if _, err := repo.DB.Exec(stmt.query, stmt.args...); err != nil {
+			t.Fatalf("seed %q: %v", stmt.query, err)
+		}
+	}
+	return f
+}
+
+// dumpTables renders every row of the tables a deletion touches, so the
+// state before a deletion can be compared with the state after its undo.
+func dumpTables(t *testing.T, repo *Repository) string {
+	t.Helper()
+	tables := []string{
+		"teams", "roles", "users", "user_roles", "user_teams", "epics",
+		"epic_required_roles", "epic_status_history", "risks", "risk_scores",
+		"risk_skips", "epic_scores", "epic_role_scores", "epic_comments", "epic_watchers",
+	}
+	var sb strings.Builder
+	for _, table := range tables {
+		var rows string
+		query := fmt.Sprintf(`SELECT COALESCE(string_agg(to_jsonb(t)::text, E'\n' ORDER BY to_jsonb(t)::text), '')
+			FROM %s t`, table)
+		if err := repo.DB.Get(&rows, query); err != nil {
+			t.Fatalf("dump %s: %v", table, err)
+		}
+		fmt.Fprintf(&sb, "%s:\n%s\n", table, rows)
+	}
+	return sb.String()
+}
+
+func TestDeleteAndRestoreRoundTrip(t *testing.T) {
+	repo := openTestRepo(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		run  func(f fixture) (func() error, error)
+	}{
+		{"risk", func(f fixture) (func() error, error) {
+			snap, err := repo.DeleteRisk(ctx, f.risk)
+			return func() error { return repo.RestoreRisk(ctx, snap) }, err
+		}},
+		{"epic", func(f fixture) (func() error, error) {
+			snap, err := repo.DeleteEpicTx(ctx, f.epic)
+			return func() error { return repo.RestoreEpic(ctx, snap) }, err
+		}},
+		{"user", func(f fixture) (func() error, error) {
+			snap, err := repo.DeleteUserTx(ctx, f.scorer)
+			return func() error { return repo.RestoreUser(ctx, snap) }, err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := repo.DB.Exec(`TRUNCATE teams, users, roles CASCADE`); err != nil {
+				t.Fatalf("truncate: %v", err)
+			}
+			f := seedFixture(t, repo)
+			before := dumpTables(t, repo)
+
+			restore, err := tt.run(f)
+			if err != nil {
+				t.Fatalf("delete error = %v", err)
+			}
+			if dumpTables(t, repo) == before {
+				t.Fatal("delete left every row in place")
+			}
+			if err := restore(); err != nil {
+				t.Fatalf("restore error = %v", err)
+			}
+			if after := dumpTables(t, repo); after != before {
+				t.Errorf("rows after undo differ\nbefore:\n%s\nafter:\n%s", before, after)
+			}
+		})
+	}
+}
@@ -6,11 +6,15 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 )
 
-// CreateTeam inserts a new team.
+// CreateTeam inserts a new team. Returns ErrAlreadyExists when a team with
+// the same name exists.
 func (r *Repository) CreateTeam(ctx context.Context, name, description string) (*domain.Team, error) {
 	op := "Repository.CreateTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	team := &domain.Team{
 		ID:          uuid.New(),
 		Name:        name,
@@ -19,11 +23,14 @@ func (r *Repository) CreateTeam(ctx context.Context, name, description string) (
 
 	query := `INSERT INTO teams (id, name, description)
 		VALUES ($1, $2, $3)
-		RETURNING created_at, updated_at`
+		RETURNING timezone, created_at, updated_at`
 	err := r.DB.QueryRowContext(ctx, query,
 		team.ID, team.Name, team.Description).
-		Scan(&team.CreatedAt, &team.UpdatedAt)
+		Scan(&team.Timezone, &team.CreatedAt, &team.UpdatedAt)
 	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("%s: %w", op, ErrAlreadyExists)
+		}
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	return team, nil
@@ -32,14 +39,16 @@ func (r *Repository) CreateTeam(ctx context.Context, name, description string) (
 // GetTeamByName returns a team by name.
 func (r *Repository) GetTeamByName(ctx context.Context, name string) (*domain.Team, error) {
 	op := "Repository.GetTeamByName"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var team domain.Team
-	query := `SELECT id, name, description, created_at, updated_at
+	query := `SELECT id, name, description, timezone, archived_at, created_at, updated_at
 		FROM teams WHERE name = $1`
 	err := r.DB.QueryRowContext(ctx, query, name).
-		Scan(&team.ID, &team.Name, &team.Description,
-			&team.CreatedAt, &team.UpdatedAt)
+		Scan(&team.ID, &team.Name, &team.Description, &team.Timezone,
+			&team.ArchivedAt, &team.CreatedAt, &team.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &team, nil
 }
@@ -47,25 +56,35 @@ func (r *Repository) GetTeamByName(ctx context.Context, name string) (*domain.Te
 // GetTeamByID returns a team by ID.
 func (r *Repository) GetTeamByID(ctx context.Context, teamID uuid.UUID) (*domain.Team, error) {
 	op := "Repository.GetTeamByID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var team domain.Team
-	query := `SELECT id, name, description, created_at, updated_at
+	query := `SELECT id, name, description, timezone, archived_at, created_at, updated_at
 		FROM teams WHERE id = $1`
 	err := r.DB.QueryRowContext(ctx, query, teamID).
-		Scan(&team.ID, &team.Name, &team.Description,
-			&team.CreatedAt, &team.UpdatedAt)
+		Scan(&team.ID, &team.Name, &team.Description, &team.Timezone,
+			&team.ArchivedAt, &team.CreatedAt, &team.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &team, nil
 }
 
-// GetAllTeams returns all teams.
+// GetAllTeams returns all teams that are not archived.
 func (r *Repository) GetAllTeams(ctx context.Context) ([]domain.Team, error) {
-	op := "Repository.GetAllTeams"
+	return r.GetTeams(ctx, false)
+}
+
+// GetTeams returns all teams ordered by name, including archived ones when
+// includeArchived is set.
+func (r *Repository) GetTeams(ctx context.Context, includeArchived bool) ([]domain.Team, error) {
+	op := "Repository.GetTeams"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var teams []domain.Team
-	query := `SELECT id, name, description, created_at, updated_at
-		FROM teams ORDER BY name`
-	rows, err := r.DB.QueryContext(ctx, query)
+	query := `SELECT id, name, description, timezone, archived_at, created_at, updated_at
+		FROM teams WHERE $1 OR archived_at IS NULL ORDER BY name`
+	rows, err := r.DB.QueryContext(ctx, query, includeArchived)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
@@ -73,8 +92,8 @@ func (r *Repository) GetAllTeams(ctx context.Context) ([]domain.Team, error) {
 
 	for rows.Next() {
 		var t domain.Team
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description,
-			&t.CreatedAt, &t.UpdatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Timezone,
+			&t.ArchivedAt, &t.CreatedAt, &t.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
 		teams = append(teams, t)
@@ -82,15 +101,18 @@ func (r *Repository) GetAllTeams(ctx context.Context) ([]domain.Team, error) {
 	return teams, nil
 }
 
-// GetTeamsByUserTelegramID returns all teams a user belongs to.
+// GetTeamsByUserTelegramID returns all teams a user belongs to. The
+// username is matched ignoring case.
 func (r *Repository) GetTeamsByUserTelegramID(ctx context.Context, telegramID string) ([]domain.Team, error) {
 	op := "Repository.GetTeamsByUserTelegramID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var teams []domain.Team
-	query := `SELECT t.id, t.name, t.description, t.created_at, t.updated_at
+	query := `SELECT t.id, t.name, t.description, t.timezone, t.archived_at, t.created_at, t.updated_at
 		FROM teams t
 		INNER JOIN user_teams ut ON t.id = ut.team_id
 		INNER JOIN users u ON u.id = ut.user_id
-		WHERE u.telegram_id = $1
+		WHERE LOWER(u.telegram_id) = LOWER($1)
 		ORDER BY t.name`
 	rows, err := r.DB.QueryContext(ctx, query, telegramID)
 	if err != nil {
@@ -100,11 +122,189 @@ func (r *Repository) GetTeamsByUserTelegramID(ctx context.Context, telegramID st
 
 	for rows.Next() {
 		var t domain.Team
-		if err := rows.Scan(&t.ID, &t.Name, &t.Description,
-			&t.CreatedAt, &t.UpdatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Description, &t.Timezone,
+			&t.ArchivedAt, &t.CreatedAt, &t.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("%s: scan: %w", op, err)
 		}
 		teams = append(teams, t)
 	}
 	return teams, nil
 }
+
+// MergeTeams moves all epics and members of the source team to the target
+// team in a single transaction. Members already in the target are kept once.
+// When deleteSource is set, the emptied source team is removed.
+// Returns the number of epics moved and members newly added to the target,
+// or ErrAlreadyExists when both teams have an epic with the same number.
+func (r *Repository) MergeTeams(ctx context.Context, fromID, toID uuid.UUID, deleteSource bool) (int, int, error) {
+	op := "Repository.MergeTeams"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var epicsMoved, membersMoved int64
+
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		res, err := tx.ExecContext(ctx,
+			`UPDATE epics SET team_id = $2, updated_at = CURRENT_TIMESTAMP
+			WHERE team_id = $1`, fromID, toID)
+		if err != nil {
+			if isUniqueViolation(err) {
+				err = ErrAlreadyExists
+			}
+			return fmt.Errorf("move epics: %w", err)
+		}
+		if epicsMoved, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("move epics: %w", err)
+		}
+
+		res, err = tx.ExecContext(ctx,
+			`INSERT INTO user_teams (user_id, team_id)
+			SELECT user_id, $2 FROM user_teams WHERE team_id = $1
+			ON CONFLICT (user_id, team_id) DO NOTHING`, fromID, toID)
+		if err != nil {
+			return fmt.Errorf("move members: %w", err)
+		}
+		if membersMoved, err = res.RowsAffected(); err != nil {
+			return fmt.Errorf("move members: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM user_teams WHERE team_id = $1`, fromID); err != nil {
+			return fmt.Errorf("clear members: %w", err)
+		}
+
+		if deleteSource {
+			if _, err := tx.ExecContext(ctx,
+				`DELETE FROM teams WHERE id = $1`, fromID); err != nil {
+				return fmt.Errorf("delete team: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return int(epicsMoved), int(membersMoved), nil
+}
+
+// UpdateTeam changes a team's name and description. The ID and
+// memberships are kept. Returns ErrAlreadyExists when another team has
+// the new name and ErrNotFound when the team does not exist.
+func (r *Repository) UpdateTeam(ctx context.Context, teamID uuid.UUID, name, description string) error {
+	op := "Repository.UpdateTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `UPDATE teams SET name = $2, description = $3, updated_at = NOW() WHERE id = $1`
+	res, err := r.DB.ExecContext(ctx, query, teamID, name, description)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%s: %w", op, ErrAlreadyExists)
+		}
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return affectedOne(op, res)
+}
+
+// CountEpicsForTeam returns the number of epics a team owns.
+func (r *Repository) CountEpicsForTeam(ctx context.Context, teamID uuid.UUID) (int, error) {
+	op := "Repository.CountEpicsForTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var count int
+	query := `SELECT COUNT(*) FROM epics WHERE team_id = $1`
+	err := r.DB.QueryRowContext(ctx, query, teamID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return count, nil
+}
+
+// DeleteTeam removes a team and its memberships in one transaction. The
+// caller must make sure the team owns no epics.
+func (r *Repository) DeleteTeam(ctx context.Context, teamID uuid.UUID) error {
+	op := "Repository.DeleteTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		return execAll(ctx, tx, []any{teamID},
+			`DELETE FROM user_teams WHERE team_id = $1`,
+			`DELETE FROM teams WHERE id = $1`,
+		)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// SetTeamTimezone stores the IANA timezone used to display the team's
+// timestamps.
+func (r *Repository) SetTeamTimezone(ctx context.Context, teamID uuid.UUID, timezone string) error {
+	op := "Repository.SetTeamTimezone"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `UPDATE teams SET timezone = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.DB.ExecContext(ctx, query, teamID, timezone)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// ArchiveTeam hides a team from team pickers. Its epics and members are
+// kept. Archiving an archived team keeps the original timestamp.
+func (r *Repository) ArchiveTeam(ctx context.Context, teamID uuid.UUID) error {
+	op := "Repository.ArchiveTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `UPDATE teams SET archived_at = COALESCE(archived_at, NOW()), updated_at = NOW()
+		WHERE id = $1`
+	res, err := r.DB.ExecContext(ctx, query, teamID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return affectedOne(op, res)
+}
+
+// UnarchiveTeam returns an archived team to the team pickers.
+func (r *Repository) UnarchiveTeam(ctx context.Context, teamID uuid.UUID) error {
+	op := "Repository.UnarchiveTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `UPDATE teams SET archived_at = NULL, updated_at = NOW() WHERE id = $1`
+	res, err := r.DB.ExecContext(ctx, query, teamID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return affectedOne(op, res)
+}
+
+// GetRoleDistributionForTeam returns the roles held by members of a team
+// with the number of members holding each. Roles nobody in the team holds
+// are omitted.
+func (r *Repository) GetRoleDistributionForTeam(ctx context.Context, teamID uuid.UUID) ([]domain.RoleWithUserCount, error) {
+	op := "Repository.GetRoleDistributionForTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var roles []domain.RoleWithUserCount
+	query := `SELECT r.id, r.name, r.description, COUNT(ut.user_id)
+		FROM user_teams ut
+		INNER JOIN user_roles ur ON ur.user_id = ut.user_id
+		INNER JOIN roles r ON r.id = ur.role_id
+		WHERE ut.team_id = $1
+		GROUP BY r.id, r.name, r.description
+		ORDER BY r.name`
+	rows, err := r.DB.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var role domain.RoleWithUserCount
+		if err := rows.Scan(&role.ID, &role.Name, &role.Description, &role.UserCount); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
@@ -0,0 +1,14 @@
+package repositories
+
+import "context"
+
+// queryContext derives the context for one repository call from ctx,
+// limited to the configured query timeout, so a hung query cannot block
+// its caller forever. Cancelling ctx, e.g. on shutdown, still cancels the
+// call. The returned cancel must always be called.
+func (r *Repository) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.queryTimeout)
+}
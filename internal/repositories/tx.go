@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// withTx runs fn inside a transaction. The transaction is committed when fn
+// returns nil and rolled back otherwise.
+func (r *Repository) withTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+	return nil
+}
+
+// execAll runs the statements in order with the same arguments,
+// stopping at the first error.
+func execAll(ctx context.Context, tx *sqlx.Tx, args []any, queries ...string) error {
+	for _, q := range queries {
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
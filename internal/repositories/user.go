@@ -4,18 +4,25 @@ import (
 	"EpicScoreBot/internal/models/domain"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
-// CreateUser inserts a new user.
+// CreateUser inserts a new user. Telegram usernames are case-insensitive
+// and are stored in lower case; ErrAlreadyExists is returned when the
+// username is already registered in any case.
 func (r *Repository) CreateUser(ctx context.Context, firstName, lastName string, telegramID string, weight int) (*domain.User, error) {
 	op := "Repository.CreateUser"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	user := &domain.User{
 		ID:         uuid.New(),
 		FirstName:  firstName,
 		LastName:   lastName,
-		TelegramID: telegramID,
+		TelegramID: strings.ToLower(telegramID),
 		Weight:     weight,
 	}
 
@@ -25,25 +32,30 @@ func (r *Repository) CreateUser(ctx context.Context, firstName, lastName string,
 	err := r.DB.QueryRowContext(ctx, query,
 		user.ID, user.FirstName, user.LastName, user.TelegramID, user.Weight).
 		Scan(&user.CreatedAt, &user.UpdatedAt)
+	if isUniqueViolation(err) {
+		return nil, fmt.Errorf("%s: %w", op, ErrAlreadyExists)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	return user, nil
 }
 
-// FindUserByTelegramID returns a user by Telegram ID.
+// FindUserByTelegramID returns a user by Telegram username, ignoring case.
 func (r *Repository) FindUserByTelegramID(ctx context.Context, telegramID string) (*domain.User, error) {
 	op := "Repository.FindUserByTelegramID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var user domain.User
 	query := `SELECT id, first_name, last_name, telegram_id, weight,
 		created_at, updated_at
-		FROM users WHERE telegram_id = $1`
+		FROM users WHERE LOWER(telegram_id) = LOWER($1)`
 	err := r.DB.QueryRowContext(ctx, query, telegramID).
 		Scan(&user.ID, &user.FirstName, &user.LastName,
 			&user.TelegramID, &user.Weight,
 			&user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &user, nil
 }
@@ -51,6 +63,8 @@ func (r *Repository) FindUserByTelegramID(ctx context.Context, telegramID string
 // GetUsersByTeamID returns all users in a team.
 func (r *Repository) GetUsersByTeamID(ctx context.Context, teamID uuid.UUID) ([]domain.User, error) {
 	op := "Repository.GetUsersByTeamID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var users []domain.User
 	query := `SELECT u.id, u.first_name, u.last_name, u.telegram_id,
 		u.weight, u.created_at, u.updated_at
@@ -76,9 +90,57 @@ func (r *Repository) GetUsersByTeamID(ctx context.Context, teamID uuid.UUID) ([]
 	return users, nil
 }
 
+// GetTeamMembersWithChatID returns the members of a team whose private chat
+// with the bot is known, ordered by last name.
+func (r *Repository) GetTeamMembersWithChatID(ctx context.Context, teamID uuid.UUID) ([]domain.User, error) {
+	op := "Repository.GetTeamMembersWithChatID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var users []domain.User
+	query := `SELECT u.id, u.first_name, u.last_name, u.telegram_id,
+		u.weight, u.chat_id, u.created_at, u.updated_at
+		FROM users u
+		INNER JOIN user_teams ut ON u.id = ut.user_id
+		WHERE ut.team_id = $1 AND u.chat_id IS NOT NULL
+		ORDER BY u.last_name, u.first_name`
+	rows, err := r.DB.QueryContext(ctx, query, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.FirstName, &u.LastName,
+			&u.TelegramID, &u.Weight, &u.ChatID,
+			&u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// UpdateUserChatID records the user's private chat with the bot. Storing
+// the same chat again changes nothing.
+func (r *Repository) UpdateUserChatID(ctx context.Context, userID uuid.UUID, chatID int64) error {
+	op := "Repository.UpdateUserChatID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `UPDATE users SET chat_id = $1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $2 AND chat_id IS DISTINCT FROM $1`
+	_, err := r.DB.ExecContext(ctx, query, chatID, userID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 // GetUsersByTeamIDAndRoleID returns users in a team with a specific role.
 func (r *Repository) GetUsersByTeamIDAndRoleID(ctx context.Context, teamID, roleID uuid.UUID) ([]domain.User, error) {
 	op := "Repository.GetUsersByTeamIDAndRoleID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var users []domain.User
 	query := `SELECT u.id, u.first_name, u.last_name, u.telegram_id,
 		u.weight, u.created_at, u.updated_at
@@ -108,6 +170,8 @@ func (r *Repository) GetUsersByTeamIDAndRoleID(ctx context.Context, teamID, role
 // AssignUserRole assigns a role to a user. Ignores conflicts.
 func (r *Repository) AssignUserRole(ctx context.Context, userID, roleID uuid.UUID) error {
 	op := "Repository.AssignUserRole"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `INSERT INTO user_roles (user_id, role_id)
 		VALUES ($1, $2) ON CONFLICT DO NOTHING`
 	_, err := r.DB.ExecContext(ctx, query, userID, roleID)
@@ -117,9 +181,32 @@ func (r *Repository) AssignUserRole(ctx context.Context, userID, roleID uuid.UUI
 	return nil
 }
 
+// ReplaceUserRole atomically replaces whatever roles a user holds with
+// the given one, keeping the one-role-per-user invariant.
+func (r *Repository) ReplaceUserRole(ctx context.Context, userID, newRoleID uuid.UUID) error {
+	op := "Repository.ReplaceUserRole"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM user_roles WHERE user_id = $1`, userID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx,
+			`INSERT INTO user_roles (user_id, role_id) VALUES ($1, $2)`, userID, newRoleID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
 // AssignUserTeam assigns a user to a team. Ignores conflicts.
 func (r *Repository) AssignUserTeam(ctx context.Context, userID, teamID uuid.UUID) error {
 	op := "Repository.AssignUserTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `INSERT INTO user_teams (user_id, team_id)
 		VALUES ($1, $2) ON CONFLICT DO NOTHING`
 	_, err := r.DB.ExecContext(ctx, query, userID, teamID)
@@ -132,6 +219,8 @@ func (r *Repository) AssignUserTeam(ctx context.Context, userID, teamID uuid.UUI
 // GetUserByID returns a user by ID.
 func (r *Repository) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
 	op := "Repository.GetUserByID"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var user domain.User
 	query := `SELECT id, first_name, last_name, telegram_id, weight,
 		created_at, updated_at
@@ -141,18 +230,52 @@ func (r *Repository) GetUserByID(ctx context.Context, userID uuid.UUID) (*domain
 			&user.TelegramID, &user.Weight,
 			&user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, notFound(err))
 	}
 	return &user, nil
 }
 
-// GetAllUsers returns every registered user.
+// GetUsersByIDs returns the users with the given IDs keyed by ID in one
+// query. IDs without a user are absent from the map.
+func (r *Repository) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.User, error) {
+	op := "Repository.GetUsersByIDs"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	strIDs := make([]string, len(ids))
+	for i, id := range ids {
+		strIDs[i] = id.String()
+	}
+	query := `SELECT id, first_name, last_name, telegram_id, weight,
+		created_at, updated_at
+		FROM users WHERE id = ANY($1::uuid[])`
+	rows, err := r.DB.QueryContext(ctx, query, pq.Array(strIDs))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	users := make(map[uuid.UUID]domain.User, len(ids))
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.FirstName, &u.LastName,
+			&u.TelegramID, &u.Weight,
+			&u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		users[u.ID] = u
+	}
+	return users, nil
+}
+
+// GetAllUsers returns every registered user ordered by last name.
 func (r *Repository) GetAllUsers(ctx context.Context) ([]domain.User, error) {
 	op := "Repository.GetAllUsers"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	var users []domain.User
 	query := `SELECT id, first_name, last_name, telegram_id, weight,
 		created_at, updated_at
-		FROM users ORDER BY last_name, first_name`
+		FROM users ORDER BY last_name, first_name, id`
 	rows, err := r.DB.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
@@ -171,57 +294,180 @@ func (r *Repository) GetAllUsers(ctx context.Context) ([]domain.User, error) {
 	return users, nil
 }
 
-// RemoveUserRole removes a role assignment from a user.
-func (r *Repository) RemoveUserRole(ctx context.Context, userID, roleID uuid.UUID) error {
+// GetUsersPage returns up to limit users starting at offset, in the same
+// stable order as GetAllUsers.
+func (r *Repository) GetUsersPage(ctx context.Context, limit, offset int) ([]domain.User, error) {
+	op := "Repository.GetUsersPage"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var users []domain.User
+	query := `SELECT id, first_name, last_name, telegram_id, weight,
+		created_at, updated_at
+		FROM users ORDER BY last_name, first_name, id
+		LIMIT $1 OFFSET $2`
+	rows, err := r.DB.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u domain.User
+		if err := rows.Scan(&u.ID, &u.FirstName, &u.LastName,
+			&u.TelegramID, &u.Weight,
+			&u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		users = append(users, u)
+	}
+	return users, nil
+}
+
+// IsUserInTeam reports whether a user is a member of a team.
+func (r *Repository) IsUserInTeam(ctx context.Context, userID, teamID uuid.UUID) (bool, error) {
+	op := "Repository.IsUserInTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var exists bool
+	query := `SELECT EXISTS (
+		SELECT 1 FROM user_teams WHERE user_id = $1 AND team_id = $2
+	)`
+	if err := r.DB.QueryRowContext(ctx, query, userID, teamID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return exists, nil
+}
+
+// RemoveUserRole removes a role assignment from a user and returns the
+// number of assignments removed, 0 when the user did not have the role.
+func (r *Repository) RemoveUserRole(ctx context.Context, userID, roleID uuid.UUID) (int, error) {
 	op := "Repository.RemoveUserRole"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2`
-	_, err := r.DB.ExecContext(ctx, query, userID, roleID)
+	res, err := r.DB.ExecContext(ctx, query, userID, roleID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return int(n), nil
 }
 
-// RemoveUserTeam removes a user from a team.
-func (r *Repository) RemoveUserTeam(ctx context.Context, userID, teamID uuid.UUID) error {
+// RemoveUserTeam removes a user from a team and returns the number of
+// memberships removed, 0 when the user was not in the team.
+func (r *Repository) RemoveUserTeam(ctx context.Context, userID, teamID uuid.UUID) (int, error) {
 	op := "Repository.RemoveUserTeam"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
 	query := `DELETE FROM user_teams WHERE user_id = $1 AND team_id = $2`
-	_, err := r.DB.ExecContext(ctx, query, userID, teamID)
+	res, err := r.DB.ExecContext(ctx, query, userID, teamID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return int(n), nil
 }
 
-// DeleteUser deletes a user by ID.
-// Related records in user_roles, user_teams, epic_scores, and risk_scores
-// are removed automatically by the database's ON DELETE CASCADE constraints.
-func (r *Repository) DeleteUser(ctx context.Context, userID uuid.UUID) error {
-	op := "Repository.DeleteUser"
-	query := `DELETE FROM users WHERE id = $1`
-	_, err := r.DB.ExecContext(ctx, query, userID)
+// DeleteUserTx deletes a user by ID together with their roles, team
+// memberships, scores and comments in one transaction and returns what it
+// removed for RestoreUser. Dependent rows are deleted explicitly instead
+// of relying on ON DELETE CASCADE. Returns ErrNotFound when the user does
+// not exist.
+func (r *Repository) DeleteUserTx(ctx context.Context, userID uuid.UUID) (domain.UserSnapshot, error) {
+	op := "Repository.DeleteUserTx"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	var snap domain.UserSnapshot
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		var err error
+		if err = execAll(ctx, tx, []any{userID},
+			`SELECT 1 FROM users WHERE id = $1 FOR UPDATE`,
+		); err != nil {
+			return err
+		}
+		if snap.Tables, err = captureRows(ctx, tx, userSnapshotTables, userID); err != nil {
+			return err
+		}
+		if err := execAll(ctx, tx, []any{userID},
+			`DELETE FROM risk_scores WHERE user_id = $1`,
+			`DELETE FROM risk_skips WHERE user_id = $1`,
+			`DELETE FROM epic_scores WHERE user_id = $1`,
+			`DELETE FROM epic_comments WHERE user_id = $1`,
+			`DELETE FROM user_roles WHERE user_id = $1`,
+			`DELETE FROM user_teams WHERE user_id = $1`,
+		); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, userID)
+		if err != nil {
+			return err
+		}
+		return affectedOne("delete user", res)
+	})
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return domain.UserSnapshot{}, fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+	return snap, nil
 }
 
 // UpdateUserName updates first and last name for a user.
 func (r *Repository) UpdateUserName(ctx context.Context, userID uuid.UUID, firstName, lastName string) error {
 	op := "Repository.UpdateUserName"
-	query := `UPDATE users SET first_name = $2, last_name = $3, updated_at = NOW() WHERE id = $1`
-	_, err := r.DB.ExecContext(ctx, query, userID, firstName, lastName)
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	firstName = strings.TrimSpace(firstName)
+	lastName = strings.TrimSpace(lastName)
+	if firstName == "" || lastName == "" {
+		return fmt.Errorf("%s: empty name: %w", op, ErrInvalidInput)
+	}
+	query := `UPDATE users SET first_name = $2, last_name = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	res, err := r.DB.ExecContext(ctx, query, userID, firstName, lastName)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	return nil
+	return affectedOne(op, res)
 }
 
-// UpdateUserWeight updates the weight for a user.
+// UpdateUserWeight updates the weight for a user, clamped to 0–100.
 func (r *Repository) UpdateUserWeight(ctx context.Context, userID uuid.UUID, weight int) error {
 	op := "Repository.UpdateUserWeight"
-	query := `UPDATE users SET weight = $2, updated_at = NOW() WHERE id = $1`
-	_, err := r.DB.ExecContext(ctx, query, userID, weight)
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	weight = min(max(weight, 0), 100)
+	query := `UPDATE users SET weight = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	res, err := r.DB.ExecContext(ctx, query, userID, weight)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return affectedOne(op, res)
+}
+
+// UpdateWeights sets the weights of several users in one transaction,
+// each clamped to 0–100. Returns ErrNotFound, changing nothing, when any
+// of the users does not exist.
+func (r *Repository) UpdateWeights(ctx context.Context, weights map[uuid.UUID]int) error {
+	op := "Repository.UpdateWeights"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	err := r.withTx(ctx, func(tx *sqlx.Tx) error {
+		query := `UPDATE users SET weight = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+		for userID, weight := range weights {
+			res, err := tx.ExecContext(ctx, query, userID, min(max(weight, 0), 100))
+			if err != nil {
+				return err
+			}
+			if err := affectedOne(userID.String(), res); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
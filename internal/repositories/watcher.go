@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"EpicScoreBot/internal/models/domain"
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// AddEpicWatcher subscribes a chat to the final score of an epic. Reports
+// false when the chat was already subscribed.
+func (r *Repository) AddEpicWatcher(ctx context.Context, w domain.EpicWatcher) (bool, error) {
+	op := "Repository.AddEpicWatcher"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `INSERT INTO epic_watchers (epic_id, chat_id, thread_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING`
+	res, err := r.DB.ExecContext(ctx, query, w.EpicID, w.ChatID, w.ThreadID)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+	return n > 0, nil
+}
+
+// RemoveEpicWatcher cancels a subscription. Returns ErrNotFound when the
+// chat was not subscribed.
+func (r *Repository) RemoveEpicWatcher(ctx context.Context, w domain.EpicWatcher) error {
+	op := "Repository.RemoveEpicWatcher"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `DELETE FROM epic_watchers
+		WHERE epic_id = $1 AND chat_id = $2 AND thread_id = $3`
+	res, err := r.DB.ExecContext(ctx, query, w.EpicID, w.ChatID, w.ThreadID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return affectedOne(op, res)
+}
+
+// TakeEpicWatchers removes and returns all subscriptions to an epic, so
+// each chat is notified once even if the epic is finalized again.
+func (r *Repository) TakeEpicWatchers(ctx context.Context, epicID uuid.UUID) ([]domain.EpicWatcher, error) {
+	op := "Repository.TakeEpicWatchers"
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+	query := `DELETE FROM epic_watchers WHERE epic_id = $1
+		RETURNING epic_id, chat_id, thread_id`
+	rows, err := r.DB.QueryContext(ctx, query, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var watchers []domain.EpicWatcher
+	for rows.Next() {
+		var w domain.EpicWatcher
+		if err := rows.Scan(&w.EpicID, &w.ChatID, &w.ThreadID); err != nil {
+			return nil, fmt.Errorf("%s: scan: %w", op, err)
+		}
+		watchers = append(watchers, w)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	return watchers, nil
+}
@@ -0,0 +1,80 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"EpicScoreBot/internal/utils/logger/sl"
+)
+
+// defaultDeadlineCheckInterval is used when the configured interval is not positive.
+const defaultDeadlineCheckInterval = time.Minute
+
+// RunDeadlineWatcher periodically closes SCORING epics whose deadline has
+// passed, finalizing them with the scores submitted so far. It blocks until
+// ctx is cancelled.
+func (s *Service) RunDeadlineWatcher(ctx context.Context) {
+	interval := s.cfg.DeadlineCheckInterval
+	if interval <= 0 {
+		interval = defaultDeadlineCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.log.Info("scoring deadline watcher started", slog.Duration("interval", interval))
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("scoring deadline watcher stopped")
+			return
+		case now := <-ticker.C:
+			if err := s.closeExpiredEpics(ctx, now); err != nil {
+				s.log.Error("failed to close expired epics", sl.Err(err))
+			}
+		}
+	}
+}
+
+// closeExpiredEpics force-finalizes every SCORING epic past its deadline
+// and announces the results.
+func (s *Service) closeExpiredEpics(ctx context.Context, now time.Time) error {
+	op := "scoring.closeExpiredEpics"
+
+	epics, err := s.repo.GetExpiredScoringEpics(ctx, now)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	for _, epic := range epics {
+		err := s.CloseEpicScoring(ctx, epic.ID)
+		switch {
+		case errors.Is(err, ErrNoScores):
+			// Nothing to finalize: drop the deadline so the epic is not
+			// picked up again on every tick and leave it to the admins.
+			s.log.Warn("scoring deadline passed without scores",
+				slog.String("epicID", epic.ID.String()))
+			if err := s.repo.SetEpicScoringDeadline(ctx, epic.ID, nil); err != nil {
+				s.log.Error("failed to clear scoring deadline",
+					slog.String("epicID", epic.ID.String()), sl.Err(err))
+			}
+			continue
+		case errors.Is(err, ErrNotScoring):
+			// Finalized since it was looked up.
+			continue
+		case err != nil:
+			s.log.Error("failed to close epic scoring by deadline",
+				slog.String("epicID", epic.ID.String()), sl.Err(err))
+			continue
+		}
+
+		s.log.Info("epic scoring closed by deadline",
+			slog.String("epicID", epic.ID.String()))
+		if s.notifier != nil {
+			s.notifier.AnnounceEpicClosed(ctx, epic.ID)
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package scoring
 
 import (
 	"context"
+	"time"
 
 	"EpicScoreBot/internal/models/domain"
 
@@ -11,16 +12,30 @@ import (
 // Repository defines the data-access contract required by the scoring service.
 type Repository interface {
 	GetEpicScoresByEpicIDAndRoleID(ctx context.Context, epicID, roleID uuid.UUID) ([]domain.EpicScore, error)
-	GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.User, error)
 	GetRiskScoresByRiskID(ctx context.Context, riskID uuid.UUID) ([]domain.RiskScore, error)
 	GetRiskByID(ctx context.Context, riskID uuid.UUID) (*domain.Risk, error)
 	GetEpicByID(ctx context.Context, epicID uuid.UUID) (*domain.Epic, error)
 	CountTeamMembers(ctx context.Context, teamID uuid.UUID) (int, error)
 	CountRiskScores(ctx context.Context, riskID uuid.UUID) (int, error)
+	CountRiskSkips(ctx context.Context, riskID uuid.UUID) (int, error)
 	SetRiskWeightedScore(ctx context.Context, riskID uuid.UUID, score float64) error
 	CountEpicScores(ctx context.Context, epicID uuid.UUID) (int, error)
+	CountEpicScorers(ctx context.Context, epicID uuid.UUID) (int, error)
 	GetDistinctRoleIDsForEpicScores(ctx context.Context, epicID uuid.UUID) ([]uuid.UUID, error)
 	UpsertEpicRoleScore(ctx context.Context, epicID, roleID uuid.UUID, weightedAvg float64) error
+	ReplaceEpicResults(ctx context.Context, epicID uuid.UUID, roleAvgs map[uuid.UUID]float64, finalScore float64) error
 	GetRisksByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.Risk, error)
-	SetEpicFinalScore(ctx context.Context, epicID uuid.UUID, score float64) error
+	SetEpicFinalScore(ctx context.Context, epicID uuid.UUID, score float64) (*time.Time, time.Time, error)
+	UpdateRiskStatus(ctx context.Context, riskID uuid.UUID, status domain.Status) error
+	GetExpiredScoringEpics(ctx context.Context, now time.Time) ([]domain.Epic, error)
+	SetEpicScoringDeadline(ctx context.Context, epicID uuid.UUID, deadline *time.Time) error
+}
+
+// Notifier announces epics whose scoring was finished automatically and
+// tells the chats watching an epic its final score.
+type Notifier interface {
+	AnnounceEpicClosed(ctx context.Context, epicID uuid.UUID)
+	AnnounceEpicScored(ctx context.Context, epicID uuid.UUID, baseScore float64)
+	NotifyEpicWatchers(ctx context.Context, epicID uuid.UUID)
 }
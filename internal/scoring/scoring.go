@@ -1,32 +1,91 @@
 package scoring
 
 import (
+	"EpicScoreBot/internal/config"
 	"EpicScoreBot/internal/models/domain"
 	"context"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"log/slog"
 	"math"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrNoScores is returned when an epic cannot be finalized because
+// nobody has submitted an effort score yet.
+var ErrNoScores = errors.New("no scores submitted")
+
+// ErrEmptyTeam is returned when scoring cannot complete because the epic's
+// team has no members, or none holding a required role, so no quorum can
+// ever be reached.
+var ErrEmptyTeam = errors.New("team has no members")
+
+// ErrZeroWeight is returned when every scorer of a weighted average has
+// weight 0 and the configuration rejects falling back to the plain mean.
+var ErrZeroWeight = errors.New("all scorers have zero weight")
+
+// ErrNotScored is returned when recalculating an epic whose scoring has
+// not completed.
+var ErrNotScored = errors.New("epic is not scored yet")
+
+// ErrNotScoring is returned when force-closing an epic that is not being
+// scored, e.g. from a stale confirmation of an epic already finalized.
+var ErrNotScoring = errors.New("epic is not being scored")
+
+// ErrEffortOutOfRange is returned for an effort score outside
+// MinEffortScore and the configured maximum.
+var ErrEffortOutOfRange = errors.New("effort score out of range")
+
+// MinEffortScore is the smallest effort score a user may submit.
+const MinEffortScore = 0
+
 // Service provides scoring business logic.
 type Service struct {
-	repo Repository
-	log  *slog.Logger
+	repo     Repository
+	notifier Notifier
+	cfg      config.ScoringConfig
+	log      *slog.Logger
+
+	// epicLocks serializes finalization per epic so that concurrent last
+	// submissions finalize an epic only once. Epic IDs are spread over a
+	// fixed number of mutexes, see lockEpic.
+	epicLocks [epicLockStripes]sync.Mutex
 }
 
 // New creates a new scoring service.
-func New(logger *slog.Logger, repo Repository) *Service {
+func New(logger *slog.Logger, cfg *config.Config, repo Repository) *Service {
 	return &Service{
 		repo: repo,
+		cfg:  cfg.Scoring,
 		log:  logger.With(slog.String("component", "scoring")),
 	}
 }
 
+// SetNotifier sets the notifier used to announce epics finished by quorum
+// or closed by deadline.
+func (s *Service) SetNotifier(n Notifier) {
+	s.notifier = n
+}
+
+// ValidateEffort checks that an effort score is between MinEffortScore and
+// the configured maximum.
+func (s *Service) ValidateEffort(score int) error {
+	if score < MinEffortScore || score > s.cfg.EffortMax() {
+		return fmt.Errorf("%w: %d not in %d..%d",
+			ErrEffortOutOfRange, score, MinEffortScore, s.cfg.EffortMax())
+	}
+	return nil
+}
+
 // CalculateEpicRoleAvg computes the weighted average score
 // for a specific role on an epic.
 // Formula: Σ(score_i × weight_i) / Σ(weight_i)
+// If every scorer has weight 0, see weightedAverage.
 func (s *Service) CalculateEpicRoleAvg(ctx context.Context, epicID, roleID uuid.UUID) (float64, error) {
 	op := "scoring.CalculateEpicRoleAvg"
 
@@ -39,26 +98,58 @@ func (s *Service) CalculateEpicRoleAvg(ctx context.Context, epicID, roleID uuid.
 		return 0, nil
 	}
 
-	var weightedSum float64
-	var totalWeight float64
+	userIDs := make([]uuid.UUID, len(scores))
+	for i, sc := range scores {
+		userIDs[i] = sc.UserID
+	}
+	users, err := s.usersByIDs(ctx, userIDs)
+	if err != nil {
+		return 0, fmt.Errorf("%s: get users: %w", op, err)
+	}
 
-	for _, sc := range scores {
-		user, err := s.repo.GetUserByID(ctx, sc.UserID)
-		if err != nil {
-			return 0, fmt.Errorf("%s: get user: %w", op, err)
-		}
-		w := float64(user.Weight)
-		weightedSum += float64(sc.Score) * w
-		totalWeight += w
+	values := make([]float64, len(scores))
+	weights := make([]float64, len(scores))
+	for i, sc := range scores {
+		values[i] = float64(sc.Score)
+		weights[i] = float64(users[sc.UserID].Weight)
 	}
 
-	if totalWeight == 0 {
-		return 0, nil
+	avg, err := s.weightedAverage(values, weights)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
+	return avg, nil
+}
 
-	return weightedSum / totalWeight, nil
+// weightedAverage returns Σ(value_i × weight_i) / Σ(weight_i). When the
+// weights add up to 0 the result would be meaningless, so it returns the
+// plain mean of the values, or ErrZeroWeight if the configuration rejects
+// the fallback.
+func (s *Service) weightedAverage(values, weights []float64) (float64, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+	var weightedSum, totalWeight, sum float64
+	for i, v := range values {
+		weightedSum += v * weights[i]
+		totalWeight += weights[i]
+		sum += v
+	}
+	if totalWeight > 0 {
+		return weightedSum / totalWeight, nil
+	}
+	if s.cfg.RejectZeroWeight() {
+		return 0, ErrZeroWeight
+	}
+	s.log.Warn("all scorers have zero weight, using the plain mean",
+		slog.Int("scores", len(values)))
+	return sum / float64(len(values)), nil
 }
 
+// BaselineRiskCoefficient is the coefficient of the lowest risk band,
+// which most minor risks end up in.
+const BaselineRiskCoefficient = 1.05
+
 // RiskCoefficient maps a weighted risk score to a multiplier coefficient.
 func RiskCoefficient(weightedScore float64) float64 {
 	rounded := math.Round(weightedScore)
@@ -70,13 +161,97 @@ func RiskCoefficient(weightedScore float64) float64 {
 	case rounded >= 5:
 		return 1.10
 	default:
-		return 1.05
+		return BaselineRiskCoefficient
+	}
+}
+
+// ImportanceFactor returns how much of a risk coefficient's effect is
+// applied for the given importance. Medium (and unset) importance keeps
+// the coefficient as is.
+func ImportanceFactor(importance domain.Importance) float64 {
+	switch importance {
+	case domain.ImportanceLow:
+		return 0.5
+	case domain.ImportanceHigh:
+		return 1.5
+	default:
+		return 1.0
+	}
+}
+
+// EffectiveRiskCoefficient blends the risk coefficient with the risk
+// importance: only the increase over 1 is scaled, so
+// effective = 1 + (coefficient − 1) × importance factor.
+// For example, a coefficient of 1.20 becomes 1.10 for a low-importance
+// risk and 1.30 for a high-importance one.
+func EffectiveRiskCoefficient(weightedScore float64, importance domain.Importance) float64 {
+	return 1 + (RiskCoefficient(weightedScore)-1)*ImportanceFactor(importance)
+}
+
+// MaxSummedRiskCoefficient caps the multiplier of the sumcapped risk
+// aggregation, so many minor risks cannot outweigh the scale of the
+// largest single risk.
+const MaxSummedRiskCoefficient = 1.5
+
+// RiskMultiplier combines the effective coefficients of the scored risks
+// into the multiplier of the base score by the given aggregation mode.
+// Without scored risks it is 1.
+func RiskMultiplier(mode string, risks []domain.Risk) float64 {
+	var coeffs []float64
+	for _, risk := range risks {
+		if risk.Status == domain.StatusScored && risk.WeightedScore != nil {
+			coeffs = append(coeffs, EffectiveRiskCoefficient(*risk.WeightedScore, risk.Importance))
+		}
+	}
+	return combineRiskCoefficients(mode, coeffs)
+}
+
+// combineRiskCoefficients combines risk coefficients by the aggregation
+// mode: product multiplies them, max takes the largest, and sumcapped
+// adds up their increases over 1, so 1.10 and 1.20 give 1.30, up to
+// MaxSummedRiskCoefficient. Unknown modes fall back to product.
+func combineRiskCoefficients(mode string, coeffs []float64) float64 {
+	switch strings.ToLower(mode) {
+	case config.RiskAggregationMax:
+		m := 1.0
+		for _, c := range coeffs {
+			m = math.Max(m, c)
+		}
+		return m
+	case config.RiskAggregationSumCapped:
+		sum := 1.0
+		for _, c := range coeffs {
+			sum += c - 1
+		}
+		return math.Min(sum, MaxSummedRiskCoefficient)
+	default:
+		product := 1.0
+		for _, c := range coeffs {
+			product *= c
+		}
+		return product
 	}
 }
 
+// usersByIDs loads the scorers of a calculation in one query. Every ID
+// must resolve to a user, as with per-score lookups.
+func (s *Service) usersByIDs(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.User, error) {
+	users, err := s.repo.GetUsersByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if _, ok := users[id]; !ok {
+			return nil, fmt.Errorf("scorer %s not found", id)
+		}
+	}
+	return users, nil
+}
+
 // CalculateRiskWeightedScore computes the weighted average risk score.
 // Each user's risk score = probability × impact.
 // weighted_avg = Σ(score_i × weight_i) / Σ(weight_i)
+// If every scorer has weight 0, see weightedAverage.
 func (s *Service) CalculateRiskWeightedScore(ctx context.Context, riskID uuid.UUID) (float64, error) {
 	op := "scoring.CalculateRiskWeightedScore"
 
@@ -89,29 +264,34 @@ func (s *Service) CalculateRiskWeightedScore(ctx context.Context, riskID uuid.UU
 		return 0, nil
 	}
 
-	var weightedSum float64
-	var totalWeight float64
-
-	for _, rs := range riskScores {
-		user, err := s.repo.GetUserByID(ctx, rs.UserID)
-		if err != nil {
-			return 0, fmt.Errorf("%s: get user: %w", op, err)
-		}
-		userScore := float64(rs.Probability * rs.Impact)
-		w := float64(user.Weight)
-		weightedSum += userScore * w
-		totalWeight += w
+	userIDs := make([]uuid.UUID, len(riskScores))
+	for i, rs := range riskScores {
+		userIDs[i] = rs.UserID
+	}
+	users, err := s.usersByIDs(ctx, userIDs)
+	if err != nil {
+		return 0, fmt.Errorf("%s: get users: %w", op, err)
 	}
 
-	if totalWeight == 0 {
-		return 0, nil
+	values := make([]float64, len(riskScores))
+	weights := make([]float64, len(riskScores))
+	for i, rs := range riskScores {
+		values[i] = float64(rs.Probability * rs.Impact)
+		weights[i] = float64(users[rs.UserID].Weight)
 	}
 
-	return weightedSum / totalWeight, nil
+	avg, err := s.weightedAverage(values, weights)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+	return avg, nil
 }
 
-// TryCompleteRiskScoring checks if all team members have scored a risk.
-// If so, calculates the weighted score and saves it.
+// TryCompleteRiskScoring checks if the scoring quorum of team members has
+// scored or skipped a risk. If so, calculates the weighted score over the
+// submitted scores and saves it; a risk everybody skipped is marked as
+// skipped. It does nothing once the epic is no longer being scored, so
+// it is safe to call after every vote.
 func (s *Service) TryCompleteRiskScoring(ctx context.Context, riskID uuid.UUID) error {
 	op := "scoring.TryCompleteRiskScoring"
 	log := slog.With(
@@ -127,6 +307,12 @@ func (s *Service) TryCompleteRiskScoring(ctx context.Context, riskID uuid.UUID)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
+	if epic.Status != domain.StatusScoring {
+		log.Debug("epic is not being scored, risk left as is",
+			slog.String("riskID", riskID.String()),
+			slog.String("status", string(epic.Status)))
+		return nil
+	}
 
 	teamMembers, err := s.repo.CountTeamMembers(ctx, epic.TeamID)
 	if err != nil {
@@ -138,122 +324,333 @@ func (s *Service) TryCompleteRiskScoring(ctx context.Context, riskID uuid.UUID)
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if riskScoreCount < teamMembers {
+	skipCount, err := s.repo.CountRiskSkips(ctx, riskID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if teamMembers == 0 {
+		log.Warn("risk scoring cannot complete: team has no members",
+			slog.String("riskID", riskID.String()),
+			slog.String("teamID", epic.TeamID.String()))
+		return fmt.Errorf("%s: %w", op, ErrEmptyTeam)
+	}
+
+	required := s.cfg.RequiredScores(teamMembers)
+	if riskScoreCount+skipCount < required {
 		log.Debug("risk scoring not complete yet",
 			slog.String("riskID", riskID.String()),
 			slog.Int("scored", riskScoreCount),
+			slog.Int("skipped", skipCount),
+			slog.Int("required", required),
 			slog.Int("total", teamMembers))
 		return nil
 	}
 
-	weightedScore, err := s.CalculateRiskWeightedScore(ctx, riskID)
-	if err != nil {
+	if err := s.closeRisk(ctx, risk); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := s.repo.SetRiskWeightedScore(ctx, riskID, weightedScore); err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	if risk.WeightedScore != nil {
+		log.Info("risk scoring completed",
+			slog.String("riskID", riskID.String()),
+			slog.Float64("weightedScore", *risk.WeightedScore),
+			slog.Float64("coefficient", RiskCoefficient(*risk.WeightedScore)))
+	} else {
+		log.Info("risk skipped by all scorers", slog.String("riskID", riskID.String()))
 	}
 
-	log.Info("risk scoring completed",
-		slog.String("riskID", riskID.String()),
-		slog.Float64("weightedScore", weightedScore),
-		slog.Float64("coefficient", RiskCoefficient(weightedScore)))
-
 	// Try to complete the epic scoring too
 	return s.TryCompleteEpicScoring(ctx, risk.EpicID)
 }
 
-// TryCompleteEpicScoring checks if all team members have scored an epic
-// and all its risks are scored. If so, calculates the final score.
+// TryCompleteEpicScoring checks if the scoring quorum of team members has
+// scored an epic and all its risks are scored. If so, calculates the final
+// score. Role averages are computed over the submitted scores only.
+// It returns ErrEmptyTeam when no team member is expected to score it.
 func (s *Service) TryCompleteEpicScoring(ctx context.Context, epicID uuid.UUID) error {
-	op := "scoring.TryCompleteEpicScoring"
+	return s.finalizeEpic(ctx, epicID, false)
+}
+
+// CloseEpicScoring force-finalizes an epic with whatever scores exist.
+// Partially scored risks are finalized over the submitted scores, risks
+// nobody scored are marked as skipped and do not affect the final score.
+// It returns ErrNotScoring unless the epic is being scored.
+func (s *Service) CloseEpicScoring(ctx context.Context, epicID uuid.UUID) error {
+	return s.finalizeEpic(ctx, epicID, true)
+}
+
+// epicLockStripes is how many mutexes epic IDs are spread over. Epics
+// sharing a mutex only wait for each other's finalization, which does not
+// include sending notifications.
+const epicLockStripes = 64
+
+// lockEpic serializes finalization of one epic and returns the unlock
+// function.
+func (s *Service) lockEpic(epicID uuid.UUID) func() {
+	h := fnv.New32a()
+	h.Write(epicID[:])
+	m := &s.epicLocks[h.Sum32()%epicLockStripes]
+	m.Lock()
+	return m.Unlock
+}
+
+// finalizeEpic calculates role averages, applies risk coefficients
+// (see EffectiveRiskCoefficient) and stores the final score rounded by the
+// configured RoundingMode. Unless allowPartial is set, it returns without
+// changes while the quorum is not reached or any risk is still unscored,
+// and for an epic that is not being scored; a forced close of such an
+// epic returns ErrNotScoring.
+func (s *Service) finalizeEpic(ctx context.Context, epicID uuid.UUID, allowPartial bool) error {
+	base, finalized, err := s.finalizeEpicLocked(ctx, epicID, allowPartial)
+	if err != nil || !finalized || s.notifier == nil {
+		return err
+	}
+	// Sent after unlocking, so a slow chat does not hold up other
+	// finalizations. Forced closes are announced by their callers;
+	// watchers are told either way.
+	if !allowPartial {
+		s.notifier.AnnounceEpicScored(ctx, epicID, base)
+	}
+	s.notifier.NotifyEpicWatchers(ctx, epicID)
+	return nil
+}
+
+// finalizeEpicLocked does the work of finalizeEpic under the epic's lock.
+// It returns the base score and whether the epic was finalized.
+func (s *Service) finalizeEpicLocked(
+	ctx context.Context,
+	epicID uuid.UUID,
+	allowPartial bool,
+) (float64, bool, error) {
+	op := "scoring.finalizeEpic"
 	log := slog.With(
 		slog.String("op", op),
 	)
 
+	// The status check below must see the result of a concurrent run.
+	defer s.lockEpic(epicID)()
+
 	epic, err := s.repo.GetEpicByID(ctx, epicID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if epic.Status == domain.StatusScored {
-		return nil
+	if epic.Status != domain.StatusScoring {
+		if allowPartial {
+			return 0, false, fmt.Errorf("%s: %w", op, ErrNotScoring)
+		}
+		return 0, false, nil
 	}
 
-	teamMembers, err := s.repo.CountTeamMembers(ctx, epic.TeamID)
+	epicScoreCount, err := s.repo.CountEpicScores(ctx, epicID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	epicScoreCount, err := s.repo.CountEpicScores(ctx, epicID)
+	if allowPartial {
+		if epicScoreCount == 0 {
+			return 0, false, fmt.Errorf("%s: %w", op, ErrNoScores)
+		}
+	} else {
+		// Only members holding one of the epic's required roles, if any,
+		// are expected to estimate its effort.
+		scorers, err := s.repo.CountEpicScorers(ctx, epicID)
+		if err != nil {
+			return 0, false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if scorers == 0 {
+			log.Warn("epic scoring cannot complete: no team member is expected to score it",
+				slog.String("epicID", epicID.String()),
+				slog.String("teamID", epic.TeamID.String()))
+			return 0, false, fmt.Errorf("%s: %w", op, ErrEmptyTeam)
+		}
+
+		required := s.cfg.RequiredScores(scorers)
+		if epicScoreCount == 0 || epicScoreCount < required {
+			log.Debug("epic scoring not complete yet",
+				slog.String("epicID", epicID.String()),
+				slog.Int("scored", epicScoreCount),
+				slog.Int("required", required),
+				slog.Int("total", scorers))
+			return 0, false, nil
+		}
+	}
+
+	// Check if all risks are scored
+	risks, err := s.repo.GetRisksByEpicID(ctx, epicID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if epicScoreCount < teamMembers {
-		log.Debug("epic scoring not complete yet",
-			slog.String("epicID", epicID.String()),
-			slog.Int("scored", epicScoreCount),
-			slog.Int("total", teamMembers))
-		return nil
+	for i, risk := range risks {
+		if risk.Status == domain.StatusScored || risk.Status == domain.StatusSkipped {
+			continue
+		}
+		if !allowPartial {
+			log.Debug("waiting for risk scoring",
+				slog.String("epicID", epicID.String()),
+				slog.String("riskID", risk.ID.String()))
+			return 0, false, nil
+		}
+		if err := s.closeRisk(ctx, &risks[i]); err != nil {
+			return 0, false, fmt.Errorf("%s: %w", op, err)
+		}
 	}
 
 	// Calculate weighted averages per role
-	roleIDs, err := s.repo.GetDistinctRoleIDsForEpicScores(ctx, epicID)
+	roleAvgs, epicBaseScore, err := s.roleAverages(ctx, epicID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return 0, false, fmt.Errorf("%s: %w", op, err)
+	}
+	for roleID, avg := range roleAvgs {
+		if err := s.repo.UpsertEpicRoleScore(ctx, epicID, roleID, avg); err != nil {
+			return 0, false, fmt.Errorf("%s: upsert role score: %w", op, err)
+		}
+	}
+
+	finalScore := s.finalScore(epicBaseScore, risks)
+
+	startedAt, scoredAt, err := s.repo.SetEpicFinalScore(ctx, epicID, finalScore)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var epicBaseScore float64
+	attrs := []any{
+		slog.String("epicID", epicID.String()),
+		slog.String("teamID", epic.TeamID.String()),
+		slog.Bool("partial", allowPartial),
+		slog.Float64("baseScore", epicBaseScore),
+		slog.Float64("finalScore", finalScore),
+		slog.String("rounding", s.cfg.RoundingMode),
+		slog.String("riskAggregation", s.cfg.RiskAggregation),
+	}
+	if d, ok := ScoringDuration(startedAt, scoredAt); ok {
+		attrs = append(attrs, slog.Duration("scoringDuration", d))
+	}
+	s.log.Info("epic scoring completed", attrs...)
+
+	return epicBaseScore, true, nil
+}
+
+// roleAverages computes the weighted average of every role that scored
+// the epic and returns them with their sum, the epic's base score. The
+// averages are rounded to the configured precision first, so the stored
+// averages add up to the base score the final score is computed from.
+func (s *Service) roleAverages(ctx context.Context, epicID uuid.UUID) (map[uuid.UUID]float64, float64, error) {
+	roleIDs, err := s.repo.GetDistinctRoleIDsForEpicScores(ctx, epicID)
+	if err != nil {
+		return nil, 0, err
+	}
+	avgs := make(map[uuid.UUID]float64, len(roleIDs))
+	var base float64
 	for _, roleID := range roleIDs {
 		avg, err := s.CalculateEpicRoleAvg(ctx, epicID, roleID)
 		if err != nil {
-			return fmt.Errorf("%s: role avg: %w", op, err)
+			return nil, 0, fmt.Errorf("role avg: %w", err)
 		}
+		avg = s.cfg.RoundRoleAverage(avg)
+		avgs[roleID] = avg
+		base += avg
+	}
+	// Rounded again so float error in the sum does not show up.
+	return avgs, s.cfg.RoundRoleAverage(base), nil
+}
 
-		if err := s.repo.UpsertEpicRoleScore(ctx, epicID, roleID, avg); err != nil {
-			return fmt.Errorf("%s: upsert role score: %w", op, err)
-		}
+// finalScore applies the coefficients of the scored risks, scaled by
+// their importance and combined by the configured RiskAggregation, to the
+// base score and rounds the result by the configured RoundingMode.
+func (s *Service) finalScore(base float64, risks []domain.Risk) float64 {
+	return s.cfg.RoundFinalScore(base * RiskMultiplier(s.cfg.RiskAggregation, risks))
+}
+
+// Recalculation is the outcome of RecalculateEpicRoleScores.
+type Recalculation struct {
+	RoleAvgs      map[uuid.UUID]float64
+	BaseScore     float64
+	PreviousFinal *float64
+	FinalScore    float64
+}
 
-		epicBaseScore += avg
+// RecalculateEpicRoleScores recomputes the role averages of a scored epic
+// with the current user weights, replaces the stored ones and
+// re-finalizes the epic with the new base score. Risk scores are kept.
+// It returns ErrNotScored for an epic that is not scored yet; its
+// averages are computed when scoring completes.
+func (s *Service) RecalculateEpicRoleScores(ctx context.Context, epicID uuid.UUID) (*Recalculation, error) {
+	op := "scoring.RecalculateEpicRoleScores"
+
+	defer s.lockEpic(epicID)()
+
+	epic, err := s.repo.GetEpicByID(ctx, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if epic.Status != domain.StatusScored {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotScored)
 	}
 
-	// Check if all risks are scored
+	roleAvgs, base, err := s.roleAverages(ctx, epicID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 	risks, err := s.repo.GetRisksByEpicID(ctx, epicID)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-
-	for _, risk := range risks {
-		if risk.Status != domain.StatusScored {
-			log.Debug("waiting for risk scoring",
-				slog.String("epicID", epicID.String()),
-				slog.String("riskID", risk.ID.String()))
-			return nil
-		}
+	final := s.finalScore(base, risks)
+	if err := s.repo.ReplaceEpicResults(ctx, epicID, roleAvgs, final); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	// Apply risk coefficients
-	finalScore := epicBaseScore
-	for _, risk := range risks {
-		if risk.WeightedScore != nil {
-			coeff := RiskCoefficient(*risk.WeightedScore)
-			finalScore *= coeff
-		}
+	s.log.Info("epic scores recalculated",
+		slog.String("epicID", epicID.String()),
+		slog.Float64("baseScore", base),
+		slog.Float64("finalScore", final))
+	return &Recalculation{
+		RoleAvgs:      roleAvgs,
+		BaseScore:     base,
+		PreviousFinal: epic.FinalScore,
+		FinalScore:    final,
+	}, nil
+}
+
+// ScoringDuration returns how long scoring took from startedAt to
+// scoredAt. It reports false when the start is unknown or after the end.
+func ScoringDuration(startedAt *time.Time, scoredAt time.Time) (time.Duration, bool) {
+	if startedAt == nil || scoredAt.Before(*startedAt) {
+		return 0, false
 	}
+	return scoredAt.Sub(*startedAt), true
+}
 
-	// Round to integer
-	finalScore = math.Round(finalScore)
+// closeRisk finalizes a risk over the submitted scores, or marks it as
+// skipped when nobody scored it. The passed risk is updated in place.
+func (s *Service) closeRisk(ctx context.Context, risk *domain.Risk) error {
+	op := "scoring.closeRisk"
 
-	if err := s.repo.SetEpicFinalScore(ctx, epicID, finalScore); err != nil {
+	count, err := s.repo.CountRiskScores(ctx, risk.ID)
+	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	s.log.Info("epic scoring completed",
-		slog.String("epicID", epicID.String()),
-		slog.Float64("baseScore", epicBaseScore),
-		slog.Float64("finalScore", finalScore))
+	if count == 0 {
+		if err := s.repo.UpdateRiskStatus(ctx, risk.ID, domain.StatusSkipped); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+		risk.Status = domain.StatusSkipped
+		return nil
+	}
 
+	weightedScore, err := s.CalculateRiskWeightedScore(ctx, risk.ID)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err := s.repo.SetRiskWeightedScore(ctx, risk.ID, weightedScore); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	risk.Status = domain.StatusScored
+	risk.WeightedScore = &weightedScore
 	return nil
 }
@@ -0,0 +1,672 @@
+package scoring
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+// fakeRepo keeps one epic scored by a single role in memory. Methods the
+// tests do not reach are left to the embedded nil interface.
+type fakeRepo struct {
+	Repository
+
+	mu          sync.Mutex
+	epic        domain.Epic
+	roleID      uuid.UUID
+	scores      []domain.EpicScore
+	users       map[uuid.UUID]domain.User
+	risks       []domain.Risk
+	riskScores  []domain.RiskScore
+	finalScores []float64
+	userQueries int
+	members     int // team size; the number of scorers when zero
+}
+
+func newFakeRepo(status domain.Status, efforts ...int) *fakeRepo {
+	r := &fakeRepo{
+		epic:   domain.Epic{ID: uuid.New(), Number: "1", TeamID: uuid.New(), Status: status},
+		roleID: uuid.New(),
+		users:  make(map[uuid.UUID]domain.User),
+	}
+	for _, e := range efforts {
+		u := domain.User{ID: uuid.New(), Weight: 100}
+		r.users[u.ID] = u
+		r.scores = append(r.scores, domain.EpicScore{EpicID: r.epic.ID, UserID: u.ID, RoleID: r.roleID, Score: e})
+	}
+	return r
+}
+
+func (r *fakeRepo) GetEpicByID(_ context.Context, _ uuid.UUID) (*domain.Epic, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e := r.epic
+	return &e, nil
+}
+
+func (r *fakeRepo) CountEpicScores(_ context.Context, _ uuid.UUID) (int, error) {
+	return len(r.scores), nil
+}
+
+func (r *fakeRepo) CountEpicScorers(_ context.Context, _ uuid.UUID) (int, error) {
+	if r.members > 0 {
+		return r.members, nil
+	}
+	return len(r.scores), nil
+}
+
+func (r *fakeRepo) CountTeamMembers(ctx context.Context, _ uuid.UUID) (int, error) {
+	return r.CountEpicScorers(ctx, r.epic.ID)
+}
+
+func (r *fakeRepo) CountRiskSkips(context.Context, uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeRepo) SetRiskWeightedScore(_ context.Context, riskID uuid.UUID, score float64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.risks {
+		if r.risks[i].ID == riskID {
+			r.risks[i].Status = domain.StatusScored
+			r.risks[i].WeightedScore = &score
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) GetRisksByEpicID(_ context.Context, _ uuid.UUID) ([]domain.Risk, error) {
+	return append([]domain.Risk(nil), r.risks...), nil
+}
+
+func (r *fakeRepo) GetRiskByID(_ context.Context, riskID uuid.UUID) (*domain.Risk, error) {
+	for _, risk := range r.risks {
+		if risk.ID == riskID {
+			return &risk, nil
+		}
+	}
+	return nil, errors.New("risk not found")
+}
+
+func (r *fakeRepo) GetDistinctRoleIDsForEpicScores(_ context.Context, _ uuid.UUID) ([]uuid.UUID, error) {
+	return []uuid.UUID{r.roleID}, nil
+}
+
+func (r *fakeRepo) GetEpicScoresByEpicIDAndRoleID(_ context.Context, _, _ uuid.UUID) ([]domain.EpicScore, error) {
+	return r.scores, nil
+}
+
+func (r *fakeRepo) GetUsersByIDs(_ context.Context, ids []uuid.UUID) (map[uuid.UUID]domain.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.userQueries++
+	users := make(map[uuid.UUID]domain.User, len(ids))
+	for _, id := range ids {
+		if u, ok := r.users[id]; ok {
+			users[id] = u
+		}
+	}
+	return users, nil
+}
+
+func (r *fakeRepo) GetRiskScoresByRiskID(_ context.Context, _ uuid.UUID) ([]domain.RiskScore, error) {
+	return r.riskScores, nil
+}
+
+func (r *fakeRepo) UpsertEpicRoleScore(_ context.Context, _, _ uuid.UUID, _ float64) error {
+	return nil
+}
+
+func (r *fakeRepo) SetEpicFinalScore(_ context.Context, _ uuid.UUID, score float64) (*time.Time, time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.epic.Status = domain.StatusScored
+	r.epic.FinalScore = &score
+	r.finalScores = append(r.finalScores, score)
+	return nil, time.Now(), nil
+}
+
+func (r *fakeRepo) CountRiskScores(_ context.Context, riskID uuid.UUID) (int, error) {
+	n := 0
+	for _, rs := range r.riskScores {
+		if rs.RiskID == riskID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeRepo) UpdateRiskStatus(_ context.Context, riskID uuid.UUID, status domain.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.risks {
+		if r.risks[i].ID == riskID {
+			r.risks[i].Status = status
+		}
+	}
+	return nil
+}
+
+func (r *fakeRepo) GetExpiredScoringEpics(_ context.Context, now time.Time) ([]domain.Epic, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d := r.epic.ScoringDeadline
+	if r.epic.Status != domain.StatusScoring || d == nil || d.After(now) {
+		return nil, nil
+	}
+	return []domain.Epic{r.epic}, nil
+}
+
+func (r *fakeRepo) SetEpicScoringDeadline(_ context.Context, _ uuid.UUID, deadline *time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.epic.ScoringDeadline = deadline
+	return nil
+}
+
+// fakeNotifier counts the announcements it was asked to make.
+type fakeNotifier struct {
+	mu       sync.Mutex
+	closed   int
+	scored   int
+	watchers int
+}
+
+func (n *fakeNotifier) AnnounceEpicClosed(context.Context, uuid.UUID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.closed++
+}
+
+func (n *fakeNotifier) AnnounceEpicScored(context.Context, uuid.UUID, float64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.scored++
+}
+
+func (n *fakeNotifier) NotifyEpicWatchers(context.Context, uuid.UUID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.watchers++
+}
+
+func newTestService(repo Repository) (*Service, *fakeNotifier) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := New(log, &config.Config{Scoring: config.ScoringConfig{
+		MinScoringQuorum: 100,
+		RoundingMode:     config.RoundingRound,
+		MaxEffortScore:   500,
+		RolePrecision:    2,
+	}}, repo)
+	n := &fakeNotifier{}
+	s.SetNotifier(n)
+	return s, n
+}
+
+func TestCloseEpicScoringRequiresScoringStatus(t *testing.T) {
+	for _, status := range []domain.Status{domain.StatusNew, domain.StatusScored} {
+		t.Run(string(status), func(t *testing.T) {
+			repo := newFakeRepo(status, 10)
+			s, n := newTestService(repo)
+
+			err := s.CloseEpicScoring(context.Background(), repo.epic.ID)
+			if !errors.Is(err, ErrNotScoring) {
+				t.Fatalf("CloseEpicScoring() error = %v, want ErrNotScoring", err)
+			}
+			if len(repo.finalScores) != 0 {
+				t.Errorf("final score stored %d times, want 0", len(repo.finalScores))
+			}
+			if n.scored != 0 || n.watchers != 0 {
+				t.Errorf("notifier called: scored %d, watchers %d", n.scored, n.watchers)
+			}
+		})
+	}
+}
+
+func TestCloseEpicScoringTwice(t *testing.T) {
+	repo := newFakeRepo(domain.StatusScoring, 8, 12)
+	s, n := newTestService(repo)
+	ctx := context.Background()
+
+	if err := s.CloseEpicScoring(ctx, repo.epic.ID); err != nil {
+		t.Fatalf("first CloseEpicScoring() error = %v", err)
+	}
+	if err := s.CloseEpicScoring(ctx, repo.epic.ID); !errors.Is(err, ErrNotScoring) {
+		t.Fatalf("second CloseEpicScoring() error = %v, want ErrNotScoring", err)
+	}
+	if len(repo.finalScores) != 1 || repo.finalScores[0] != 10 {
+		t.Errorf("final scores = %v, want [10]", repo.finalScores)
+	}
+	if n.watchers != 1 {
+		t.Errorf("watchers notified %d times, want 1", n.watchers)
+	}
+}
+
+func TestTryCompleteEpicScoringIgnoresOtherStatuses(t *testing.T) {
+	repo := newFakeRepo(domain.StatusNew, 10)
+	s, _ := newTestService(repo)
+
+	if err := s.TryCompleteEpicScoring(context.Background(), repo.epic.ID); err != nil {
+		t.Fatalf("TryCompleteEpicScoring() error = %v", err)
+	}
+	if len(repo.finalScores) != 0 {
+		t.Errorf("final score stored for a NEW epic: %v", repo.finalScores)
+	}
+}
+
+func TestTryCompleteEpicScoringConcurrent(t *testing.T) {
+	repo := newFakeRepo(domain.StatusScoring, 5, 8, 13)
+	s, n := newTestService(repo)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := s.TryCompleteEpicScoring(context.Background(), repo.epic.ID); err != nil {
+				t.Errorf("TryCompleteEpicScoring() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(repo.finalScores) != 1 {
+		t.Errorf("final score stored %d times, want 1", len(repo.finalScores))
+	}
+	if n.scored != 1 || n.watchers != 1 {
+		t.Errorf("notified scored %d, watchers %d times, want 1 each", n.scored, n.watchers)
+	}
+}
+
+func TestQuorumFinalizesWithoutAbsentMember(t *testing.T) {
+	// Five team members and an 80% quorum: the fourth submission of the
+	// effort and of the risk completes the epic.
+	repo := newFakeRepo(domain.StatusScoring)
+	repo.members = 5
+	risk := domain.Risk{ID: uuid.New(), EpicID: repo.epic.ID, Status: domain.StatusNew}
+	repo.risks = []domain.Risk{risk}
+	s, n := newTestService(repo)
+	s.cfg.MinScoringQuorum = 80
+	ctx := context.Background()
+
+	submit := func(effort int) {
+		t.Helper()
+		u := domain.User{ID: uuid.New(), Weight: 100}
+		repo.users[u.ID] = u
+		repo.scores = append(repo.scores, domain.EpicScore{EpicID: repo.epic.ID, UserID: u.ID, RoleID: repo.roleID, Score: effort})
+		repo.riskScores = append(repo.riskScores, domain.RiskScore{RiskID: risk.ID, UserID: u.ID, Probability: 3, Impact: 3})
+		if err := s.TryCompleteRiskScoring(ctx, risk.ID); err != nil {
+			t.Fatalf("TryCompleteRiskScoring() error = %v", err)
+		}
+	}
+
+	for _, effort := range []int{10, 20, 30} {
+		submit(effort)
+	}
+	if repo.epic.Status != domain.StatusScoring || repo.risks[0].Status != domain.StatusNew {
+		t.Fatalf("epic %s, risk %s after 3 of 5 submissions, want both still open",
+			repo.epic.Status, repo.risks[0].Status)
+	}
+
+	submit(40)
+	if repo.risks[0].WeightedScore == nil || *repo.risks[0].WeightedScore != 9 {
+		t.Errorf("risk weighted score = %v, want 9", repo.risks[0].WeightedScore)
+	}
+	// The average of the four submitted efforts, 25, times the risk
+	// coefficient 1.2; the absent member does not count as a zero.
+	if len(repo.finalScores) != 1 || repo.finalScores[0] != 30 {
+		t.Errorf("final scores = %v, want [30]", repo.finalScores)
+	}
+	if n.scored != 1 {
+		t.Errorf("announced %d times, want 1", n.scored)
+	}
+}
+
+func TestTryCompleteRiskScoringAfterEpicIsScored(t *testing.T) {
+	repo := newFakeRepo(domain.StatusScored, 10)
+	risk := domain.Risk{ID: uuid.New(), EpicID: repo.epic.ID, Status: domain.StatusScored}
+	repo.risks = []domain.Risk{risk}
+	// A late vote that would change the risk's score.
+	repo.riskScores = []domain.RiskScore{{RiskID: risk.ID, UserID: repo.scores[0].UserID, Probability: 4, Impact: 4}}
+	s, n := newTestService(repo)
+
+	if err := s.TryCompleteRiskScoring(context.Background(), risk.ID); err != nil {
+		t.Fatalf("TryCompleteRiskScoring() error = %v", err)
+	}
+	if repo.risks[0].WeightedScore != nil {
+		t.Errorf("risk of a scored epic rescored to %v", *repo.risks[0].WeightedScore)
+	}
+	if n.scored != 0 || n.watchers != 0 {
+		t.Errorf("notifier called: scored %d, watchers %d", n.scored, n.watchers)
+	}
+}
+
+// lockCheckingNotifier records whether the epic's lock was free while
+// notifications were sent.
+type lockCheckingNotifier struct {
+	fakeNotifier
+	s      *Service
+	epicID uuid.UUID
+	locked bool
+}
+
+func (n *lockCheckingNotifier) NotifyEpicWatchers(ctx context.Context, epicID uuid.UUID) {
+	done := make(chan struct{})
+	go func() {
+		n.s.lockEpic(n.epicID)()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		n.locked = true
+	}
+	n.fakeNotifier.NotifyEpicWatchers(ctx, epicID)
+}
+
+func TestFinalizeEpicNotifiesAfterUnlocking(t *testing.T) {
+	repo := newFakeRepo(domain.StatusScoring, 10)
+	s, _ := newTestService(repo)
+	n := &lockCheckingNotifier{s: s, epicID: repo.epic.ID}
+	s.SetNotifier(n)
+
+	if err := s.TryCompleteEpicScoring(context.Background(), repo.epic.ID); err != nil {
+		t.Fatalf("TryCompleteEpicScoring() error = %v", err)
+	}
+	if n.watchers != 1 {
+		t.Fatalf("watchers notified %d times, want 1", n.watchers)
+	}
+	if n.locked {
+		t.Error("watchers were notified while the epic was locked")
+	}
+}
+
+// runWatcher starts the deadline watcher of s and returns a channel closed
+// when it returns.
+func runWatcher(ctx context.Context, s *Service) <-chan struct{} {
+	s.cfg.DeadlineCheckInterval = 5 * time.Millisecond
+	done := make(chan struct{})
+	go func() {
+		s.RunDeadlineWatcher(ctx)
+		close(done)
+	}()
+	return done
+}
+
+func TestDeadlineWatcherClosesExpiredEpic(t *testing.T) {
+	repo := newFakeRepo(domain.StatusScoring, 8, 12)
+	deadline := time.Now().Add(-time.Minute)
+	repo.epic.ScoringDeadline = &deadline
+	// Nobody scored the risk, so only a forced close finalizes the epic.
+	repo.risks = []domain.Risk{{ID: uuid.New(), EpicID: repo.epic.ID, Status: domain.StatusNew}}
+	s, n := newTestService(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := runWatcher(ctx, s)
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	for start := time.Now(); ; time.Sleep(5 * time.Millisecond) {
+		repo.mu.Lock()
+		status := repo.epic.Status
+		repo.mu.Unlock()
+		if status == domain.StatusScored {
+			break
+		}
+		if time.Since(start) > 2*time.Second {
+			t.Fatalf("epic status = %s after the deadline, want %s", status, domain.StatusScored)
+		}
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if len(repo.finalScores) != 1 || repo.finalScores[0] != 10 {
+		t.Errorf("final scores = %v, want [10]", repo.finalScores)
+	}
+	if repo.risks[0].Status != domain.StatusSkipped {
+		t.Errorf("risk status = %s, want %s", repo.risks[0].Status, domain.StatusSkipped)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.closed != 1 || n.scored != 0 {
+		t.Errorf("announced closed %d and scored %d times, want 1 and 0", n.closed, n.scored)
+	}
+}
+
+func TestDeadlineWatcherStopsOnCancel(t *testing.T) {
+	repo := newFakeRepo(domain.StatusScoring, 8, 12)
+	deadline := time.Now().Add(time.Hour)
+	repo.epic.ScoringDeadline = &deadline
+	s, _ := newTestService(repo)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := runWatcher(ctx, s)
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("watcher still running after cancel")
+	}
+	if len(repo.finalScores) != 0 {
+		t.Errorf("final scores = %v, want none before the deadline", repo.finalScores)
+	}
+}
+
+func TestCombineRiskCoefficients(t *testing.T) {
+	tests := []struct {
+		name   string
+		mode   string
+		coeffs []float64
+		want   float64
+	}{
+		{"product of none", config.RiskAggregationProduct, nil, 1},
+		{"max of none", config.RiskAggregationMax, nil, 1},
+		{"sumcapped of none", config.RiskAggregationSumCapped, nil, 1},
+		{"product", config.RiskAggregationProduct, []float64{1.3, 1.1}, 1.43},
+		{"max", config.RiskAggregationMax, []float64{1.1, 1.3, 1.2}, 1.3},
+		{"max ignores a coefficient below 1", config.RiskAggregationMax, []float64{0.9}, 1},
+		{"sumcapped adds the increases", config.RiskAggregationSumCapped, []float64{1.3, 1.1}, 1.4},
+		{"sumcapped is capped", config.RiskAggregationSumCapped, []float64{1.45, 1.45, 1.45}, MaxSummedRiskCoefficient},
+		{"mode is case-insensitive", "SumCapped", []float64{1.3, 1.1}, 1.4},
+		{"unknown mode multiplies", "unknown", []float64{1.3, 1.1}, 1.43},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := combineRiskCoefficients(tt.mode, tt.coeffs); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("combineRiskCoefficients(%q, %v) = %v, want %v", tt.mode, tt.coeffs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRiskMultiplier(t *testing.T) {
+	s9, s5, s13 := 9.4, 5.0, 13.0
+	risks := []domain.Risk{
+		{Status: domain.StatusScored, WeightedScore: &s9, Importance: domain.ImportanceHigh},
+		{Status: domain.StatusScored, WeightedScore: &s5},
+		{Status: domain.StatusScored, WeightedScore: &s13, Importance: domain.ImportanceLow},
+		{Status: domain.StatusScoring, WeightedScore: &s9, Importance: domain.ImportanceHigh},
+		{Status: domain.StatusScored},
+	}
+	tests := []struct {
+		mode string
+		want float64
+	}{
+		// Effective coefficients 1.30, 1.10 and 1.15; the unscored risks
+		// are left out.
+		{config.RiskAggregationProduct, 1.3 * 1.1 * 1.15},
+		{config.RiskAggregationMax, 1.3},
+		{config.RiskAggregationSumCapped, MaxSummedRiskCoefficient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			if got := RiskMultiplier(tt.mode, risks); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("RiskMultiplier(%q) = %v, want %v", tt.mode, got, tt.want)
+			}
+		})
+	}
+	if got := RiskMultiplier(config.RiskAggregationProduct, nil); got != 1 {
+		t.Errorf("RiskMultiplier() without risks = %v, want 1", got)
+	}
+}
+
+func TestScoringDuration(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		started  *time.Time
+		scoredAt time.Time
+		want     time.Duration
+		wantOK   bool
+	}{
+		{"known start", &start, start.Add(26 * time.Hour), 26 * time.Hour, true},
+		{"scored at the start", &start, start, 0, true},
+		{"unknown start", nil, start, 0, false},
+		{"start after the end", &start, start.Add(-time.Minute), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ScoringDuration(tt.started, tt.scoredAt)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ScoringDuration() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRoleAveragesAreRounded(t *testing.T) {
+	repo := newFakeRepo(domain.StatusScoring, 5, 8, 13)
+	s, _ := newTestService(repo)
+
+	avgs, base, err := s.roleAverages(context.Background(), repo.epic.ID)
+	if err != nil {
+		t.Fatalf("roleAverages() error = %v", err)
+	}
+	if got := avgs[repo.roleID]; got != 8.67 {
+		t.Errorf("role average = %v, want 8.67", got)
+	}
+	if base != 8.67 {
+		t.Errorf("base = %v, want 8.67", base)
+	}
+}
+
+func TestWeightedAverage(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		values  []float64
+		weights []float64
+		want    float64
+		wantErr error
+	}{
+		{"no values", config.ZeroWeightMean, nil, nil, 0, nil},
+		{"weighted", config.ZeroWeightMean, []float64{10, 20}, []float64{100, 50}, 40.0 / 3, nil},
+		{"a zero weight is left out", config.ZeroWeightMean, []float64{10, 20}, []float64{100, 0}, 10, nil},
+		{"all zero weights use the mean", config.ZeroWeightMean, []float64{10, 20}, []float64{0, 0}, 15, nil},
+		{"all zero weights rejected", config.ZeroWeightReject, []float64{10, 20}, []float64{0, 0}, 0, ErrZeroWeight},
+		{"reject mode with weights", config.ZeroWeightReject, []float64{10, 20}, []float64{1, 1}, 15, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestService(nil)
+			s.cfg.ZeroWeightMode = tt.mode
+			got, err := s.weightedAverage(tt.values, tt.weights)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("weightedAverage() error = %v, want %v", err, tt.wantErr)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("weightedAverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// newWeightedRepo returns a repo whose scorers have the given weights, each
+// with an effort score and a risk assessment.
+func newWeightedRepo(weights ...int) *fakeRepo {
+	r := newFakeRepo(domain.StatusScoring)
+	for i, w := range weights {
+		u := domain.User{ID: uuid.New(), Weight: w}
+		r.users[u.ID] = u
+		r.scores = append(r.scores, domain.EpicScore{EpicID: r.epic.ID, UserID: u.ID, RoleID: r.roleID, Score: 3 + 5*i})
+		r.riskScores = append(r.riskScores, domain.RiskScore{UserID: u.ID, Probability: 1 + i%4, Impact: 4 - i%3})
+	}
+	return r
+}
+
+func TestBatchedUserLookupMatchesPerUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []int
+	}{
+		{"single scorer", []int{100}},
+		{"equal weights", []int{100, 100, 100}},
+		{"mixed weights", []int{100, 50, 80, 10, 65}},
+		{"some zero weights", []int{0, 40, 0, 90}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newWeightedRepo(tt.weights...)
+			s, _ := newTestService(repo)
+			ctx := context.Background()
+
+			// The expected averages look every scorer up on their own.
+			var effortSum, riskSum, weightSum float64
+			for i, sc := range repo.scores {
+				w := float64(repo.users[sc.UserID].Weight)
+				rs := repo.riskScores[i]
+				effortSum += float64(sc.Score) * w
+				riskSum += float64(rs.Probability*rs.Impact) * w
+				weightSum += w
+			}
+
+			effort, err := s.CalculateEpicRoleAvg(ctx, repo.epic.ID, repo.roleID)
+			if err != nil {
+				t.Fatalf("CalculateEpicRoleAvg() error = %v", err)
+			}
+			if want := effortSum / weightSum; math.Abs(effort-want) > 1e-9 {
+				t.Errorf("CalculateEpicRoleAvg() = %v, want %v", effort, want)
+			}
+			risk, err := s.CalculateRiskWeightedScore(ctx, uuid.New())
+			if err != nil {
+				t.Fatalf("CalculateRiskWeightedScore() error = %v", err)
+			}
+			if want := riskSum / weightSum; math.Abs(risk-want) > 1e-9 {
+				t.Errorf("CalculateRiskWeightedScore() = %v, want %v", risk, want)
+			}
+			if repo.userQueries != 2 {
+				t.Errorf("users loaded in %d queries, want one per calculation", repo.userQueries)
+			}
+		})
+	}
+}
+
+func TestBatchedUserLookupMissingUser(t *testing.T) {
+	repo := newWeightedRepo(100, 50, 80)
+	delete(repo.users, repo.scores[1].UserID)
+	s, _ := newTestService(repo)
+	ctx := context.Background()
+
+	// A deleted scorer must fail the calculation rather than count with
+	// weight 0.
+	if _, err := s.CalculateEpicRoleAvg(ctx, repo.epic.ID, repo.roleID); err == nil {
+		t.Error("CalculateEpicRoleAvg() error = nil, want an error for the missing user")
+	}
+	if _, err := s.CalculateRiskWeightedScore(ctx, uuid.New()); err == nil {
+		t.Error("CalculateRiskWeightedScore() error = nil, want an error for the missing user")
+	}
+}
@@ -0,0 +1,120 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /activescoring ───────────────────────────────────────────────────────
+
+// handleActiveScoring lists every epic being scored across all teams with
+// its effort and risk completion, oldest first so stalled epics stand out.
+func (epicBot *Bot) handleActiveScoring(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleActiveScoring"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+
+	epics, err := epicBot.repo.GetEpicsByStatus(ctx, domain.StatusScoring)
+	if err != nil {
+		log.Error("error getting scoring epics", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка получения эпиков.")
+		return retErr
+	}
+	if len(epics) == 0 {
+		_, err := epicBot.sendReply(ctx, msg, "✅ Сейчас нет эпиков на оценке.")
+		return err
+	}
+
+	ids := make([]uuid.UUID, len(epics))
+	for i, e := range epics {
+		ids[i] = e.ID
+	}
+	progress, err := epicBot.repo.GetScoringProgress(ctx, ids)
+	if err != nil {
+		log.Error("error getting scoring progress", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка получения прогресса оценки.")
+		return retErr
+	}
+	teams, err := epicBot.repo.GetAllTeams(ctx)
+	if err != nil {
+		log.Error("error getting teams", sl.Err(err))
+	}
+	teamNames := make(map[uuid.UUID]string, len(teams))
+	for _, t := range teams {
+		teamNames[t.ID] = t.Name
+	}
+
+	_, err = epicBot.sendReply(ctx, msg, renderActiveScoring(epics, progress, teamNames, time.Now()))
+	return err
+}
+
+// renderActiveScoring formats the scoring dashboard. Epics are sorted by
+// when scoring started, oldest first.
+func renderActiveScoring(
+	epics []domain.Epic,
+	progress map[uuid.UUID]domain.ScoringProgress,
+	teamNames map[uuid.UUID]string,
+	now time.Time,
+) string {
+	sorted := make([]domain.Epic, len(epics))
+	copy(sorted, epics)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return progress[sorted[i].ID].StartedAt.Before(progress[sorted[j].ID].StartedAt)
+	})
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📊 Эпики на оценке: %d\n", len(sorted))
+	for _, e := range sorted {
+		p := progress[e.ID]
+		team := teamNames[e.TeamID]
+		if team == "" {
+			team = "—"
+		}
+		fmt.Fprintf(&sb, "\n#%s «%s» — %s\n", e.Number, e.Name, team)
+		fmt.Fprintf(&sb, "  📋 Трудоёмкость: %s\n", progressBar(p.EffortScores, p.Members))
+		if p.Risks > 0 {
+			fmt.Fprintf(&sb, "  ⚠️ Риски: %s\n", progressBar(p.RisksScored, p.Risks))
+		} else {
+			sb.WriteString("  ⚠️ Риски: нет\n")
+		}
+		if !p.StartedAt.IsZero() {
+			fmt.Fprintf(&sb, "  ⏳ На оценке: %s\n", formatAge(now.Sub(p.StartedAt)))
+		}
+	}
+	return sb.String()
+}
+
+// formatAge renders a duration in days, hours and minutes, keeping the two
+// most significant units.
+func formatAge(d time.Duration) string {
+	if d < time.Minute {
+		return "меньше минуты"
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d д %d ч", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%d ч %d мин", hours, minutes)
+	default:
+		return fmt.Sprintf("%d мин", minutes)
+	}
+}
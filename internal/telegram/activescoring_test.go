@@ -0,0 +1,60 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "меньше минуты"},
+		{59 * time.Second, "меньше минуты"},
+		{time.Minute, "1 мин"},
+		{59*time.Minute + 59*time.Second, "59 мин"},
+		{time.Hour, "1 ч 0 мин"},
+		{3*time.Hour + 25*time.Minute, "3 ч 25 мин"},
+		{24 * time.Hour, "1 д 0 ч"},
+		{50*time.Hour + 30*time.Minute, "2 д 2 ч"},
+		{-time.Hour, "меньше минуты"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.d.String(), func(t *testing.T) {
+			if got := formatAge(tt.d); got != tt.want {
+				t.Errorf("formatAge(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderActiveScoring(t *testing.T) {
+	now := time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC)
+	team := uuid.New()
+	newer := domain.Epic{ID: uuid.New(), Number: "2", Name: "Search", TeamID: team}
+	older := domain.Epic{ID: uuid.New(), Number: "1", Name: "Login", TeamID: uuid.New()}
+	progress := map[uuid.UUID]domain.ScoringProgress{
+		newer.ID: {StartedAt: now.Add(-90 * time.Minute), Members: 4, EffortScores: 2, Risks: 2, RisksScored: 1},
+		older.ID: {StartedAt: now.Add(-26 * time.Hour), Members: 3, EffortScores: 3},
+	}
+	teamNames := map[uuid.UUID]string{team: "Core"}
+
+	want := "📊 Эпики на оценке: 2\n" +
+		"\n#1 «Login» — —\n" +
+		"  📋 Трудоёмкость: ▰▰▰ 3/3\n" +
+		"  ⚠️ Риски: нет\n" +
+		"  ⏳ На оценке: 1 д 2 ч\n" +
+		"\n#2 «Search» — Core\n" +
+		"  📋 Трудоёмкость: ▰▰▱▱ 2/4\n" +
+		"  ⚠️ Риски: ▰▱ 1/2\n" +
+		"  ⏳ На оценке: 1 ч 30 мин\n"
+	got := renderActiveScoring([]domain.Epic{newer, older}, progress, teamNames, now)
+	if got != want {
+		t.Errorf("renderActiveScoring() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -2,11 +2,17 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"strings"
+	"time"
 
+	"EpicScoreBot/internal/audit"
 	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/scoring"
 	"EpicScoreBot/internal/utils/logger/sl"
 
 	"github.com/go-telegram/bot/models"
@@ -16,13 +22,25 @@ import (
 // ─── Callback data format ──────────────────────────────────────────────────
 //
 // adm_user_<action>_<userID>
-// adm_role_<action>_<roleID>        (userID stored in session as pendingUserID)
+// adm_userpage_<action>_<offset>    (user picker page switch)
+// adm_role_<action>_<roleID>        (userID stored in session as pendingUserID,
+//                                    except for deleterole)
 // adm_team_<action>_<...>
 //   assignteam flow:   adm_team_assignteam_<teamID>  (userID in session)
 //   addepic    flow:   adm_team_addepic_<teamID>
 //   removefromteam:    adm_team_removefromteam_<teamID> (userID in session)
+//   deleteteam:        adm_team_deleteteam_<teamID>
+//   renameteam:        adm_team_renameteam_<teamID>
+//   archiveteam:       adm_team_archiveteam_<teamID>
+//   unarchiveteam:     adm_team_unarchiveteam_<teamID>
+//   duplicateepic:     adm_team_duplicateepic_<teamID> (source epic and number in session)
+//   setteamweights:    adm_team_setteamweights_<teamID>
+//   bulkstartscore:    adm_team_bulkstartscore_<teamID>
+// adm_tw_<action>[_<userID>]         (weights being edited kept in session)
 // adm_epic_<action>_<epicID>
+// adm_epicpage_<action>_<status>_<offset> (status is ALL when unfiltered)
 // adm_risk_<action>_<epicID>_<riskID>
+// adm_importance_<level>            (epicID and description stored in session)
 // adm_confirm_<action>_<id>
 // adm_deny_*
 
@@ -92,6 +110,115 @@ func (epicBot *Bot) deleteAndSend(ctx context.Context, msg *models.Message, mess
 	epicBot.sendReply(ctx, msg, text)
 }
 
+// handleAdmUserPage switches the user picker to another page.
+// data = "adm_userpage_<action>_<offset>"
+func (epicBot *Bot) handleAdmUserPage(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	data string,
+) {
+	op := "bot.handleAdmUserPage"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("data", data),
+	)
+
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return
+	}
+	rest := strings.TrimPrefix(data, "adm_userpage_")
+	sep := strings.LastIndex(rest, "_")
+	if sep <= 0 {
+		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+		return
+	}
+	action := rest[:sep]
+	offset, err := strconv.Atoi(rest[sep+1:])
+	if err != nil || offset < 0 {
+		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+		return
+	}
+
+	msgID := msg.ID
+	if sess, ok := epicBot.sessions.get(sessionKeyFromCallback(msg, callback)); ok && sess.MessageID > 0 {
+		msgID = sess.MessageID
+	}
+
+	kb, err := epicBot.userPickerKeyboard(ctx, action, offset)
+	if err != nil || kb == nil {
+		if err != nil {
+			log.Error("error getting users page", sl.Err(err))
+		}
+		epicBot.editOrSend(ctx, msg, msgID, "❌ Пользователи не найдены.")
+		return
+	}
+	epicBot.editOrSendWithKeyboard(ctx, msg, msgID, "👤 Выберите пользователя:", kb)
+}
+
+// epicPageAnyStatus marks an epic picker page without a status filter.
+const epicPageAnyStatus = "ALL"
+
+// handleAdmEpicPage switches the epic picker to another page.
+// data = "adm_epicpage_<action>_<status>_<offset>"
+func (epicBot *Bot) handleAdmEpicPage(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	data string,
+) {
+	op := "bot.handleAdmEpicPage"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("data", data),
+	)
+
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return
+	}
+	rest := strings.TrimPrefix(data, "adm_epicpage_")
+	sep := strings.LastIndex(rest, "_")
+	if sep <= 0 {
+		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+		return
+	}
+	offset, err := strconv.Atoi(rest[sep+1:])
+	if err != nil || offset < 0 {
+		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+		return
+	}
+	rest = rest[:sep]
+	sep = strings.LastIndex(rest, "_")
+	if sep <= 0 {
+		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+		return
+	}
+	action := rest[:sep]
+	status := domain.Status(rest[sep+1:])
+	if status == epicPageAnyStatus {
+		status = ""
+	}
+
+	msgID := msg.ID
+	if sess, ok := epicBot.sessions.get(sessionKeyFromCallback(msg, callback)); ok && sess.MessageID > 0 {
+		msgID = sess.MessageID
+	}
+
+	text, kb, err := epicBot.epicPickerPage(ctx, action, status, offset)
+	if err != nil || kb == nil {
+		if err != nil {
+			log.Error("error getting epics page", sl.Err(err))
+		}
+		epicBot.editOrSend(ctx, msg, msgID, "❌ Эпики не найдены.")
+		return
+	}
+	epicBot.editOrSendWithKeyboard(ctx, msg, msgID, text, kb)
+}
+
 // handleAdmUserSelected handles when an admin picks a user from the user picker.
 // data = "adm_user_<action>_<userID>"
 func (epicBot *Bot) handleAdmUserSelected(
@@ -107,8 +234,8 @@ func (epicBot *Bot) handleAdmUserSelected(
 		slog.String("data", data),
 	)
 
-	if !epicBot.isAdminCallback(callback) {
-		epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return
 	}
 	rest := strings.TrimPrefix(data, "adm_user_")
@@ -129,7 +256,7 @@ func (epicBot *Bot) handleAdmUserSelected(
 
 	user, err := epicBot.repo.GetUserByID(ctx, userID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, "❌ Пользователь не найден.")
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found"))
 		return
 	}
 
@@ -147,6 +274,8 @@ func (epicBot *Bot) handleAdmUserSelected(
 		epicBot.showRolePicker(ctx, msg, callback, "assignrole", userID.String(), msgID)
 	case "unassignrole":
 		epicBot.showUserRolePicker(ctx, msg, callback, "unassignrole", userID, msgID)
+	case "changerole":
+		epicBot.showRolePicker(ctx, msg, callback, "changerole", userID.String(), msgID)
 	case "assignteam":
 		epicBot.showTeamPickerForUser(ctx, msg, callback, "assignteam", user, msgID)
 	case "removefromteam":
@@ -171,7 +300,7 @@ func (epicBot *Bot) handleAdmUserSelected(
 			Data:      map[string]string{"pendingUserID": userID.String()},
 		})
 		epicBot.editOrSend(ctx, msg, msgID,
-			fmt.Sprintf("✏️ Переименование пользователя %s %s (@%s).\n📝 Введите новое имя:",
+			fmt.Sprintf("✏️ Переименование пользователя %s %s (@%s).\n📝 Введите новое имя:"+cancelHint,
 				user.FirstName, user.LastName, user.TelegramID))
 	case "changerate":
 		epicBot.sessions.set(sk, &Session{
@@ -182,7 +311,7 @@ func (epicBot *Bot) handleAdmUserSelected(
 			Data:      map[string]string{"pendingUserID": userID.String()},
 		})
 		epicBot.editOrSend(ctx, msg, msgID,
-			fmt.Sprintf("⚖️ Изменение веса пользователя %s %s (@%s).\nТекущий вес: %d\n📝 Введите новый вес (0–100):",
+			fmt.Sprintf("⚖️ Изменение веса пользователя %s %s (@%s).\nТекущий вес: %d\n📝 Введите новый вес (0–100):"+cancelHint,
 				user.FirstName, user.LastName, user.TelegramID, user.Weight))
 	default:
 		epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Неизвестное действие: %s", action))
@@ -212,21 +341,11 @@ func (epicBot *Bot) showTeamPickerForUser(
 		epicBot.editOrSend(ctx, msg, msgID, "❌ Команды не найдены.")
 		return
 	}
-	sk := sessionKeyFromCallback(msg, callback)
-	sess, _ := epicBot.sessions.get(sk)
-	if sess == nil {
-		sess = &Session{
-			Data:     make(map[string]string),
-			Username: callback.From.Username,
-		}
-	}
-	sess.Data["pendingUserID"] = user.ID.String()
-	sess.MessageID = msgID
-	epicBot.sessions.set(sk, sess)
+	epicBot.rememberPendingUser(sessionKeyFromCallback(msg, callback), callback.From.Username, user.ID.String(), msgID)
 
 	var rows [][]models.InlineKeyboardButton
 	for _, t := range teams {
-		rows = append(rows, inlineRow(inlineBtn(
+		rows = append(rows, inlineRow(epicBot.pickerBtn(
 			"👥 "+t.Name,
 			fmt.Sprintf("adm_team_%s_%s", action, t.ID.String()),
 		)))
@@ -245,8 +364,8 @@ func (epicBot *Bot) handleAdmRoleSelected(
 	callback *models.CallbackQuery,
 	data string,
 ) {
-	if !epicBot.isAdminCallback(callback) {
-		epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return
 	}
 	rest := strings.TrimPrefix(data, "adm_role_")
@@ -257,6 +376,17 @@ func (epicBot *Bot) handleAdmRoleSelected(
 	roleIDStr := rest[len(rest)-36:]
 	action := rest[:len(rest)-37]
 
+	// Role deletion is not tied to a user picked earlier.
+	if action == "deleterole" {
+		roleID, err := uuid.Parse(roleIDStr)
+		if err != nil {
+			epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID роли.")
+			return
+		}
+		epicBot.confirmDeleteRole(ctx, msg, callback, roleID)
+		return
+	}
+
 	sk := sessionKeyFromCallback(msg, callback)
 	sess, ok := epicBot.sessions.get(sk)
 	if !ok || sess == nil {
@@ -282,34 +412,70 @@ func (epicBot *Bot) handleAdmRoleSelected(
 
 	user, err := epicBot.repo.GetUserByID(ctx, userID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, "❌ Пользователь не найден.")
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found"))
 		return
 	}
 	role, err := epicBot.repo.GetRoleByID(ctx, roleID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, "❌ Роль не найдена.")
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "role.not_found"))
 		return
 	}
 
 	msgID := sess.MessageID
-	delete(sess.Data, "pendingUserID")
 	epicBot.sessions.clear(sk)
 
 	switch action {
 	case "assignrole":
 		if err := epicBot.repo.AssignUserRole(ctx, userID, roleID); err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Ошибка назначения роли: %v", err))
+			epicBot.log.Error("failed to assign role", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка назначения роли.")
 			return
 		}
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionRoleAssigned,
+			fmt.Sprintf("@%s → %s", user.TelegramID, role.Name))
 		epicBot.deleteAndSend(ctx, msg, msgID,
 			fmt.Sprintf("✅ Роль «%s» назначена пользователю %s %s.", role.Name, user.FirstName, user.LastName))
 	case "unassignrole":
-		if err := epicBot.repo.RemoveUserRole(ctx, userID, roleID); err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Ошибка снятия роли: %v", err))
+		removed, err := epicBot.repo.RemoveUserRole(ctx, userID, roleID)
+		if err != nil {
+			epicBot.log.Error("failed to remove role", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка снятия роли.")
+			return
+		}
+		if removed == 0 {
+			epicBot.deleteAndSend(ctx, msg, msgID,
+				fmt.Sprintf("ℹ️ У пользователя %s %s нет роли «%s» — ничего не изменено.",
+					user.FirstName, user.LastName, role.Name))
 			return
 		}
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionRoleUnassigned,
+			fmt.Sprintf("@%s ✕ %s", user.TelegramID, role.Name))
+		epicBot.rememberUndo(msg.Chat.ID, callback.From.Username,
+			fmt.Sprintf("снятие роли «%s» у @%s", role.Name, user.TelegramID),
+			func(ctx context.Context) error { return epicBot.repo.AssignUserRole(ctx, userID, roleID) })
 		epicBot.deleteAndSend(ctx, msg, msgID,
 			fmt.Sprintf("✅ Роль «%s» снята у пользователя %s %s.", role.Name, user.FirstName, user.LastName))
+	case "changerole":
+		oldRole := "—"
+		current, currentErr := epicBot.repo.GetRoleByUserID(ctx, userID)
+		if currentErr == nil {
+			oldRole = current.Name
+		}
+		if err := epicBot.repo.ReplaceUserRole(ctx, userID, roleID); err != nil {
+			epicBot.log.Error("failed to replace role", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка смены роли.")
+			return
+		}
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionRoleChanged,
+			fmt.Sprintf("@%s: %s → %s", user.TelegramID, oldRole, role.Name))
+		if currentErr == nil {
+			epicBot.rememberUndo(msg.Chat.ID, callback.From.Username,
+				fmt.Sprintf("смена роли @%s: %s → %s", user.TelegramID, oldRole, role.Name),
+				func(ctx context.Context) error { return epicBot.repo.ReplaceUserRole(ctx, userID, current.ID) })
+		}
+		epicBot.deleteAndSend(ctx, msg, msgID,
+			fmt.Sprintf("✅ Роль пользователя %s %s изменена: %s → %s.",
+				user.FirstName, user.LastName, oldRole, role.Name))
 	default:
 		epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Неизвестное действие: %s", action))
 	}
@@ -322,8 +488,8 @@ func (epicBot *Bot) handleAdmTeamSelected(
 	callback *models.CallbackQuery,
 	data string,
 ) {
-	if !epicBot.isAdminCallback(callback) {
-		epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return
 	}
 	rest := strings.TrimPrefix(data, "adm_team_")
@@ -355,7 +521,7 @@ func (epicBot *Bot) handleAdmTeamSelected(
 			MessageID: msgID,
 			Data:      map[string]string{"teamID": teamID.String()},
 		})
-		epicBot.editOrSend(ctx, msg, msgID, "📝 Введите номер эпика (например, EP-1):")
+		epicBot.editOrSend(ctx, msg, msgID, "📝 Введите номер эпика (например, EP-1):"+cancelHint)
 
 	case "assignteam", "removefromteam":
 		sess, ok := epicBot.sessions.get(sk)
@@ -382,17 +548,16 @@ func (epicBot *Bot) handleAdmTeamSelected(
 
 		user, err := epicBot.repo.GetUserByID(ctx, userID)
 		if err != nil {
-			epicBot.sendReply(ctx, msg, "❌ Пользователь не найден.")
+			epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found"))
 			return
 		}
 		team, err := epicBot.repo.GetTeamByID(ctx, teamID)
 		if err != nil {
-			epicBot.sendReply(ctx, msg, "❌ Команда не найдена.")
+			epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
 			return
 		}
 
 		msgID := sess.MessageID
-		delete(sess.Data, "pendingUserID")
 		epicBot.sessions.clear(sk)
 
 		switch action {
@@ -412,20 +577,96 @@ func (epicBot *Bot) handleAdmTeamSelected(
 				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка добавления в команду.")
 				return
 			}
+			epicBot.recordAudit(ctx, callback.From.Username, audit.ActionTeamAssigned,
+				fmt.Sprintf("@%s → %s", user.TelegramID, team.Name))
 			epicBot.deleteAndSend(ctx, msg, msgID,
 				fmt.Sprintf("✅ Пользователь %s %s добавлен в команду «%s».",
 					user.FirstName, user.LastName, team.Name))
 		case "removefromteam":
-			if err := epicBot.repo.RemoveUserTeam(ctx, userID, teamID); err != nil {
+			removed, err := epicBot.repo.RemoveUserTeam(ctx, userID, teamID)
+			if err != nil {
+				epicBot.log.Error("failed to remove user from team", sl.Err(err))
+				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка удаления из команды.")
+				return
+			}
+			if removed == 0 {
 				epicBot.deleteAndSend(ctx, msg, msgID,
-					fmt.Sprintf("❌ Ошибка удаления из команды: %v", err))
+					fmt.Sprintf("ℹ️ Пользователь %s %s не состоит в команде «%s» — ничего не изменено.",
+						user.FirstName, user.LastName, team.Name))
 				return
 			}
+			epicBot.recordAudit(ctx, callback.From.Username, audit.ActionTeamUnassigned,
+				fmt.Sprintf("@%s ✕ %s", user.TelegramID, team.Name))
+			epicBot.rememberUndo(msg.Chat.ID, callback.From.Username,
+				fmt.Sprintf("удаление @%s из команды «%s»", user.TelegramID, team.Name),
+				func(ctx context.Context) error { return epicBot.repo.AssignUserTeam(ctx, userID, teamID) })
 			epicBot.deleteAndSend(ctx, msg, msgID,
 				fmt.Sprintf("✅ Пользователь %s %s удалён из команды «%s».",
 					user.FirstName, user.LastName, team.Name))
 		}
 
+	case "deleteteam":
+		teamID, err := uuid.Parse(lastID)
+		if err != nil {
+			epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID команды.")
+			return
+		}
+		epicBot.confirmDeleteTeam(ctx, msg, callback, teamID)
+
+	case "renameteam":
+		teamID, err := uuid.Parse(lastID)
+		if err != nil {
+			epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID команды.")
+			return
+		}
+		epicBot.startRenameTeam(ctx, msg, callback, teamID)
+
+	case "archiveteam", "unarchiveteam":
+		teamID, err := uuid.Parse(lastID)
+		if err != nil {
+			epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID команды.")
+			return
+		}
+		epicBot.setTeamArchived(ctx, msg, callback, teamID, action == "archiveteam")
+
+	case "duplicateepic":
+		teamID, err := uuid.Parse(lastID)
+		if err != nil {
+			epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID команды.")
+			return
+		}
+		epicBot.duplicateEpic(ctx, msg, callback, teamID)
+
+	case "setteamweights":
+		teamID, err := uuid.Parse(lastID)
+		if err != nil {
+			epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID команды.")
+			return
+		}
+		epicBot.startTeamWeights(ctx, msg, callback, teamID)
+
+	case "bulkstartscore":
+		teamID, err := uuid.Parse(lastID)
+		if err != nil {
+			epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID команды.")
+			return
+		}
+		epicBot.bulkStartScore(ctx, msg, callback, teamID)
+
+	case "teamstats":
+		teamID, err := uuid.Parse(lastID)
+		if err != nil {
+			epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID команды.")
+			return
+		}
+		sess, _ := epicBot.sessions.get(sk)
+		msgID := 0
+		if sess != nil {
+			msgID = sess.MessageID
+		}
+		epicBot.sessions.clear(sk)
+		epicBot.showTeamStats(ctx, msg, teamID, msgID)
+
 	case "list":
 		teamID, err := uuid.Parse(lastID)
 		if err != nil {
@@ -472,8 +713,8 @@ func (epicBot *Bot) handleAdmEpicSelected(
 	callback *models.CallbackQuery,
 	data string,
 ) {
-	if !epicBot.isAdminCallback(callback) {
-		epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return
 	}
 	rest := strings.TrimPrefix(data, "adm_epic_")
@@ -492,7 +733,7 @@ func (epicBot *Bot) handleAdmEpicSelected(
 
 	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, "❌ Эпик не найден.")
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
 		return
 	}
 
@@ -505,12 +746,16 @@ func (epicBot *Bot) handleAdmEpicSelected(
 
 	switch action {
 	case "startscore":
+		var deadline time.Duration
+		if sess != nil {
+			deadline, _ = time.ParseDuration(sess.Data["deadline"])
+		}
 		epicBot.sessions.clear(sk)
-		epicBot.deleteAndSendStartScore(ctx, msg, epicID, msgID)
+		epicBot.deleteAndSendStartScore(ctx, msg, callback.From.Username, epicID, msgID, deadline)
 
 	case "results":
 		epicBot.sessions.clear(sk)
-		epicBot.showEpicResultsAndClean(ctx, msg, epicID, msgID)
+		epicBot.showEpicResultsAndClean(ctx, msg, epicID, msgID, epicBot.isAdmin(fromCallback(callback)))
 
 	case "epicstatus":
 		epicBot.sessions.clear(sk)
@@ -525,7 +770,7 @@ func (epicBot *Bot) handleAdmEpicSelected(
 			Data:      map[string]string{"epicID": epicID.String()},
 		})
 		epicBot.editOrSend(ctx, msg, msgID,
-			fmt.Sprintf("📝 Введите описание риска для эпика #%s «%s»:", epic.Number, epic.Name))
+			fmt.Sprintf("📝 Введите описание риска для эпика #%s «%s»:"+cancelHint, epic.Number, epic.Name))
 
 	case "deleteepic":
 		kb := inlineKeyboard(inlineRow(
@@ -537,9 +782,23 @@ func (epicBot *Bot) handleAdmEpicSelected(
 				epic.Number, epic.Name),
 			kb)
 
+	case "closescore":
+		kb := inlineKeyboard(inlineRow(
+			inlineBtn("✅ Да, завершить", "adm_confirm_closescore_"+epicID.String()),
+			inlineBtn("❌ Отмена", "adm_deny_closescore"),
+		))
+		epicBot.editOrSendWithKeyboard(ctx, msg, msgID,
+			fmt.Sprintf("⚠️ Завершить оценку эпика #%s «%s» по уже поданным оценкам?\n"+
+				"Неоценённые риски будут пропущены.",
+				epic.Number, epic.Name),
+			kb)
+
 	case "deleterisk":
 		epicBot.showRiskPickerEditing(ctx, msg, callback, "deleterisk", epic, msgID)
 
+	case "duplicateepic":
+		epicBot.startDuplicateEpic(ctx, msg, callback, epicID, epic.Number, msgID)
+
 	default:
 		epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Неизвестное действие: %s", action))
 	}
@@ -553,8 +812,8 @@ func (epicBot *Bot) handleAdmRiskSelected(
 	callback *models.CallbackQuery,
 	data string,
 ) {
-	if !epicBot.isAdminCallback(callback) {
-		epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return
 	}
 	rest := strings.TrimPrefix(data, "adm_risk_")
@@ -579,7 +838,7 @@ func (epicBot *Bot) handleAdmRiskSelected(
 
 	risk, err := epicBot.repo.GetRiskByID(ctx, riskID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, "❌ Риск не найден.")
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "risk.not_found"))
 		return
 	}
 
@@ -593,9 +852,6 @@ func (epicBot *Bot) handleAdmRiskSelected(
 	switch action {
 	case "deleterisk":
 		desc := risk.Description
-		if len([]rune(desc)) > 60 {
-			desc = string([]rune(desc)[:57]) + "..."
-		}
 		kb := inlineKeyboard(inlineRow(
 			inlineBtn("✅ Да, удалить", "adm_confirm_deleterisk_"+riskID.String()),
 			inlineBtn("❌ Отмена", "adm_deny_deleterisk"),
@@ -616,10 +872,6 @@ func (epicBot *Bot) handleAdmConfirm(
 	callback *models.CallbackQuery,
 	data string,
 ) {
-	if !epicBot.isSuperAdminCallback(callback) {
-		epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
-		return
-	}
 	rest := strings.TrimPrefix(data, "adm_confirm_")
 	if len(rest) < 37 {
 		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
@@ -628,6 +880,17 @@ func (epicBot *Bot) handleAdmConfirm(
 	idStr := rest[len(rest)-36:]
 	action := rest[:len(rest)-37]
 
+	// Closing scoring is available to admins, deletions to super-admins only.
+	if action == "closescore" {
+		if !epicBot.isAdmin(fromCallback(callback)) {
+			epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+			return
+		}
+	} else if !epicBot.isSuperAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return
+	}
+
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID.")
@@ -643,50 +906,164 @@ func (epicBot *Bot) handleAdmConfirm(
 	epicBot.sessions.clear(sk)
 
 	switch action {
+	case "closescore":
+		if err := epicBot.scoring.CloseEpicScoring(ctx, id); err != nil {
+			switch {
+			case errors.Is(err, scoring.ErrNoScores):
+				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Нет ни одной оценки — завершить оценку нельзя.")
+				return
+			case errors.Is(err, scoring.ErrNotScoring):
+				epicBot.deleteAndSend(ctx, msg, msgID, "ℹ️ Эпик сейчас не оценивается — завершать нечего.")
+				return
+			}
+			epicBot.log.Error("failed to close epic scoring", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка завершения оценки.")
+			return
+		}
+		epicNum := id.String()
+		if epic, err := epicBot.repo.GetEpicByID(ctx, id); err == nil {
+			epicNum = epic.Number
+		}
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionScoringClosed, "#"+epicNum)
+		epicBot.showEpicResultsAndClean(ctx, msg, id, msgID, true)
+
 	case "deleteepic":
 		epic, _ := epicBot.repo.GetEpicByID(ctx, id)
-		if err := epicBot.repo.DeleteEpic(ctx, id); err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Ошибка удаления эпика: %v", err))
+		snap, err := epicBot.repo.DeleteEpicTx(ctx, id)
+		if err != nil {
+			epicBot.log.Error("failed to delete epic", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка удаления эпика.")
 			return
 		}
 		epicNum := id.String()
 		if epic != nil {
 			epicNum = epic.Number
 		}
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionEpicDeleted, "#"+epicNum)
+		epicBot.rememberUndo(msg.Chat.ID, callback.From.Username, "удаление эпика #"+epicNum,
+			func(ctx context.Context) error { return epicBot.repo.RestoreEpic(ctx, snap) })
 		epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("🗑️ Эпик #%s удалён.", epicNum))
 
 	case "deleterisk":
 		risk, _ := epicBot.repo.GetRiskByID(ctx, id)
-		if err := epicBot.repo.DeleteRisk(ctx, id); err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Ошибка удаления риска: %v", err))
+		snap, err := epicBot.repo.DeleteRisk(ctx, id)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Риск не найден.")
+				return
+			}
+			epicBot.log.Error("failed to delete risk", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка удаления риска.")
 			return
 		}
 		desc := id.String()
 		if risk != nil {
 			desc = risk.Description
-			if len([]rune(desc)) > 60 {
-				desc = string([]rune(desc)[:57]) + "..."
-			}
 		}
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionRiskDeleted, desc)
+		epicBot.rememberUndo(msg.Chat.ID, callback.From.Username, fmt.Sprintf("удаление риска «%s»", desc),
+			func(ctx context.Context) error { return epicBot.repo.RestoreRisk(ctx, snap) })
 		epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("🗑️ Риск «%s» удалён.", desc))
+		// The deleted risk may have been the last one the epic was waiting
+		// for; nobody else would trigger completion then.
+		if risk != nil {
+			if err := epicBot.scoring.TryCompleteEpicScoring(ctx, risk.EpicID); err != nil {
+				epicBot.log.Error("failed to try complete epic scoring",
+					slog.String("epicID", risk.EpicID.String()), sl.Err(err))
+				epicBot.notifyCompletionError(ctx, msg, err)
+			}
+		}
 
 	case "deleteuser":
 		user, _ := epicBot.repo.GetUserByID(ctx, id)
-		if err := epicBot.repo.DeleteUser(ctx, id); err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Ошибка удаления пользователя: %v", err))
+		snap, err := epicBot.repo.DeleteUserTx(ctx, id)
+		if err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Пользователь не найден.")
+				return
+			}
+			epicBot.log.Error("failed to delete user", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка удаления пользователя.")
 			return
 		}
 		userLabel := id.String()
 		if user != nil {
 			userLabel = fmt.Sprintf("%s %s (@%s)", user.FirstName, user.LastName, user.TelegramID)
 		}
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionUserDeleted, userLabel)
+		epicBot.rememberUndo(msg.Chat.ID, callback.From.Username, "удаление пользователя "+userLabel,
+			func(ctx context.Context) error { return epicBot.repo.RestoreUser(ctx, snap) })
 		epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("🗑️ Пользователь %s удалён.", userLabel))
 
+	case "deleterole":
+		epicBot.deleteRole(ctx, msg, callback, id, msgID)
+
+	case "deleteteam":
+		epicBot.deleteTeam(ctx, msg, callback, id, msgID)
+
 	default:
 		epicBot.sendReply(ctx, msg, "❌ Неизвестное действие.")
 	}
 }
 
+// handleAdmRiskImportance creates the risk described earlier in /addrisk
+// with the chosen importance.
+// data = "adm_importance_<level>"
+func (epicBot *Bot) handleAdmRiskImportance(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	data string,
+) {
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return
+	}
+	importance := domain.Importance(strings.TrimPrefix(data, "adm_importance_"))
+	switch importance {
+	case domain.ImportanceLow, domain.ImportanceMedium, domain.ImportanceHigh:
+	default:
+		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+		return
+	}
+
+	sk := sessionKeyFromCallback(msg, callback)
+	sess, ok := epicBot.sessions.get(sk)
+	if !ok || sess == nil || sess.Step != StepAddRiskImportance {
+		epicBot.sendReply(ctx, msg, "❌ Сессия истекла. Повторите команду.")
+		return
+	}
+	msgID := sess.MessageID
+	desc := sess.Data["riskDesc"]
+	epicIDStr := sess.Data["epicID"]
+	epicBot.sessions.clear(sk)
+
+	epicID, err := uuid.Parse(epicIDStr)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка: неверный ID эпика.")
+		return
+	}
+	risk, err := epicBot.repo.CreateRisk(ctx, desc, epicID, importance)
+	if err != nil {
+		if errors.Is(err, repositories.ErrInvalidInput) {
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Описание риска пустое или слишком длинное.")
+			return
+		}
+		epicBot.log.Error("failed to create risk", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка создания риска.")
+		return
+	}
+	epic, _ := epicBot.repo.GetEpicByID(ctx, epicID)
+	epicNum := epicID.String()
+	if epic != nil {
+		epicNum = epic.Number
+	}
+	epicBot.recordAudit(ctx, callback.From.Username, audit.ActionRiskCreated,
+		fmt.Sprintf("#%s: %s (%s)", epicNum, risk.Description, risk.Importance))
+	epicBot.deleteAndSend(ctx, msg, msgID,
+		fmt.Sprintf("✅ Риск создан для эпика #%s (ID: %s, важность: %s)", epicNum, risk.ID, risk.Importance))
+}
+
 // showRiskPickerEditing sends risks picker editing the existing message.
 func (epicBot *Bot) showRiskPickerEditing(
 	ctx context.Context,
@@ -713,12 +1090,8 @@ func (epicBot *Bot) showRiskPickerEditing(
 	}
 	var rows [][]models.InlineKeyboardButton
 	for _, r := range risks {
-		desc := r.Description
-		if len([]rune(desc)) > 50 {
-			desc = string([]rune(desc)[:47]) + "..."
-		}
 		data := fmt.Sprintf("adm_risk_%s_%s_%s", action, epic.ID.String(), r.ID.String())
-		rows = append(rows, inlineRow(inlineBtn("⚠️ "+desc, data)))
+		rows = append(rows, inlineRow(epicBot.pickerBtn("⚠️ "+r.Description, data)))
 	}
 	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
 	kb := inlineKeyboard(rows...)
@@ -727,19 +1100,19 @@ func (epicBot *Bot) showRiskPickerEditing(
 }
 
 // deleteAndSendStartScore deletes the picker message and runs startscore logic.
-func (epicBot *Bot) deleteAndSendStartScore(ctx context.Context, msg *models.Message, epicID uuid.UUID, msgID int) {
+func (epicBot *Bot) deleteAndSendStartScore(ctx context.Context, msg *models.Message, actor string, epicID uuid.UUID, msgID int, deadline time.Duration) {
 	if msgID > 0 {
 		epicBot.deleteMessage(ctx, msg.Chat.ID, msgID)
 	}
-	epicBot.execStartScore(ctx, msg, epicID)
+	epicBot.execStartScore(ctx, msg, actor, epicID, deadline)
 }
 
 // showEpicResultsAndClean deletes picker message and shows results.
-func (epicBot *Bot) showEpicResultsAndClean(ctx context.Context, msg *models.Message, epicID uuid.UUID, msgID int) {
+func (epicBot *Bot) showEpicResultsAndClean(ctx context.Context, msg *models.Message, epicID uuid.UUID, msgID int, full bool) {
 	if msgID > 0 {
 		_ = epicBot.deleteMessage(ctx, msg.Chat.ID, msgID)
 	}
-	epicBot.showEpicResults(ctx, msg, epicID)
+	epicBot.showEpicResults(ctx, msg, epicID, full)
 }
 
 // showEpicStatusReportAndClean deletes picker message and shows status.
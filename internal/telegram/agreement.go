@@ -0,0 +1,92 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /agreement ───────────────────────────────────────────────────────────
+
+// handleAgreement shows how far a user's effort scores tended to be from
+// the role consensus of scored epics, optionally within one team.
+// Usage: /agreement @username [team name]
+func (epicBot *Bot) handleAgreement(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleAgreement"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	args := strings.Fields(commandArguments(msg))
+	if len(args) == 0 || !strings.HasPrefix(args[0], "@") || len(args[0]) < 2 {
+		_, err := epicBot.sendReply(ctx, msg, "⚠️ Использование: /agreement @username [команда]")
+		return err
+	}
+	username := strings.TrimPrefix(args[0], "@")
+
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			_, retErr := epicBot.sendReply(ctx, msg,
+				fmt.Sprintf("❌ Пользователь @%s не зарегистрирован.", username))
+			return retErr
+		}
+		log.Error("error finding user", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal"))
+		return retErr
+	}
+
+	var teamID *uuid.UUID
+	scope := "по всем командам"
+	if len(args) > 1 {
+		name := strings.Join(args[1:], " ")
+		team, err := epicBot.repo.GetTeamByName(ctx, name)
+		if err != nil {
+			_, retErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "team.name_not_found", name))
+			return retErr
+		}
+		teamID = &team.ID
+		scope = fmt.Sprintf("в команде «%s»", team.Name)
+	}
+
+	a, err := epicBot.repo.GetUserAgreement(ctx, user.ID, teamID)
+	if err != nil {
+		log.Error("error getting user agreement", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка расчёта согласованности.")
+		return retErr
+	}
+	if a.Epics == 0 {
+		_, retErr := epicBot.sendReply(ctx, msg,
+			fmt.Sprintf("ℹ️ У @%s нет оценок завершённых эпиков %s.", user.TelegramID, scope))
+		return retErr
+	}
+
+	tendency := "оценивает в среднем точно"
+	switch {
+	case a.AvgDeviation >= 0.5:
+		tendency = "склонен переоценивать"
+	case a.AvgDeviation <= -0.5:
+		tendency = "склонен недооценивать"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🎯 Согласованность оценок @%s %s\n\n", user.TelegramID, scope)
+	fmt.Fprintf(&sb, "Оценок в завершённых эпиках: %d\n", a.Epics)
+	fmt.Fprintf(&sb, "Среднее отклонение: %+.2f\n", a.AvgDeviation)
+	fmt.Fprintf(&sb, "Среднее абсолютное отклонение: %.2f\n\n", a.AvgAbsDeviation)
+	fmt.Fprintf(&sb, "Отклонение считается от средневзвешенной оценки роли в эпике; %s.", tendency)
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}
@@ -0,0 +1,128 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /archiveteam, /unarchiveteam — team picker ──────────────────────────
+
+// historyTeamActions are the team pickers that also offer archived teams:
+// reports and maintenance of a team still make sense after archiving.
+var historyTeamActions = map[string]bool{
+	"teamstats":  true,
+	"list":       true,
+	"renameteam": true,
+	"deleteteam": true,
+}
+
+// pickerTeams returns the teams offered by the team picker of action.
+// Archived teams are hidden unless the action needs them.
+func (epicBot *Bot) pickerTeams(ctx context.Context, action string) ([]domain.Team, error) {
+	switch {
+	case action == "unarchiveteam":
+		all, err := epicBot.repo.GetTeams(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		var archived []domain.Team
+		for _, t := range all {
+			if t.ArchivedAt != nil {
+				archived = append(archived, t)
+			}
+		}
+		return archived, nil
+	case historyTeamActions[action]:
+		return epicBot.repo.GetTeams(ctx, true)
+	default:
+		return epicBot.repo.GetAllTeams(ctx)
+	}
+}
+
+// teamPickerLabel marks archived teams in a team picker.
+func teamPickerLabel(t domain.Team) string {
+	if t.ArchivedAt != nil {
+		return "📦 " + t.Name
+	}
+	return "👥 " + t.Name
+}
+
+// handleArchiveTeam shows a team picker for hiding a team from pickers
+// without deleting its epics.
+func (epicBot *Bot) handleArchiveTeam(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+	return epicBot.showTeamPickerInitial(ctx, msg, "archiveteam")
+}
+
+// handleUnarchiveTeam shows a picker of archived teams to restore.
+func (epicBot *Bot) handleUnarchiveTeam(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+	return epicBot.showTeamPickerInitial(ctx, msg, "unarchiveteam")
+}
+
+// setTeamArchived archives or restores the picked team.
+func (epicBot *Bot) setTeamArchived(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	teamID uuid.UUID,
+	archive bool,
+) {
+	op := "bot.setTeamArchived"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("team_id", teamID.String()),
+	)
+	if !epicBot.isSuperAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return
+	}
+	sk := sessionKeyFromCallback(msg, callback)
+	msgID := 0
+	if sess, ok := epicBot.sessions.get(sk); ok {
+		msgID = sess.MessageID
+	}
+	epicBot.sessions.clear(sk)
+
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+
+	if archive {
+		err = epicBot.repo.ArchiveTeam(ctx, teamID)
+	} else {
+		err = epicBot.repo.UnarchiveTeam(ctx, teamID)
+	}
+	if err != nil {
+		log.Error("error changing team archive state", slog.Bool("archive", archive), sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+
+	if archive {
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionTeamArchived, team.Name)
+		epicBot.deleteAndSend(ctx, msg, msgID,
+			fmt.Sprintf("📦 Команда «%s» в архиве. Её эпики сохранены и доступны в отчётах.\n"+
+				"Вернуть: /unarchiveteam", team.Name))
+		return
+	}
+	epicBot.recordAudit(ctx, callback.From.Username, audit.ActionTeamUnarchived, team.Name)
+	epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("✅ Команда «%s» возвращена из архива.", team.Name))
+}
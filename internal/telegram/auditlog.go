@@ -0,0 +1,77 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// defaultAuditLogEntries is how many entries /auditlog shows by default.
+	defaultAuditLogEntries = 20
+	// maxAuditLogEntries caps the N argument of /auditlog.
+	maxAuditLogEntries = 100
+)
+
+// recordAudit writes an administrative action to the audit log.
+func (epicBot *Bot) recordAudit(ctx context.Context, actor, action, details string) {
+	if epicBot.audit == nil {
+		return
+	}
+	epicBot.audit.Record(ctx, actor, action, details)
+}
+
+// ─── /auditlog ────────────────────────────────────────────────────────────
+
+// handleAuditLog prints the latest audit entries.
+// Usage: /auditlog [N]
+func (epicBot *Bot) handleAuditLog(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleAuditLog"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+
+	limit := defaultAuditLogEntries
+	if args := strings.TrimSpace(commandArguments(msg)); args != "" {
+		n, err := strconv.Atoi(args)
+		if err != nil || n <= 0 {
+			_, retErr := epicBot.sendReply(ctx, msg, "⚠️ Использование: /auditlog [количество записей]")
+			return retErr
+		}
+		limit = min(n, maxAuditLogEntries)
+	}
+
+	entries, err := epicBot.repo.GetRecentAuditEntries(ctx, limit)
+	if err != nil {
+		log.Error("error getting audit entries", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка чтения журнала.")
+		return retErr
+	}
+	if len(entries) == 0 {
+		_, err := epicBot.sendReply(ctx, msg, "📜 Журнал действий пуст.")
+		return err
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "📜 Последние действия (%d):\n\n", len(entries))
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "%s @%s %s", e.CreatedAt.Format("02.01.2006 15:04"), e.Actor, e.Action)
+		if e.Details != "" {
+			fmt.Fprintf(&sb, ": %s", e.Details)
+		}
+		sb.WriteString("\n")
+	}
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}
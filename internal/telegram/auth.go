@@ -6,62 +6,81 @@ import (
 	"github.com/go-telegram/bot/models"
 )
 
-// isAdmin checks if the message sender is in the admins list.
-func (epicBot *Bot) isAdmin(msg *models.Message) bool {
-	if msg == nil || msg.From == nil {
-		return false
-	}
-	for _, admin := range epicBot.cfg.BotConfig.Admins {
-		if strings.EqualFold(msg.From.Username, admin) {
-			return true
-		}
+// MessageInfo exposes the sender of an incoming update regardless of its
+// concrete type, so authorization does not depend on where a request came
+// from (a message or a callback query).
+type MessageInfo interface {
+	SenderUsername() string
+	SenderID() int64
+}
+
+// messageSender adapts *models.Message to MessageInfo.
+type messageSender struct{ msg *models.Message }
+
+// fromMessage wraps a message as MessageInfo. A nil message has no sender.
+func fromMessage(msg *models.Message) MessageInfo { return messageSender{msg: msg} }
+
+func (m messageSender) SenderUsername() string {
+	if m.msg == nil || m.msg.From == nil {
+		return ""
 	}
-	for _, superadmin := range epicBot.cfg.BotConfig.SuperAdmins {
-		if strings.EqualFold(msg.From.Username, superadmin) {
-			return true
-		}
+	return m.msg.From.Username
+}
+
+func (m messageSender) SenderID() int64 {
+	if m.msg == nil || m.msg.From == nil {
+		return 0
 	}
-	return false
+	return m.msg.From.ID
 }
 
-// isSuperAdmin checks if the message sender is in the super admins list.
-func (epicBot *Bot) isSuperAdmin(msg *models.Message) bool {
-	if msg == nil || msg.From == nil {
-		return false
+// callbackSender adapts *models.CallbackQuery to MessageInfo.
+type callbackSender struct{ callback *models.CallbackQuery }
+
+// fromCallback wraps a callback query as MessageInfo. A nil callback has no sender.
+func fromCallback(callback *models.CallbackQuery) MessageInfo {
+	return callbackSender{callback: callback}
+}
+
+func (c callbackSender) SenderUsername() string {
+	if c.callback == nil {
+		return ""
 	}
-	for _, superadmin := range epicBot.cfg.BotConfig.SuperAdmins {
-		if strings.EqualFold(msg.From.Username, superadmin) {
-			return true
-		}
+	return c.callback.From.Username
+}
+
+func (c callbackSender) SenderID() int64 {
+	if c.callback == nil {
+		return 0
 	}
-	return false
+	return c.callback.From.ID
 }
 
-// isAdminCallback checks if the callback sender is an admin.
-func (epicBot *Bot) isAdminCallback(callback *models.CallbackQuery) bool {
-	if callback == nil {
+// isAdmin checks if the sender is in the admins or super-admins list.
+func (epicBot *Bot) isAdmin(sender MessageInfo) bool {
+	username := sender.SenderUsername()
+	if username == "" {
 		return false
 	}
-	for _, admin := range epicBot.cfg.BotConfig.Admins {
-		if strings.EqualFold(callback.From.Username, admin) {
+	epicBot.adminsMu.RLock()
+	admins := epicBot.cfg.BotConfig.Admins
+	epicBot.adminsMu.RUnlock()
+	for _, admin := range admins {
+		if strings.EqualFold(username, admin) {
 			return true
 		}
 	}
-	for _, superadmin := range epicBot.cfg.BotConfig.SuperAdmins {
-		if strings.EqualFold(callback.From.Username, superadmin) {
-			return true
-		}
-	}
-	return false
+	return epicBot.isSuperAdmin(sender)
 }
 
-// isSuperAdminCallback checks if the callback sender is a super admin.
-func (epicBot *Bot) isSuperAdminCallback(callback *models.CallbackQuery) bool {
-	if callback == nil {
+// isSuperAdmin checks if the sender is in the super-admins list.
+func (epicBot *Bot) isSuperAdmin(sender MessageInfo) bool {
+	username := sender.SenderUsername()
+	if username == "" {
 		return false
 	}
 	for _, superadmin := range epicBot.cfg.BotConfig.SuperAdmins {
-		if strings.EqualFold(callback.From.Username, superadmin) {
+		if strings.EqualFold(username, superadmin) {
 			return true
 		}
 	}
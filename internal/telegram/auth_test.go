@@ -0,0 +1,64 @@
+package telegram
+
+import (
+	"testing"
+
+	"EpicScoreBot/internal/config"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestAdminChecks(t *testing.T) {
+	epicBot := &Bot{cfg: &config.Config{BotConfig: config.BotConfig{
+		Admins:      []string{"ann"},
+		SuperAdmins: []string{"Root"},
+	}}}
+
+	adapters := []struct {
+		name string
+		wrap func(user *models.User) MessageInfo
+	}{
+		{"message", func(user *models.User) MessageInfo {
+			return fromMessage(&models.Message{From: user})
+		}},
+		{"callback", func(user *models.User) MessageInfo {
+			if user == nil {
+				return fromCallback(&models.CallbackQuery{})
+			}
+			return fromCallback(&models.CallbackQuery{From: *user})
+		}},
+	}
+	tests := []struct {
+		name           string
+		user           *models.User
+		wantAdmin      bool
+		wantSuperAdmin bool
+	}{
+		{"admin", &models.User{ID: 1, Username: "ann"}, true, false},
+		{"admin in another case", &models.User{ID: 1, Username: "ANN"}, true, false},
+		{"super-admin is an admin too", &models.User{ID: 2, Username: "root"}, true, true},
+		{"other user", &models.User{ID: 3, Username: "bob"}, false, false},
+		{"no username", &models.User{ID: 4}, false, false},
+		{"no sender", nil, false, false},
+	}
+	for _, a := range adapters {
+		for _, tt := range tests {
+			t.Run(a.name+"/"+tt.name, func(t *testing.T) {
+				sender := a.wrap(tt.user)
+				if got := epicBot.isAdmin(sender); got != tt.wantAdmin {
+					t.Errorf("isAdmin() = %v, want %v", got, tt.wantAdmin)
+				}
+				if got := epicBot.isSuperAdmin(sender); got != tt.wantSuperAdmin {
+					t.Errorf("isSuperAdmin() = %v, want %v", got, tt.wantSuperAdmin)
+				}
+			})
+		}
+	}
+
+	// The nil adapters must not panic either.
+	for _, sender := range []MessageInfo{fromMessage(nil), fromCallback(nil)} {
+		if epicBot.isAdmin(sender) || epicBot.isSuperAdmin(sender) {
+			t.Errorf("%T without an update is an admin", sender)
+		}
+	}
+}
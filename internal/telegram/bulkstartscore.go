@@ -0,0 +1,73 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /bulkstartscore — team picker ────────────────────────────────────────
+
+// handleBulkStartScore shows a team picker for sending every new epic of
+// the team to scoring at once.
+func (epicBot *Bot) handleBulkStartScore(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	return epicBot.showTeamPickerInitial(ctx, msg, "bulkstartscore")
+}
+
+// bulkStartScore moves all NEW epics of the picked team and their risks to
+// SCORING in one transaction. Epics already being scored are left alone.
+func (epicBot *Bot) bulkStartScore(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	teamID uuid.UUID,
+) {
+	op := "bot.bulkStartScore"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("team_id", teamID.String()),
+	)
+	sk := sessionKeyFromCallback(msg, callback)
+	msgID := 0
+	if sess, ok := epicBot.sessions.get(sk); ok {
+		msgID = sess.MessageID
+	}
+	epicBot.sessions.clear(sk)
+
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+	numbers, risks, err := epicBot.repo.StartTeamScoring(ctx, teamID)
+	if err != nil {
+		log.Error("error starting team scoring", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка запуска оценки.")
+		return
+	}
+	if len(numbers) == 0 {
+		epicBot.deleteAndSend(ctx, msg, msgID,
+			fmt.Sprintf("ℹ️ В команде «%s» нет новых эпиков.", team.Name))
+		return
+	}
+
+	for _, number := range numbers {
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionScoringStarted, "#"+number)
+	}
+	log.Info("team scoring started", slog.Int("epics", len(numbers)), slog.Int("risks", risks))
+	epicBot.deleteAndSend(ctx, msg, msgID,
+		fmt.Sprintf("🚀 Команда «%s»: на оценку отправлено эпиков — %d, рисков — %d.\n#%s",
+			team.Name, len(numbers), risks, strings.Join(numbers, ", #")))
+}
@@ -2,12 +2,15 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
 	"strings"
 	"time"
 
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
 	"EpicScoreBot/internal/scoring"
 	"EpicScoreBot/internal/utils/logger/sl"
 
@@ -84,7 +87,21 @@ func (epicBot *Bot) handleCallbackQuery(ctx context.Context, update *models.Upda
 
 	// score_epic_<epicID>_<value> — submit epic score
 	case strings.HasPrefix(data, "score_epic_"):
-		epicBot.handleEpicScoreSubmit(rctx, msg, username, data)
+		epicBot.handleEpicScoreSubmit(rctx, msg, username, strings.TrimPrefix(data, "score_epic_"), false)
+
+	// rescore_epic_<epicID>_<value> — confirmed replacement of an epic score
+	case strings.HasPrefix(data, "rescore_epic_"):
+		epicBot.handleEpicScoreSubmit(rctx, msg, username, strings.TrimPrefix(data, "rescore_epic_"), true)
+
+	// rescore_risk_<riskID>_<prob>_<impact> — confirmed replacement of a risk score
+	case strings.HasPrefix(data, "rescore_risk_"):
+		epicBot.handleRiskRescore(rctx, msg, username, data)
+
+	// rescore_keep — keep the previous score
+	case data == "rescore_keep":
+		if err := epicBot.editReply(rctx, msg.Chat.ID, msg.ID, "👌 Оценка оставлена без изменений."); err != nil {
+			log.Error("failed to edit message", sl.Err(err))
+		}
 
 	// risks_<epicID> — show unscored risks for epic
 	case strings.HasPrefix(data, "risks_"):
@@ -106,7 +123,7 @@ func (epicBot *Bot) handleCallbackQuery(ctx context.Context, update *models.Upda
 			epicBot.sendCallbackAlert(rctx, callback, "❌ Ошибка парсинга ID риска")
 			return
 		}
-		epicBot.showRiskScoreForm(rctx, msg, riskID)
+		epicBot.showRiskScoreForm(rctx, msg, username, riskID)
 
 	// riskprob_<riskID>_<value> — submit risk probability (step 1)
 	case strings.HasPrefix(data, "riskprob_"):
@@ -116,6 +133,37 @@ func (epicBot *Bot) handleCallbackQuery(ctx context.Context, update *models.Upda
 	case strings.HasPrefix(data, "riskimp_"):
 		epicBot.handleRiskImpact(rctx, msg, username, data)
 
+	// riskskip_<riskID> — mark a risk as not applicable to the user
+	case strings.HasPrefix(data, "riskskip_"):
+		riskID, err := uuid.Parse(strings.TrimPrefix(data, "riskskip_"))
+		if err != nil {
+			epicBot.sendCallbackAlert(rctx, callback, "❌ Ошибка парсинга ID риска")
+			return
+		}
+		epicBot.handleRiskSkip(rctx, msg, username, riskID)
+
+	// comment_<epicID> — ask for a comment on an epic
+	case strings.HasPrefix(data, "comment_"):
+		epicID, err := uuid.Parse(strings.TrimPrefix(data, "comment_"))
+		if err != nil {
+			epicBot.sendCallbackAlert(rctx, callback, "❌ Ошибка парсинга ID эпика")
+			return
+		}
+		epicBot.startEpicComment(rctx, msg, username, epicID)
+
+	// resetscore_<epicID> — withdraw the user's effort score
+	case strings.HasPrefix(data, "resetscore_"):
+		epicID, err := uuid.Parse(strings.TrimPrefix(data, "resetscore_"))
+		if err != nil {
+			epicBot.sendCallbackAlert(rctx, callback, "❌ Ошибка парсинга ID эпика")
+			return
+		}
+		epicBot.handleResetScore(rctx, msg, username, epicID)
+
+	// settings_<option> — toggle in /settings
+	case strings.HasPrefix(data, "settings_"):
+		epicBot.handleSettingsCallback(rctx, msg, callback, data)
+
 	// ── Admin flows ─────────────────────────────────────────────────────────
 
 	case data == "adm_cancel":
@@ -125,12 +173,16 @@ func (epicBot *Bot) handleCallbackQuery(ctx context.Context, update *models.Upda
 		if ok && sess.MessageID > 0 {
 			epicBot.deleteMessage(rctx, msg.Chat.ID, sess.MessageID)
 		}
-		epicBot.sendReply(rctx, msg, "❌ Действие отменено.")
+		epicBot.sendReply(rctx, msg, epicBot.t(rctx, msg, "action.cancelled"))
 
 	// adm_user_<action>_<userID> — user selected in picker
 	case strings.HasPrefix(data, "adm_user_"):
 		epicBot.handleAdmUserSelected(rctx, msg, callback, data)
 
+	// adm_userpage_<action>_<offset> — user picker page switched
+	case strings.HasPrefix(data, "adm_userpage_"):
+		epicBot.handleAdmUserPage(rctx, msg, callback, data)
+
 	// adm_role_<action>_<roleID> — role selected in picker
 	case strings.HasPrefix(data, "adm_role_"):
 		epicBot.handleAdmRoleSelected(rctx, msg, callback, data)
@@ -139,6 +191,10 @@ func (epicBot *Bot) handleCallbackQuery(ctx context.Context, update *models.Upda
 	case strings.HasPrefix(data, "adm_team_"):
 		epicBot.handleAdmTeamSelected(rctx, msg, callback, data)
 
+	// adm_epicpage_<action>_<status>_<offset> — epic picker page switched
+	case strings.HasPrefix(data, "adm_epicpage_"):
+		epicBot.handleAdmEpicPage(rctx, msg, callback, data)
+
 	// adm_epic_<action>_<epicID> — epic selected in picker
 	case strings.HasPrefix(data, "adm_epic_"):
 		epicBot.handleAdmEpicSelected(rctx, msg, callback, data)
@@ -147,6 +203,14 @@ func (epicBot *Bot) handleCallbackQuery(ctx context.Context, update *models.Upda
 	case strings.HasPrefix(data, "adm_risk_"):
 		epicBot.handleAdmRiskSelected(rctx, msg, callback, data)
 
+	// adm_tw_<action>[_<userID>] — weight editor of /setteamweights
+	case strings.HasPrefix(data, "adm_tw_"):
+		epicBot.handleAdmTeamWeights(rctx, msg, callback, data)
+
+	// adm_importance_<level> — risk importance chosen in /addrisk
+	case strings.HasPrefix(data, "adm_importance_"):
+		epicBot.handleAdmRiskImportance(rctx, msg, callback, data)
+
 	// adm_confirm_<action>_<id> — confirm destructive action
 	case strings.HasPrefix(data, "adm_confirm_"):
 		epicBot.handleAdmConfirm(rctx, msg, callback, data)
@@ -159,7 +223,11 @@ func (epicBot *Bot) handleCallbackQuery(ctx context.Context, update *models.Upda
 		if ok && sess.MessageID > 0 {
 			epicBot.deleteMessage(rctx, msg.Chat.ID, sess.MessageID)
 		}
-		epicBot.sendReply(rctx, msg, "❌ Удаление отменено.")
+		if data == "adm_deny_closescore" {
+			epicBot.sendReply(rctx, msg, "❌ Завершение оценки отменено.")
+			return
+		}
+		epicBot.sendReply(rctx, msg, epicBot.t(rctx, msg, "action.delete_cancelled"))
 
 	default:
 		log.Warn("unknown callback data", slog.String("data", data))
@@ -173,7 +241,7 @@ func (epicBot *Bot) showTeamEpics(ctx context.Context, msg *models.Message, user
 
 	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Пользователь не найден."); botErr != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found")); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
@@ -181,7 +249,8 @@ func (epicBot *Bot) showTeamEpics(ctx context.Context, msg *models.Message, user
 
 	epics, err := epicBot.repo.GetUnscoredEpicsByUser(ctx, user.ID, teamID)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка: %v", err)); botErr != nil {
+		log.Error("failed to get unscored epics", sl.Err(err))
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal")); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
@@ -203,7 +272,7 @@ func (epicBot *Bot) showTeamEpics(ctx context.Context, msg *models.Message, user
 
 	var rows [][]models.InlineKeyboardButton
 	for _, epic := range epics {
-		rows = append(rows, inlineRow(inlineBtn(
+		rows = append(rows, inlineRow(epicBot.pickerBtn(
 			fmt.Sprintf("📝 #%s %s", epic.Number, epic.Name),
 			fmt.Sprintf("epic_%s", epic.ID.String()),
 		)))
@@ -223,7 +292,7 @@ func (epicBot *Bot) showEpicScoreOptions(ctx context.Context, msg *models.Messag
 
 	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Эпик не найден."); botErr != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found")); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
@@ -231,7 +300,7 @@ func (epicBot *Bot) showEpicScoreOptions(ctx context.Context, msg *models.Messag
 
 	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Пользователь не найден."); botErr != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found")); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
@@ -246,6 +315,14 @@ func (epicBot *Bot) showEpicScoreOptions(ctx context.Context, msg *models.Messag
 	}
 
 	effortScored, _ := epicBot.repo.HasUserScoredEpic(ctx, epicID, user.ID)
+	// Users outside the epic's required roles only score its risks.
+	if !effortScored {
+		scorer, err := epicBot.repo.IsUserEpicScorer(ctx, epicID, user.ID)
+		if err != nil {
+			log.Error("error checking epic scorer", sl.Err(err))
+		}
+		effortScored = err == nil && !scorer
+	}
 	unscoredRisks, _ := epicBot.repo.GetUnscoredRisksByUser(ctx, user.ID, epicID)
 
 	if effortScored && len(unscoredRisks) == 0 {
@@ -273,9 +350,11 @@ func (epicBot *Bot) showEpicScoreOptions(ctx context.Context, msg *models.Messag
 		},
 	}
 
-	sent, botErr := epicBot.sendMarkdown(ctx, msg,
-		fmt.Sprintf("📝 Эпик \\#%s «%s»\n\n%s\n\nВаша роль: *%s*\n\nВведите оценку трудоёмкости \\(число от 0 до 500\\):",
-			escapeMarkdownV2(epic.Number), escapeMarkdownV2(epic.Name), escapeMarkdownV2(epic.Description), escapeMarkdownV2(role.Name)))
+	sent, botErr := epicBot.sendMarkdownWithKeyboard(ctx, msg,
+		fmt.Sprintf("📝 Эпик \\#%s «%s»\n\n%s\n\nВаша роль: *%s*\n\nВведите оценку трудоёмкости \\(число от %d до %d\\) или /cancel для отмены:",
+			escapeMarkdownV2(epic.Number), escapeMarkdownV2(epic.Name), escapeMarkdownV2(epic.Description), escapeMarkdownV2(role.Name),
+			scoring.MinEffortScore, epicBot.cfg.Scoring.EffortMax()),
+		inlineKeyboard(inlineRow(inlineBtn("💬 Комментарий", "comment_"+epicID.String()))))
 	if botErr != nil {
 		log.Error("failed to send reply", sl.Err(botErr))
 		return
@@ -286,13 +365,18 @@ func (epicBot *Bot) showEpicScoreOptions(ctx context.Context, msg *models.Messag
 	epicBot.sessions.set(sk, sess)
 }
 
-// handleEpicScoreSubmit processes an epic score submission.
-// Format: score_epic_<epicID>_<value>
-func (epicBot *Bot) handleEpicScoreSubmit(ctx context.Context, msg *models.Message, username, data string) {
+// handleEpicScoreSubmit parses an epic score submitted with a button.
+// Format: <epicID>_<value>. Unless confirmed, replacing a different
+// earlier score asks the user first.
+func (epicBot *Bot) handleEpicScoreSubmit(
+	ctx context.Context,
+	msg *models.Message,
+	username, trimmed string,
+	confirmed bool,
+) {
 	op := "bot.handleEpicScoreSubmit()"
 	log := epicBot.log.With(slog.String("op", op))
 
-	trimmed := strings.TrimPrefix(data, "score_epic_")
 	lastUnderscore := strings.LastIndex(trimmed, "_")
 	if lastUnderscore < 0 {
 		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Некорректные данные."); botErr != nil {
@@ -301,10 +385,7 @@ func (epicBot *Bot) handleEpicScoreSubmit(ctx context.Context, msg *models.Messa
 		return
 	}
 
-	epicIDStr := trimmed[:lastUnderscore]
-	valueStr := trimmed[lastUnderscore+1:]
-
-	epicID, err := uuid.Parse(epicIDStr)
+	epicID, err := uuid.Parse(trimmed[:lastUnderscore])
 	if err != nil {
 		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID эпика."); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
@@ -312,57 +393,92 @@ func (epicBot *Bot) handleEpicScoreSubmit(ctx context.Context, msg *models.Messa
 		return
 	}
 
-	score, err := strconv.Atoi(valueStr)
-	if err != nil || score < 1 {
+	score, err := strconv.Atoi(trimmed[lastUnderscore+1:])
+	if err != nil {
 		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Некорректная оценка."); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
 	}
 
+	epicBot.submitEpicScore(ctx, msg, msg.ID, username, epicID, score, confirmed)
+}
+
+// submitEpicScore saves a user's effort score for an epic, replacing the
+// prompt identified by promptID with the result, and tries to complete
+// the epic. It is shared by the button and the text-input paths.
+func (epicBot *Bot) submitEpicScore(
+	ctx context.Context,
+	msg *models.Message,
+	promptID int,
+	username string,
+	epicID uuid.UUID,
+	score int,
+	confirmed bool,
+) {
+	if err := epicBot.scoring.ValidateEffort(score); err != nil {
+		epicBot.deleteAndSend(ctx, msg, promptID,
+			fmt.Sprintf("❌ Оценка должна быть числом от %d до %d.", scoring.MinEffortScore, epicBot.cfg.Scoring.EffortMax()))
+		return
+	}
+
 	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Пользователь не найден."); botErr != nil {
-			log.Error("failed to send reply", sl.Err(botErr))
-		}
+		epicBot.deleteAndSend(ctx, msg, promptID, epicBot.lookupErrorText(ctx, msg, err, "user.not_found"))
 		return
 	}
 
 	role, err := epicBot.repo.GetRoleByUserID(ctx, user.ID)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, "❌ У вас нет назначенной роли."); botErr != nil {
-			log.Error("failed to send reply", sl.Err(botErr))
-		}
+		epicBot.deleteAndSend(ctx, msg, promptID, "❌ У вас нет назначенной роли.")
 		return
 	}
 
-	if err := epicBot.repo.CreateEpicScore(ctx, epicID, user.ID, role.ID, score); err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg,
-			fmt.Sprintf("❌ Ошибка сохранения оценки: %v", err)); botErr != nil {
-			log.Error("failed to send reply", sl.Err(botErr))
-		}
+	if err := epicBot.checkEpicTeamMember(ctx, user.ID, epicID); err != nil {
+		epicBot.deleteAndSend(ctx, msg, promptID, epicBot.teamMemberErrorText(ctx, msg, err))
 		return
 	}
 
-	epic, _ := epicBot.repo.GetEpicByID(ctx, epicID)
-	epicNum := epicID.String()
-	if epic != nil {
-		epicNum = epic.Number
+	scorer, err := epicBot.repo.IsUserEpicScorer(ctx, epicID, user.ID)
+	if err != nil {
+		epicBot.log.Error("failed to check epic scorer", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, promptID, "❌ Ошибка сохранения оценки.")
+		return
+	}
+	if !scorer {
+		epicBot.deleteAndSend(ctx, msg, promptID,
+			fmt.Sprintf("⛔ Трудоёмкость этого эпика оценивают другие роли, ваша роль: %s.", role.Name))
+		return
 	}
 
-	if _, botErr := epicBot.sendReply(ctx, msg,
-		fmt.Sprintf("✅ Оценка %d для эпика #%s сохранена!", score, epicNum)); botErr != nil {
-		log.Error("failed to send reply", sl.Err(botErr))
+	if !confirmed && epicBot.confirmEpicRescore(ctx, msg, promptID, user.ID, epicID, score) {
+		return
 	}
 
+	inserted, err := epicBot.repo.CreateEpicScore(ctx, epicID, user.ID, role.ID, score)
+	if err != nil {
+		epicBot.log.Error("failed to save epic score", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, promptID, "❌ Ошибка сохранения оценки.")
+		return
+	}
+
+	epicNum := epicID.String()
+	if epic, err := epicBot.repo.GetEpicByID(ctx, epicID); err == nil {
+		epicNum = epic.Number
+	}
+	epicBot.deleteAndSend(ctx, msg, promptID,
+		fmt.Sprintf("✅ Оценка %d для эпика #%s %s!", score, epicNum, savedVerb(inserted)))
+
+	// Completion is checked after every save: a retried write may report
+	// a new vote as replaced, and an earlier attempt may have failed.
 	if err := epicBot.scoring.TryCompleteEpicScoring(ctx, epicID); err != nil {
 		epicBot.log.Error("failed to try complete epic scoring",
 			slog.String("epicID", epicID.String()), sl.Err(err))
+		epicBot.notifyCompletionError(ctx, msg, err)
 	}
 
 	// Show unscored risks if any remain.
 	epicBot.showEpicRisks(ctx, msg, username, epicID)
-
 }
 
 // showEpicRisks shows unscored risks for an epic.
@@ -372,7 +488,7 @@ func (epicBot *Bot) showEpicRisks(ctx context.Context, msg *models.Message, user
 
 	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Пользователь не найден."); botErr != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found")); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
@@ -380,7 +496,8 @@ func (epicBot *Bot) showEpicRisks(ctx context.Context, msg *models.Message, user
 
 	risks, err := epicBot.repo.GetUnscoredRisksByUser(ctx, user.ID, epicID)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка: %v", err)); botErr != nil {
+		log.Error("failed to get unscored risks", sl.Err(err))
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal")); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
@@ -395,12 +512,8 @@ func (epicBot *Bot) showEpicRisks(ctx context.Context, msg *models.Message, user
 
 	var rows [][]models.InlineKeyboardButton
 	for _, risk := range risks {
-		desc := risk.Description
-		if len([]rune(desc)) > 50 {
-			desc = string([]rune(desc)[:47]) + "..."
-		}
-		rows = append(rows, inlineRow(inlineBtn(
-			fmt.Sprintf("⚠️ %s", desc),
+		rows = append(rows, inlineRow(epicBot.pickerBtn(
+			fmt.Sprintf("⚠️ %s", risk.Description),
 			fmt.Sprintf("risk_%s", risk.ID.String()),
 		)))
 	}
@@ -412,19 +525,43 @@ func (epicBot *Bot) showEpicRisks(ctx context.Context, msg *models.Message, user
 	}
 }
 
-// showRiskScoreForm shows probability buttons for a risk.
-func (epicBot *Bot) showRiskScoreForm(ctx context.Context, msg *models.Message, riskID uuid.UUID) {
+// showRiskScoreForm shows probability buttons for a risk. The prompt also
+// accepts a reply with both values, see handleRiskReply.
+func (epicBot *Bot) showRiskScoreForm(ctx context.Context, msg *models.Message, username string, riskID uuid.UUID) {
 	op := "bot.showRiskScoreForm()"
 	log := epicBot.log.With(slog.String("op", op))
 
 	risk, err := epicBot.repo.GetRiskByID(ctx, riskID)
 	if err != nil {
-		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Риск не найден."); botErr != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "risk.not_found")); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
 	}
 
+	kb := riskProbabilityKeyboard(riskID)
+
+	if err := epicBot.editMarkdownWithKeyboard(ctx, msg.Chat.ID, msg.ID,
+		fmt.Sprintf("⚠️ Риск: %s\n\nВыберите *вероятность* риска \\(1–4\\)\\.\n"+
+			"Или ответьте на это сообщение двумя числами: вероятность и влияние, например `3 2`\\.",
+			escapeMarkdownV2(risk.Description)),
+		kb); err != nil {
+		log.Error("failed to edit message", sl.Err(err))
+		return
+	}
+
+	sk := sessionKey{ChatID: msg.Chat.ID, ThreadID: msg.MessageThreadID, Username: username}
+	epicBot.sessions.set(sk, &Session{
+		Step:      StepScoreRiskReply,
+		ThreadID:  msg.MessageThreadID,
+		Username:  username,
+		MessageID: msg.ID,
+		Data:      map[string]string{"riskID": riskID.String()},
+	})
+}
+
+// riskProbabilityKeyboard holds the probability buttons of a risk prompt.
+func riskProbabilityKeyboard(riskID uuid.UUID) *models.InlineKeyboardMarkup {
 	var probBtns []models.InlineKeyboardButton
 	for i := 1; i <= 4; i++ {
 		probBtns = append(probBtns, inlineBtn(
@@ -432,13 +569,60 @@ func (epicBot *Bot) showRiskScoreForm(ctx context.Context, msg *models.Message,
 			fmt.Sprintf("riskprob_%s_%d", riskID.String(), i),
 		))
 	}
-	kb := inlineKeyboard(inlineRow(probBtns...))
+	return inlineKeyboard(
+		inlineRow(probBtns...),
+		inlineRow(inlineBtn("⏭ Не могу оценить", "riskskip_"+riskID.String())),
+	)
+}
 
-	if err := epicBot.editMarkdownWithKeyboard(ctx, msg.Chat.ID, msg.ID,
-		fmt.Sprintf("⚠️ Риск: %s\n\nВыберите *вероятность* риска \\(1–4\\):", escapeMarkdownV2(risk.Description)),
-		kb); err != nil {
-		log.Error("failed to edit message", sl.Err(err))
+// handleRiskReply records a risk score sent as a reply ("P I") to the
+// sender's own risk prompt. Reports whether the message was consumed.
+func (epicBot *Bot) handleRiskReply(ctx context.Context, msg *models.Message) bool {
+	if msg.ReplyToMessage == nil || msg.From == nil {
+		return false
+	}
+	sk := sessionKey{ChatID: msg.Chat.ID, ThreadID: msg.MessageThreadID, Username: msg.From.Username}
+	sess, ok := epicBot.sessions.get(sk)
+	if !ok || sess.Step != StepScoreRiskReply || sess.MessageID != msg.ReplyToMessage.ID {
+		return false
 	}
+
+	prob, impact, err := parseRiskReply(msg.Text)
+	if err != nil {
+		epicBot.sendReply(ctx, msg,
+			"❌ Ответьте двумя числами от 1 до 4: вероятность и влияние, например «3 2».")
+		return true
+	}
+	riskID, err := uuid.Parse(sess.Data["riskID"])
+	if err != nil {
+		epicBot.sessions.clear(sk)
+		epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID риска.")
+		return true
+	}
+
+	epicBot.sessions.clear(sk)
+	epicBot.submitRiskScore(ctx, msg, sess.MessageID, msg.From.Username, riskID, prob, impact, false)
+	return true
+}
+
+// parseRiskReply parses "P I" — probability and impact, each 1–4,
+// separated by whitespace, a comma or a slash.
+func parseRiskReply(text string) (int, int, error) {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '/' || r == '\t' || r == '\n'
+	})
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("expected two numbers, got %d", len(fields))
+	}
+	prob, err := strconv.Atoi(fields[0])
+	if err != nil || prob < 1 || prob > 4 {
+		return 0, 0, fmt.Errorf("invalid probability %q", fields[0])
+	}
+	impact, err := strconv.Atoi(fields[1])
+	if err != nil || impact < 1 || impact > 4 {
+		return 0, 0, fmt.Errorf("invalid impact %q", fields[1])
+	}
+	return prob, impact, nil
 }
 
 // handleRiskProbability processes risk probability selection.
@@ -541,19 +725,83 @@ func (epicBot *Bot) handleRiskImpact(ctx context.Context, msg *models.Message, u
 		return
 	}
 
+	epicBot.sessions.clear(sessionKey{ChatID: msg.Chat.ID, ThreadID: msg.MessageThreadID, Username: username})
+	epicBot.submitRiskScore(ctx, msg, msg.ID, username, riskID, prob, impact, false)
+}
+
+// handleRiskRescore replaces a risk score after the user confirmed it.
+// Format: rescore_risk_<riskID>_<probability>_<impact>
+func (epicBot *Bot) handleRiskRescore(ctx context.Context, msg *models.Message, username, data string) {
+	parts := strings.Split(strings.TrimPrefix(data, "rescore_risk_"), "_")
+	if len(parts) != 3 {
+		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+		return
+	}
+	riskID, err := uuid.Parse(parts[0])
+	if err != nil {
+		epicBot.sendReply(ctx, msg, "❌ Ошибка парсинга ID риска.")
+		return
+	}
+	prob, err := strconv.Atoi(parts[1])
+	if err != nil || prob < 1 || prob > 4 {
+		epicBot.sendReply(ctx, msg, "❌ Вероятность должна быть от 1 до 4.")
+		return
+	}
+	impact, err := strconv.Atoi(parts[2])
+	if err != nil || impact < 1 || impact > 4 {
+		epicBot.sendReply(ctx, msg, "❌ Влияние должно быть от 1 до 4.")
+		return
+	}
+	epicBot.submitRiskScore(ctx, msg, msg.ID, username, riskID, prob, impact, true)
+}
+
+// submitRiskScore saves a user's risk assessment and reports the result by
+// editing the risk prompt identified by promptID. Unless confirmed,
+// replacing a different earlier assessment asks the user first.
+func (epicBot *Bot) submitRiskScore(
+	ctx context.Context,
+	msg *models.Message,
+	promptID int,
+	username string,
+	riskID uuid.UUID,
+	prob, impact int,
+	confirmed bool,
+) {
+	op := "bot.submitRiskScore()"
+	log := epicBot.log.With(slog.String("op", op))
+
 	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
 	if err != nil {
 		log.Error("user not found", slog.String("username", username))
-		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Пользователь не найден."); botErr != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found")); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
 	}
 
-	if err := epicBot.repo.CreateRiskScore(ctx, riskID, user.ID, prob, impact); err != nil {
+	risk, err := epicBot.repo.GetRiskByID(ctx, riskID)
+	if err != nil {
+		log.Error("risk not found", slog.String("riskID", riskID.String()), sl.Err(err))
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "risk.not_found")); botErr != nil {
+			log.Error("failed to send reply", sl.Err(botErr))
+		}
+		return
+	}
+	if err := epicBot.checkEpicTeamMember(ctx, user.ID, risk.EpicID); err != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.teamMemberErrorText(ctx, msg, err)); botErr != nil {
+			log.Error("failed to send reply", sl.Err(botErr))
+		}
+		return
+	}
+
+	if !confirmed && epicBot.confirmRiskRescore(ctx, msg, promptID, user.ID, risk, prob, impact) {
+		return
+	}
+
+	inserted, err := epicBot.repo.CreateRiskScore(ctx, riskID, user.ID, prob, impact)
+	if err != nil {
 		log.Error("failed to create risk score", sl.Err(err))
-		if _, botErr := epicBot.sendReply(ctx, msg,
-			fmt.Sprintf("❌ Ошибка сохранения оценки риска: %v", err)); botErr != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Ошибка сохранения оценки риска."); botErr != nil {
 			log.Error("failed to send reply", sl.Err(botErr))
 		}
 		return
@@ -562,16 +810,198 @@ func (epicBot *Bot) handleRiskImpact(ctx context.Context, msg *models.Message, u
 	riskScore := prob * impact
 	coeff := scoring.RiskCoefficient(float64(riskScore))
 
+	if err := epicBot.editReply(ctx, msg.Chat.ID, promptID,
+		fmt.Sprintf("✅ Оценка риска %s!\nВероятность: %d, Влияние: %d\nРезультат: %d (коэфф: %.2f)",
+			savedVerb(inserted), prob, impact, riskScore, coeff)); err != nil {
+		log.Error("failed to edit message", sl.Err(err))
+	}
+
+	if err := epicBot.scoring.TryCompleteRiskScoring(ctx, riskID); err != nil {
+		log.Error("failed to try complete risk scoring",
+			slog.String("riskID", riskID.String()), sl.Err(err))
+		epicBot.notifyCompletionError(ctx, msg, err)
+	}
+	// Offer the risks still awaiting this user, as after an effort score.
+	epicBot.showEpicRisks(ctx, msg, username, risk.EpicID)
+}
+
+// handleRiskSkip records that the user cannot assess a risk. The skip
+// counts towards the completion quorum but not the weighted score.
+func (epicBot *Bot) handleRiskSkip(ctx context.Context, msg *models.Message, username string, riskID uuid.UUID) {
+	op := "bot.handleRiskSkip()"
+	log := epicBot.log.With(slog.String("op", op))
+
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
+	if err != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found")); botErr != nil {
+			log.Error("failed to send reply", sl.Err(botErr))
+		}
+		return
+	}
+
+	risk, err := epicBot.repo.GetRiskByID(ctx, riskID)
+	if err != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "risk.not_found")); botErr != nil {
+			log.Error("failed to send reply", sl.Err(botErr))
+		}
+		return
+	}
+	if err := epicBot.checkEpicTeamMember(ctx, user.ID, risk.EpicID); err != nil {
+		if _, botErr := epicBot.sendReply(ctx, msg, epicBot.teamMemberErrorText(ctx, msg, err)); botErr != nil {
+			log.Error("failed to send reply", sl.Err(botErr))
+		}
+		return
+	}
+
+	epicBot.sessions.clear(sessionKey{ChatID: msg.Chat.ID, ThreadID: msg.MessageThreadID, Username: username})
+
+	if _, err := epicBot.repo.SkipRisk(ctx, riskID, user.ID); err != nil {
+		log.Error("failed to skip risk", sl.Err(err))
+		if _, botErr := epicBot.sendReply(ctx, msg, "❌ Ошибка сохранения пропуска риска."); botErr != nil {
+			log.Error("failed to send reply", sl.Err(botErr))
+		}
+		return
+	}
+
 	if err := epicBot.editReply(ctx, msg.Chat.ID, msg.ID,
-		fmt.Sprintf("✅ Оценка риска сохранена!\nВероятность: %d, Влияние: %d\nРезультат: %d (коэфф: %.2f)",
-			prob, impact, riskScore, coeff)); err != nil {
+		fmt.Sprintf("⏭ Риск «%s» пропущен: он не учитывается в вашей оценке.", risk.Description)); err != nil {
 		log.Error("failed to edit message", sl.Err(err))
 	}
 
 	if err := epicBot.scoring.TryCompleteRiskScoring(ctx, riskID); err != nil {
 		log.Error("failed to try complete risk scoring",
 			slog.String("riskID", riskID.String()), sl.Err(err))
+		epicBot.notifyCompletionError(ctx, msg, err)
+	}
+	// Offer the risks still awaiting this user, as after an effort score.
+	epicBot.showEpicRisks(ctx, msg, username, risk.EpicID)
+}
+
+// notifyCompletionError tells the chat when scoring cannot be completed
+// for a reason an administrator has to fix. Other errors are only logged.
+func (epicBot *Bot) notifyCompletionError(ctx context.Context, msg *models.Message, err error) {
+	var text string
+	switch {
+	case errors.Is(err, scoring.ErrEmptyTeam):
+		text = "⚠️ В команде эпика нет участников, которые могут его оценить, — оценка не может быть завершена. " +
+			"Обратитесь к администратору."
+	case errors.Is(err, scoring.ErrZeroWeight):
+		text = "⚠️ У всех оценивших нулевой вес — оценка не может быть завершена. " +
+			"Обратитесь к администратору, чтобы изменить вес через /changerate."
+	default:
+		return
+	}
+	if _, botErr := epicBot.sendReply(ctx, msg, text); botErr != nil {
+		epicBot.log.Error("failed to send reply", sl.Err(botErr))
+	}
+}
+
+// errNotInTeam is returned when a user scores an epic of another team.
+var errNotInTeam = errors.New("user is not a member of the epic's team")
+
+// checkEpicTeamMember verifies that the user belongs to the epic's team,
+// so outsiders cannot skew the team-size based completion quorum.
+func (epicBot *Bot) checkEpicTeamMember(ctx context.Context, userID, epicID uuid.UUID) error {
+	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
+	if err != nil {
+		return err
+	}
+	ok, err := epicBot.repo.IsUserInTeam(ctx, userID, epic.TeamID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errNotInTeam
+	}
+	return nil
+}
+
+// teamMemberErrorText turns a checkEpicTeamMember error into a reply.
+// Unexpected errors are logged rather than shown.
+func (epicBot *Bot) teamMemberErrorText(ctx context.Context, msg *models.Message, err error) string {
+	if errors.Is(err, errNotInTeam) {
+		return epicBot.t(ctx, msg, "access.not_in_epic_team")
+	}
+	epicBot.log.Error("failed to check epic team membership", sl.Err(err))
+	return epicBot.t(ctx, msg, "error.team_check")
+}
+
+// confirmEpicRescore asks the user before replacing a different earlier
+// score for the epic. It reports whether the submission must stop here,
+// either because a confirmation was shown or because the lookup failed.
+func (epicBot *Bot) confirmEpicRescore(
+	ctx context.Context,
+	msg *models.Message,
+	msgID int,
+	userID, epicID uuid.UUID,
+	score int,
+) bool {
+	prev, err := epicBot.repo.GetUserEpicScore(ctx, epicID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		epicBot.log.Error("failed to get previous epic score",
+			slog.String("epicID", epicID.String()), sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка сохранения оценки.")
+		return true
+	}
+	if prev.Score == score {
+		return false
+	}
+	epicNum := epicID.String()
+	if epic, err := epicBot.repo.GetEpicByID(ctx, epicID); err == nil {
+		epicNum = epic.Number
+	}
+	kb := inlineKeyboard(inlineRow(
+		inlineBtn("✅ Заменить", fmt.Sprintf("rescore_epic_%s_%d", epicID.String(), score)),
+		inlineBtn("❌ Оставить", "rescore_keep"),
+	))
+	epicBot.editOrSendWithKeyboard(ctx, msg, msgID,
+		fmt.Sprintf("⚠️ Вы уже оценили эпик #%s на %d.\nЗаменить на %d?", epicNum, prev.Score, score), kb)
+	return true
+}
+
+// confirmRiskRescore asks the user before replacing a different earlier
+// assessment of the risk. Like confirmEpicRescore, it reports whether the
+// submission must stop here.
+func (epicBot *Bot) confirmRiskRescore(
+	ctx context.Context,
+	msg *models.Message,
+	msgID int,
+	userID uuid.UUID,
+	risk *domain.Risk,
+	prob, impact int,
+) bool {
+	prev, err := epicBot.repo.GetUserRiskScore(ctx, risk.ID, userID)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return false
+	}
+	if err != nil {
+		epicBot.log.Error("failed to get previous risk score",
+			slog.String("riskID", risk.ID.String()), sl.Err(err))
+		epicBot.sendReply(ctx, msg, "❌ Ошибка сохранения оценки риска.")
+		return true
+	}
+	if prev.Probability == prob && prev.Impact == impact {
+		return false
+	}
+	kb := inlineKeyboard(inlineRow(
+		inlineBtn("✅ Заменить", fmt.Sprintf("rescore_risk_%s_%d_%d", risk.ID.String(), prob, impact)),
+		inlineBtn("❌ Оставить", "rescore_keep"),
+	))
+	epicBot.editOrSendWithKeyboard(ctx, msg, msgID,
+		fmt.Sprintf("⚠️ Вы уже оценили риск «%s»: %d×%d.\nЗаменить на %d×%d?",
+			risk.Description, prev.Probability, prev.Impact, prob, impact), kb)
+	return true
+}
+
+// savedVerb tells a first-time vote from a changed one in score replies.
+func savedVerb(inserted bool) string {
+	if inserted {
+		return "сохранена"
 	}
+	return "изменена"
 }
 
 // sendCallbackAlert sends a popup alert to a callback query.
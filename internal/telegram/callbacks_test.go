@@ -0,0 +1,127 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"EpicScoreBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestParseRiskReply(t *testing.T) {
+	tests := []struct {
+		text       string
+		wantProb   int
+		wantImpact int
+		wantErr    bool
+	}{
+		{"2 3", 2, 3, false},
+		{"1,4", 1, 4, false},
+		{"4/1", 4, 1, false},
+		{"  3\t2\n", 3, 2, false},
+		{"2, 3", 2, 3, false},
+		{"", 0, 0, true},
+		{"2", 0, 0, true},
+		{"1 2 3", 0, 0, true},
+		{"0 2", 0, 0, true},
+		{"2 5", 0, 0, true},
+		{"a 2", 0, 0, true},
+		{"2 b", 0, 0, true},
+		{"2.5 3", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			prob, impact, err := parseRiskReply(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRiskReply(%q) error = %v, wantErr %v", tt.text, err, tt.wantErr)
+			}
+			if prob != tt.wantProb || impact != tt.wantImpact {
+				t.Errorf("parseRiskReply(%q) = %d, %d, want %d, %d", tt.text, prob, impact, tt.wantProb, tt.wantImpact)
+			}
+		})
+	}
+}
+
+func TestConfirmEpicRescore(t *testing.T) {
+	epicID, userID := uuid.New(), uuid.New()
+	tests := []struct {
+		name       string
+		prev       *domain.EpicScore
+		wantPrompt bool
+	}{
+		{"first vote", nil, false},
+		{"same score again", &domain.EpicScore{Score: 8}, false},
+		{"different score", &domain.EpicScore{Score: 5}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRepo{epic: &domain.Epic{ID: epicID, Number: "42"}, epicScore: tt.prev}
+			epicBot, api := newTestBot(t, nil, repo)
+
+			got := epicBot.confirmEpicRescore(context.Background(), testMessage(""), 7, userID, epicID, 8)
+			if got != tt.wantPrompt {
+				t.Fatalf("confirmEpicRescore() = %v, want %v", got, tt.wantPrompt)
+			}
+			calls := api.Calls()
+			if !tt.wantPrompt {
+				if len(calls) != 0 {
+					t.Errorf("calls = %v, want none", calls)
+				}
+				return
+			}
+			if len(calls) != 1 || calls[0].Method != "editMessageText" {
+				t.Fatalf("calls = %v, want one editMessageText", calls)
+			}
+			if text := calls[0].Params["text"]; !strings.Contains(text, "#42 на 5") {
+				t.Errorf("prompt = %q, want the previous score", text)
+			}
+			wantData := fmt.Sprintf("rescore_epic_%s_8", epicID)
+			if kb := calls[0].Params["reply_markup"]; !strings.Contains(kb, wantData) {
+				t.Errorf("keyboard = %s, want a %q button", kb, wantData)
+			}
+		})
+	}
+}
+
+func TestConfirmRiskRescore(t *testing.T) {
+	risk := &domain.Risk{ID: uuid.New(), Description: "API"}
+	tests := []struct {
+		name       string
+		prev       *domain.RiskScore
+		wantPrompt bool
+	}{
+		{"first vote", nil, false},
+		{"same assessment again", &domain.RiskScore{Probability: 2, Impact: 3}, false},
+		{"different assessment", &domain.RiskScore{Probability: 4, Impact: 3}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epicBot, api := newTestBot(t, nil, &fakeRepo{riskScore: tt.prev})
+
+			got := epicBot.confirmRiskRescore(context.Background(), testMessage(""), 7, uuid.New(), risk, 2, 3)
+			if got != tt.wantPrompt {
+				t.Fatalf("confirmRiskRescore() = %v, want %v", got, tt.wantPrompt)
+			}
+			calls := api.Calls()
+			if !tt.wantPrompt {
+				if len(calls) != 0 {
+					t.Errorf("calls = %v, want none", calls)
+				}
+				return
+			}
+			if len(calls) != 1 || calls[0].Method != "editMessageText" {
+				t.Fatalf("calls = %v, want one editMessageText", calls)
+			}
+			if text := calls[0].Params["text"]; !strings.Contains(text, "«API»: 4×3") {
+				t.Errorf("prompt = %q, want the previous assessment", text)
+			}
+			wantData := fmt.Sprintf("rescore_risk_%s_2_3", risk.ID)
+			if kb := calls[0].Params["reply_markup"]; !strings.Contains(kb, wantData) {
+				t.Errorf("keyboard = %s, want a %q button", kb, wantData)
+			}
+		})
+	}
+}
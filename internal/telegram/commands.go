@@ -0,0 +1,110 @@
+package telegram
+
+import (
+	"maps"
+	"slices"
+)
+
+// ─── Command names, aliases and suggestions ───────────────────────────────
+
+// knownCommands lists every command handled by commandHandler. Keep it in
+// sync with the dispatcher switch; unknown commands are matched against it.
+var knownCommands = []string{
+	"start", "help", "setlang", "settings", "cancel", "score", "resetmyscore", "feedback", "epicstatus", "history", "findepic",
+	"watchepic", "unwatchepic", "whoami",
+	"addteam", "adduser", "assignrole", "addepic", "duplicateepic", "importepics", "addrisk",
+	"setepicroles", "startscore", "bulkstartscore", "closescore", "results", "report", "exportpdf", "list",
+	"listroles", "recalculate", "teamstats", "activescoring", "scoringtime", "settimezone", "agreement", "viewas", "ping",
+	"assignteam", "renameuser", "changerate", "setteamweights", "unassignrole", "changerole",
+	"removefromteam", "renameteam", "mergeteams", "archiveteam", "unarchiveteam", "deleteteam", "deleteepic",
+	"deleterisk", "deleteuser", "createrole", "deleterole", "addadmin", "removeadmin",
+	"auditlog", "undo", "stats", "resendkeyboard",
+}
+
+// commandAliases maps common alternative names to the canonical command.
+var commandAliases = map[string]string{
+	"addrole":          "createrole",
+	"newrole":          "createrole",
+	"removerole":       "deleterole",
+	"roles":            "listroles",
+	"newteam":          "addteam",
+	"createteam":       "addteam",
+	"removeteam":       "deleteteam",
+	"newuser":          "adduser",
+	"createuser":       "adduser",
+	"removeuser":       "deleteuser",
+	"newepic":          "addepic",
+	"createepic":       "addepic",
+	"removeepic":       "deleteepic",
+	"copyepic":         "duplicateepic",
+	"cloneepic":        "duplicateepic",
+	"newrisk":          "addrisk",
+	"removerisk":       "deleterisk",
+	"status":           "epicstatus",
+	"me":               "whoami",
+	"members":          "list",
+	"changeweight":     "changerate",
+	"setweight":        "setteamweights",
+	"teamweights":      "setteamweights",
+	"stop":             "cancel",
+	"menu":             "resendkeyboard",
+	"pdf":              "exportpdf",
+	"watch":            "watchepic",
+	"unwatch":          "unwatchepic",
+	"notifyonfinalize": "watchepic",
+}
+
+// resolveCommand returns the canonical name for an alias, or the name
+// itself.
+func resolveCommand(name string) string {
+	if canonical, ok := commandAliases[name]; ok {
+		return canonical
+	}
+	return name
+}
+
+// maxSuggestDistance is the largest edit distance at which an unknown
+// command is still considered a typo of a known one.
+const maxSuggestDistance = 2
+
+// suggestCommand returns the canonical command closest to name, comparing
+// against known commands and then aliases. A match must be within
+// maxSuggestDistance edits and change less than half of name, so short
+// words are not matched to arbitrary commands. Ties go to the first
+// candidate.
+func suggestCommand(name string) (string, bool) {
+	candidates := append(slices.Clone(knownCommands), slices.Sorted(maps.Keys(commandAliases))...)
+	best, bestDist := "", maxSuggestDistance+1
+	for _, c := range candidates {
+		d := levenshtein(name, c)
+		if d < bestDist && 2*d < len([]rune(name)) {
+			best, bestDist = c, d
+		}
+	}
+	if best == "" {
+		return "", false
+	}
+	return resolveCommand(best), true
+}
+
+// levenshtein returns the edit distance between a and b in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
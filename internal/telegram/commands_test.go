@@ -0,0 +1,65 @@
+package telegram
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSuggestCommand(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{"resluts", "results", true},
+		{"strat", "start", true},
+		{"hlp", "help", true},
+		{"newrol", "createrole", true},
+		{"wach", "watchepic", true},
+		{"hepl", "", false},
+		{"xyz", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := suggestCommand(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("suggestCommand(%q) = %q, %v, want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"", "abc", 3},
+		{"start", "start", 0},
+		{"strat", "start", 2},
+		{"kitten", "sitting", 3},
+		{"эпик", "эпос", 2},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCommandAliasesResolveToKnownCommands(t *testing.T) {
+	for alias, canonical := range commandAliases {
+		if !slices.Contains(knownCommands, canonical) {
+			t.Errorf("alias %q resolves to unknown command %q", alias, canonical)
+		}
+		if slices.Contains(knownCommands, alias) {
+			t.Errorf("alias %q shadows a command", alias)
+		}
+	}
+	if got := resolveCommand("results"); got != "results" {
+		t.Errorf("resolveCommand(%q) = %q, want the name itself", "results", got)
+	}
+}
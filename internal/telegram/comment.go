@@ -0,0 +1,168 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /feedback — comments on epics being scored ───────────────────────────
+
+// handleFeedback lists the epics being scored in the caller's teams, so a
+// comment such as an assumption behind the estimate can be left on one.
+func (epicBot *Bot) handleFeedback(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleFeedback"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	username := msg.From.Username
+	if username == "" {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "user.no_username"))
+		return err
+	}
+
+	teams, err := epicBot.repo.GetTeamsByUserTelegramID(ctx, username)
+	if err != nil || len(teams) == 0 {
+		if err != nil {
+			log.Error("error getting teams by user telegram id", sl.Err(err))
+		}
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "comment.no_teams"))
+		return retErr
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	for _, team := range teams {
+		epics, err := epicBot.repo.GetEpicsByTeamIDAndStatus(ctx, team.ID, domain.StatusScoring)
+		if err != nil {
+			log.Error("error getting scoring epics", sl.Err(err))
+			continue
+		}
+		for _, epic := range epics {
+			rows = append(rows, inlineRow(epicBot.pickerBtn(
+				fmt.Sprintf("💬 #%s %s", epic.Number, epic.Name), "comment_"+epic.ID.String())))
+		}
+	}
+	if len(rows) == 0 {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "comment.no_epics"))
+		return err
+	}
+	_, err = epicBot.sendWithKeyboard(ctx, msg, epicBot.t(ctx, msg, "comment.pick"), inlineKeyboard(rows...))
+	return err
+}
+
+// startEpicComment asks for the text of a comment on the picked epic.
+// Only members of the epic's team may comment, and only while it is being
+// scored. data = "comment_<epicID>".
+func (epicBot *Bot) startEpicComment(ctx context.Context, msg *models.Message, username string, epicID uuid.UUID) {
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
+	if err != nil {
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found"))
+		return
+	}
+	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
+	if err != nil {
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
+		return
+	}
+	if epic.Status != domain.StatusScoring {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "comment.not_scoring", epic.Number))
+		return
+	}
+	if err := epicBot.checkEpicTeamMember(ctx, user.ID, epicID); err != nil {
+		epicBot.sendReply(ctx, msg, epicBot.teamMemberErrorText(ctx, msg, err))
+		return
+	}
+
+	sent, err := epicBot.sendReply(ctx, msg,
+		epicBot.promptText(ctx, msg, "comment.prompt", epic.Number, epic.Name))
+	if err != nil {
+		return
+	}
+	sess := &Session{
+		Step:     StepEpicComment,
+		ThreadID: msg.MessageThreadID,
+		Username: username,
+		Data:     map[string]string{"epicID": epicID.String()},
+	}
+	if sent != nil {
+		sess.MessageID = sent.ID
+	}
+	epicBot.sessions.set(sessionKey{ChatID: msg.Chat.ID, ThreadID: msg.MessageThreadID, Username: username}, sess)
+}
+
+// saveEpicComment stores the comment text entered in the session.
+func (epicBot *Bot) saveEpicComment(ctx context.Context, msg *models.Message, sk sessionKey, sess *Session, text string) {
+	op := "bot.saveEpicComment"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	msgID := sess.MessageID
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		epicBot.editOrSend(ctx, msg, msgID, epicBot.t(ctx, msg, "comment.empty"))
+		return
+	}
+	if limit := epicBot.cfg.Limits.Description; config.ExceedsLimit(text, limit) {
+		epicBot.editOrSend(ctx, msg, msgID,
+			epicBot.t(ctx, msg, "comment.too_long", limit, utf8.RuneCountInString(text)))
+		return
+	}
+	epicBot.sessions.clear(sk)
+
+	epicID, err := uuid.Parse(sess.Data["epicID"])
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.t(ctx, msg, "comment.bad_epic_id"))
+		return
+	}
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, msg.From.Username)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "user.not_found"))
+		return
+	}
+	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
+		return
+	}
+
+	if _, err := epicBot.repo.CreateEpicComment(ctx, epicID, user.ID, text); err != nil {
+		if errors.Is(err, repositories.ErrInvalidInput) {
+			epicBot.deleteAndSend(ctx, msg, msgID, epicBot.t(ctx, msg, "comment.invalid"))
+			return
+		}
+		log.Error("error creating epic comment", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.t(ctx, msg, "comment.error"))
+		return
+	}
+	epicBot.deleteAndSend(ctx, msg, msgID, epicBot.t(ctx, msg, "comment.saved", epic.Number))
+}
+
+// writeEpicComments adds the comments on an epic to a Markdown message.
+// Nothing is written when there are none.
+func (epicBot *Bot) writeEpicComments(ctx context.Context, msg *models.Message, sb *strings.Builder, epicID uuid.UUID) error {
+	comments, err := epicBot.repo.GetCommentsByEpicID(ctx, epicID)
+	if err != nil || len(comments) == 0 {
+		return err
+	}
+	sb.WriteString(epicBot.t(ctx, msg, "comment.heading") + "\n")
+	for _, c := range comments {
+		fmt.Fprintf(sb, "  • %s %s: %s\n",
+			escapeMarkdownV2(c.FirstName), escapeMarkdownV2(c.LastName), escapeMarkdownV2(c.Text))
+	}
+	sb.WriteString("\n")
+	return nil
+}
@@ -0,0 +1,81 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── Deep links: https://t.me/<bot>?start=score_<epicID> ──────────────────
+
+// scorePayloadPrefix starts the /start payload that opens scoring of an
+// epic.
+const scorePayloadPrefix = "score_"
+
+// scoreDeepLink returns a link that opens a private chat with the bot and
+// starts scoring the epic, or "" while the bot username is unknown.
+func (epicBot *Bot) scoreDeepLink(epicID uuid.UUID) string {
+	if epicBot.botUsername == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://t.me/%s?start=%s%s", epicBot.botUsername, scorePayloadPrefix, epicID)
+}
+
+// parseScorePayload extracts the epic ID from a "score_<epicID>" /start
+// payload.
+func parseScorePayload(payload string) (uuid.UUID, bool) {
+	raw, ok := strings.CutPrefix(strings.TrimSpace(payload), scorePayloadPrefix)
+	if !ok {
+		return uuid.Nil, false
+	}
+	epicID, err := uuid.Parse(raw)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return epicID, true
+}
+
+// startScoreDeepLink opens scoring of an epic reached through a deep link.
+// The epic must be in scoring and the sender must be on its team.
+func (epicBot *Bot) startScoreDeepLink(ctx context.Context, msg *models.Message, epicID uuid.UUID) {
+	op := "bot.startScoreDeepLink"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("epic_id", epicID.String()),
+	)
+
+	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
+	if err != nil {
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
+		return
+	}
+	if epic.Status != domain.StatusScoring {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "epic.not_scoring", epic.Number))
+		return
+	}
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, msg.From.Username)
+	if err != nil {
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "user.not_found"))
+		return
+	}
+	inTeam, err := epicBot.repo.IsUserInTeam(ctx, user.ID, epic.TeamID)
+	if err != nil {
+		log.Error("error checking team membership", sl.Err(err))
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.team_check"))
+		return
+	}
+	if !inTeam {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "epic.not_in_team", epic.Number))
+		return
+	}
+
+	epicBot.showEpicScoreOptions(ctx, msg, msg.From.Username, epicID)
+}
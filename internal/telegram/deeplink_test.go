@@ -0,0 +1,56 @@
+package telegram
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseScorePayload(t *testing.T) {
+	epicID := uuid.MustParse("6f1c2b8e-3d4a-4c5b-9e7f-0a1b2c3d4e5f")
+	tests := []struct {
+		name    string
+		payload string
+		want    uuid.UUID
+		wantOK  bool
+	}{
+		{"epic", "score_" + epicID.String(), epicID, true},
+		{"surrounding spaces", " score_" + epicID.String() + " ", epicID, true},
+		{"no prefix", epicID.String(), uuid.Nil, false},
+		{"other payload", "help", uuid.Nil, false},
+		{"bad ID", "score_123", uuid.Nil, false},
+		{"empty", "", uuid.Nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseScorePayload(tt.payload)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("parseScorePayload(%q) = %v, %v, want %v, %v", tt.payload, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// startPayload matches what Telegram accepts as a /start parameter.
+var startPayload = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+
+func TestScoreDeepLink(t *testing.T) {
+	epicID := uuid.New()
+	if got := (&Bot{}).scoreDeepLink(epicID); got != "" {
+		t.Errorf("scoreDeepLink() without a username = %q, want empty", got)
+	}
+
+	link := (&Bot{botUsername: "epic_score_bot"}).scoreDeepLink(epicID)
+	payload, ok := strings.CutPrefix(link, "https://t.me/epic_score_bot?start=")
+	if !ok {
+		t.Fatalf("scoreDeepLink() = %q, want a t.me link to the bot", link)
+	}
+	if !startPayload.MatchString(payload) {
+		t.Errorf("payload %q is not a valid /start parameter", payload)
+	}
+	if got, ok := parseScorePayload(payload); !ok || got != epicID {
+		t.Errorf("parseScorePayload(%q) = %v, %v, want %v", payload, got, ok, epicID)
+	}
+}
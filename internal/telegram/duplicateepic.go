@@ -0,0 +1,162 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /duplicateepic — epic picker, number, team picker ──────────────────
+
+// handleDuplicateEpic shows an epic picker for copying an epic with its
+// risks into a team under a new number.
+func (epicBot *Bot) handleDuplicateEpic(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	return epicBot.showEpicPickerInitial(ctx, msg, "duplicateepic", "")
+}
+
+// startDuplicateEpic asks for the number of the copy of the picked epic.
+func (epicBot *Bot) startDuplicateEpic(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	epicID uuid.UUID,
+	epicNumber string,
+	msgID int,
+) {
+	epicBot.sessions.set(sessionKeyFromCallback(msg, callback), &Session{
+		Step:      StepDuplicateEpicNumber,
+		ThreadID:  msg.MessageThreadID,
+		Username:  callback.From.Username,
+		MessageID: msgID,
+		Data:      map[string]string{"srcEpicID": epicID.String()},
+	})
+	epicBot.editOrSend(ctx, msg, msgID,
+		fmt.Sprintf("📄 Копия эпика #%s.\n📝 Введите номер нового эпика:"+cancelHint, epicNumber))
+}
+
+// askDuplicateEpicTeam checks the entered number and shows a team picker
+// for the copy. Whether the number is free depends on the team, so that is
+// checked when the copy is made.
+func (epicBot *Bot) askDuplicateEpicTeam(
+	ctx context.Context,
+	msg *models.Message,
+	sk sessionKey,
+	sess *Session,
+	number string,
+) {
+	op := "bot.askDuplicateEpicTeam"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	msgID := sess.MessageID
+
+	if problem := epicBot.epicNumberProblem(number); problem != "" {
+		epicBot.editOrSend(ctx, msg, msgID, problem+" Введите номер нового эпика:")
+		return
+	}
+	teams, err := epicBot.repo.GetAllTeams(ctx)
+	if err != nil || len(teams) == 0 {
+		if err != nil {
+			log.Error("error getting all teams", sl.Err(err))
+		}
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Команды не найдены.")
+		return
+	}
+
+	// The team picker is answered by a button, not by text.
+	if _, ok := epicBot.sessions.update(sk, func(s *Session) {
+		s.Step = ""
+		s.Data["number"] = number
+	}); !ok {
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Сессия истекла. Повторите команду.")
+		return
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	for _, t := range teams {
+		rows = append(rows, inlineRow(epicBot.pickerBtn(
+			"👥 "+t.Name, "adm_team_duplicateepic_"+t.ID.String())))
+	}
+	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
+	epicBot.editOrSendWithKeyboard(ctx, msg, msgID,
+		fmt.Sprintf("👥 В какую команду добавить эпик #%s?", number), inlineKeyboard(rows...))
+}
+
+// duplicateEpic copies the epic collected in the session into the picked
+// team.
+func (epicBot *Bot) duplicateEpic(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	teamID uuid.UUID,
+) {
+	op := "bot.duplicateEpic"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return
+	}
+
+	sk := sessionKeyFromCallback(msg, callback)
+	sess, ok := epicBot.sessions.get(sk)
+	if !ok || sess.Data["number"] == "" {
+		epicBot.sendReply(ctx, msg, "❌ Сессия истекла. Повторите команду.")
+		return
+	}
+	epicBot.sessions.clear(sk)
+	msgID := sess.MessageID
+
+	srcID, err := uuid.Parse(sess.Data["srcEpicID"])
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка: неверный ID эпика.")
+		return
+	}
+	src, err := epicBot.repo.GetEpicByID(ctx, srcID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
+		return
+	}
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+
+	epic, risks, err := epicBot.repo.CloneEpic(ctx, srcID, sess.Data["number"], teamID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrAlreadyExists):
+			epicBot.deleteAndSend(ctx, msg, msgID,
+				fmt.Sprintf("❌ В команде «%s» уже есть эпик #%s.", team.Name, sess.Data["number"]))
+		case errors.Is(err, repositories.ErrNotFound):
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Эпик не найден.")
+		default:
+			log.Error("error cloning epic", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка копирования эпика.")
+		}
+		return
+	}
+
+	epicBot.recordAudit(ctx, callback.From.Username, audit.ActionEpicCreated,
+		fmt.Sprintf("#%s %s (копия #%s)", epic.Number, epic.Name, src.Number))
+	epicBot.deleteAndSend(ctx, msg, msgID,
+		fmt.Sprintf("✅ Эпик #%s «%s» скопирован в команду «%s» как #%s.\nРисков скопировано: %d.",
+			src.Number, src.Name, team.Name, epic.Number, risks))
+}
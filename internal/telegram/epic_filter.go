@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// epicFilterDateLayout is the date format accepted in picker arguments.
+const epicFilterDateLayout = "2006-01-02"
+
+// epicFilter narrows an epic list by team and creation date.
+type epicFilter struct {
+	teamID *uuid.UUID
+	since  time.Time
+}
+
+// empty reports whether the filter does not restrict anything.
+func (f epicFilter) empty() bool {
+	return f.teamID == nil && f.since.IsZero()
+}
+
+// apply returns the epics matching the filter.
+func (f epicFilter) apply(epics []domain.Epic) []domain.Epic {
+	if f.empty() {
+		return epics
+	}
+	var out []domain.Epic
+	for _, e := range epics {
+		if f.teamID != nil && e.TeamID != *f.teamID {
+			continue
+		}
+		if !f.since.IsZero() && e.CreatedAt.Before(f.since) {
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// parseEpicFilter parses the command arguments of a picker: an optional
+// date (YYYY-MM-DD, epics created on or after it) and an optional team
+// name. Durations are left to the command itself (see /startscore) and
+// skipped here. The returned error text is meant to be shown to the user.
+func (epicBot *Bot) parseEpicFilter(ctx context.Context, msg *models.Message) (epicFilter, error) {
+	var f epicFilter
+	var teamWords []string
+	for _, word := range strings.Fields(commandArguments(msg)) {
+		if t, err := time.ParseInLocation(epicFilterDateLayout, word, time.Local); err == nil {
+			f.since = t
+			continue
+		}
+		if _, ok := parseScoringDuration(word); ok {
+			continue
+		}
+		teamWords = append(teamWords, word)
+	}
+	if len(teamWords) > 0 {
+		name := strings.Join(teamWords, " ")
+		team, err := epicBot.repo.GetTeamByName(ctx, name)
+		if err != nil {
+			return f, errors.New(epicBot.lookupErrorText(ctx, msg, err, "team.name_not_found", name))
+		}
+		f.teamID = &team.ID
+	}
+	return f, nil
+}
+
+// parseScoringDuration parses a scoring duration such as 90m, 24h or 3d.
+// Only positive durations are accepted.
+func parseScoringDuration(s string) (time.Duration, bool) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, false
+		}
+		return time.Duration(n) * 24 * time.Hour, true
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// epicLimitGuidance explains how to narrow a picker that hit the epic cap.
+func epicLimitGuidance(command string, found, limit int) string {
+	return fmt.Sprintf("⚠️ Найдено эпиков: %d, за раз можно показать не больше %d.\n"+
+		"Уточните выборку:\n"+
+		"/%s <команда> — эпики одной команды\n"+
+		"/%s <ГГГГ-ММ-ДД> — эпики, созданные с этой даты\n"+
+		"Фильтры можно совмещать.",
+		found, limit, command, command)
+}
@@ -0,0 +1,74 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/models/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestEpicPickerCap(t *testing.T) {
+	platform := &domain.Team{ID: uuid.New(), Name: "Platform"}
+	var epics []domain.Epic
+	for i := range 5 {
+		team := uuid.New()
+		if i < 2 {
+			team = platform.ID
+		}
+		epics = append(epics, domain.Epic{
+			ID: uuid.New(), Number: fmt.Sprintf("EP-%d", i), Name: "Epic", TeamID: team,
+			CreatedAt: time.Date(2026, time.Month(1+i), 1, 12, 0, 0, 0, time.Local),
+		})
+	}
+	tests := []struct {
+		name         string
+		command      string
+		wantGuidance bool
+		wantListed   []string
+	}{
+		{"over the cap", "/results 2026-01-01", true, nil},
+		{"narrowed by team", "/results Platform", false, []string{"EP-0", "EP-1"}},
+		{"narrowed by date", "/results 2026-04-01", false, []string{"EP-3", "EP-4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{BotConfig: config.BotConfig{MaxListedEpics: 3}}
+			epicBot, api := newTestBot(t, cfg, &fakeRepo{epics: epics, team: platform})
+
+			if err := epicBot.showEpicPickerInitial(context.Background(), testCommand(tt.command), "results", ""); err != nil {
+				t.Fatalf("showEpicPickerInitial() error = %v", err)
+			}
+
+			calls := api.Calls()
+			if len(calls) != 1 {
+				t.Fatalf("sent %d messages, want 1", len(calls))
+			}
+			text, markup := calls[0].Params["text"], calls[0].Params["reply_markup"]
+			if !tt.wantGuidance {
+				if strings.Count(markup, "EP-") != len(tt.wantListed) {
+					t.Errorf("picker %q lists %s, want %q", text, markup, tt.wantListed)
+				}
+				for _, number := range tt.wantListed {
+					if !strings.Contains(markup, number) {
+						t.Errorf("picker does not list %s", number)
+					}
+				}
+				return
+			}
+			for _, want := range []string{"Найдено эпиков: 5", "не больше 3", "/results <команда>", "/results <ГГГГ-ММ-ДД>"} {
+				if !strings.Contains(text, want) {
+					t.Errorf("guidance %q does not mention %q", text, want)
+				}
+			}
+			if strings.Contains(markup, "EP-") {
+				t.Errorf("guidance came with the epic list: %s", markup)
+			}
+		})
+	}
+}
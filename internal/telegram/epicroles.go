@@ -0,0 +1,156 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /setepicroles ────────────────────────────────────────────────────────
+
+const setEpicRolesUsage = "⚠️ Использование: /setepicroles <номер> [роль, роль...]\n" +
+	"Без ролей — показать текущие, «-» — оценивают все участники команды.\n" +
+	"Например: /setepicroles EP-1 Backend, QA"
+
+// handleSetEpicRoles restricts which roles estimate an epic's effort.
+// Usage: /setepicroles <number> [role, role...] | -
+func (epicBot *Bot) handleSetEpicRoles(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleSetEpicRoles"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+
+	// Epic numbers never contain spaces; role names may.
+	number, rest, _ := strings.Cut(strings.TrimSpace(commandArguments(msg)), " ")
+	rest = strings.TrimSpace(rest)
+	if number == "" {
+		_, err := epicBot.sendReply(ctx, msg, setEpicRolesUsage)
+		return err
+	}
+
+	epic, err := epicBot.repo.GetEpicByNumber(ctx, number)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.epicNumberErrorText(ctx, msg, err, number))
+		return retErr
+	}
+
+	if rest == "" {
+		current, err := epicBot.repo.GetEpicRequiredRoles(ctx, epic.ID)
+		if err != nil {
+			log.Error("error getting required roles", sl.Err(err))
+			_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка получения ролей эпика.")
+			return retErr
+		}
+		_, err = epicBot.sendReply(ctx, msg,
+			fmt.Sprintf("🎭 Трудоёмкость эпика #%s оценивают: %s", epic.Number, epicRolesText(current)))
+		return err
+	}
+	if epic.Status == domain.StatusScored {
+		_, err := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Эпик #%s уже оценён.", epic.Number))
+		return err
+	}
+
+	var roles []domain.Role
+	if rest != "-" {
+		all, err := epicBot.repo.GetAllRoles(ctx)
+		if err != nil {
+			log.Error("error getting roles", sl.Err(err))
+			_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка получения ролей.")
+			return retErr
+		}
+		var unknown []string
+		roles, unknown = resolveRoleNames(all, strings.Split(rest, ","))
+		if len(unknown) > 0 {
+			known := "—"
+			if len(all) > 0 {
+				known = epicRolesText(all)
+			}
+			_, retErr := epicBot.sendReply(ctx, msg,
+				fmt.Sprintf("❌ Неизвестные роли: %s.\nДоступные роли: %s",
+					strings.Join(unknown, ", "), known))
+			return retErr
+		}
+		if len(roles) == 0 {
+			_, retErr := epicBot.sendReply(ctx, msg, setEpicRolesUsage)
+			return retErr
+		}
+	}
+	ids := make([]uuid.UUID, len(roles))
+	for i, r := range roles {
+		ids[i] = r.ID
+	}
+	if err := epicBot.repo.SetEpicRequiredRoles(ctx, epic.ID, ids); err != nil {
+		log.Error("error setting required roles", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка сохранения ролей эпика.")
+		return retErr
+	}
+
+	text := epicRolesText(roles)
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionEpicRolesChanged,
+		fmt.Sprintf("#%s: %s", epic.Number, text))
+	if _, err := epicBot.sendReply(ctx, msg,
+		fmt.Sprintf("✅ Трудоёмкость эпика #%s оценивают: %s", epic.Number, text)); err != nil {
+		log.Error("failed to send reply", sl.Err(err))
+	}
+
+	// Fewer expected scorers may already make up the quorum.
+	if epic.Status == domain.StatusScoring {
+		if err := epicBot.scoring.TryCompleteEpicScoring(ctx, epic.ID); err != nil {
+			log.Error("failed to try complete epic scoring", sl.Err(err))
+			epicBot.notifyCompletionError(ctx, msg, err)
+		}
+	}
+	return nil
+}
+
+// resolveRoleNames looks up roles by name, ignoring case, duplicates and
+// blank entries. Names matching no role are returned as unknown.
+func resolveRoleNames(all []domain.Role, names []string) ([]domain.Role, []string) {
+	var roles []domain.Role
+	var unknown []string
+	seen := make(map[uuid.UUID]bool)
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		idx := slices.IndexFunc(all, func(r domain.Role) bool {
+			return strings.EqualFold(r.Name, name)
+		})
+		switch {
+		case idx < 0:
+			unknown = append(unknown, name)
+		case !seen[all[idx].ID]:
+			roles = append(roles, all[idx])
+			seen[all[idx].ID] = true
+		}
+	}
+	return roles, unknown
+}
+
+// epicRolesText lists the roles estimating an epic, or says that the whole
+// team does.
+func epicRolesText(roles []domain.Role) string {
+	if len(roles) == 0 {
+		return "все участники команды"
+	}
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = r.Name
+	}
+	return strings.Join(names, ", ")
+}
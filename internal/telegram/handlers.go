@@ -2,15 +2,20 @@ package telegram
 
 import (
 	"context"
-	"database/sql"
 	"errors"
 	"fmt"
 	"log/slog"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/config"
 	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
 	"EpicScoreBot/internal/scoring"
 	"EpicScoreBot/internal/utils/logger/sl"
 
@@ -29,17 +34,24 @@ func (epicBot *Bot) commandHandler(ctx context.Context, update *models.Update) e
 		ThreadID: msg.MessageThreadID,
 		Username: msg.From.Username,
 	}
-	sess, ok := epicBot.sessions.get(sk)
-	if ok && sess.MessageID > 0 {
+	sess, hadSession := epicBot.sessions.get(sk)
+	command := resolveCommand(commandText(msg))
+	if command == "resendkeyboard" {
+		// The only command that continues the pending session.
+		return epicBot.handleResendKeyboard(ctx, msg, sk, sess, hadSession)
+	}
+	if hadSession && sess.MessageID > 0 {
 		epicBot.deleteMessage(ctx, msg.Chat.ID, sess.MessageID)
 	}
 	epicBot.sessions.clear(sk)
 
-	switch commandText(msg) {
+	switch command {
 	case "start":
 		return epicBot.handleStart(ctx, msg)
 	case "help":
 		return epicBot.handleHelp(ctx, msg)
+	case "setlang":
+		return epicBot.handleSetLang(ctx, msg)
 	case "addteam":
 		return epicBot.handleAddTeam(ctx, msg)
 	case "adduser":
@@ -56,14 +68,26 @@ func (epicBot *Bot) commandHandler(ctx context.Context, update *models.Update) e
 		return epicBot.handleAddRisk(ctx, msg)
 	case "startscore":
 		return epicBot.handleStartScore(ctx, msg)
+	case "bulkstartscore":
+		return epicBot.handleBulkStartScore(ctx, msg)
 	case "results":
 		return epicBot.handleResults(ctx, msg)
 	case "epicstatus":
 		return epicBot.handleEpicStatus(ctx, msg)
 	case "score":
 		return epicBot.handleScoreMenu(ctx, msg)
+	case "resetmyscore":
+		return epicBot.handleResetMyScore(ctx, msg)
+	case "feedback":
+		return epicBot.handleFeedback(ctx, msg)
+	case "watchepic":
+		return epicBot.handleWatchEpic(ctx, msg)
+	case "unwatchepic":
+		return epicBot.handleUnwatchEpic(ctx, msg)
 	case "unassignrole":
 		return epicBot.handleUnassignRole(ctx, msg)
+	case "changerole":
+		return epicBot.handleChangeRole(ctx, msg)
 	case "removefromteam":
 		return epicBot.handleRemoveFromTeam(ctx, msg)
 	case "deleteepic":
@@ -80,68 +104,232 @@ func (epicBot *Bot) commandHandler(ctx context.Context, update *models.Update) e
 		return epicBot.handleRemoveAdmin(ctx, msg)
 	case "list":
 		return epicBot.handleList(ctx, msg)
+	case "agreement":
+		return epicBot.handleAgreement(ctx, msg)
+	case "teamstats":
+		return epicBot.handleTeamStats(ctx, msg)
+	case "activescoring":
+		return epicBot.handleActiveScoring(ctx, msg)
+	case "history":
+		return epicBot.handleEpicHistory(ctx, msg)
+	case "scoringtime":
+		return epicBot.handleScoringTime(ctx, msg)
+	case "setepicroles":
+		return epicBot.handleSetEpicRoles(ctx, msg)
+	case "duplicateepic":
+		return epicBot.handleDuplicateEpic(ctx, msg)
+	case "setteamweights":
+		return epicBot.handleSetTeamWeights(ctx, msg)
+	case "listroles":
+		return epicBot.handleListRoles(ctx, msg)
+	case "createrole":
+		return epicBot.handleCreateRole(ctx, msg)
+	case "deleterole":
+		return epicBot.handleDeleteRole(ctx, msg)
+	case "report":
+		return epicBot.handleReport(ctx, msg)
+	case "exportpdf":
+		return epicBot.handleExportPDF(ctx, msg)
+	case "recalculate":
+		return epicBot.handleRecalculate(ctx, msg)
+	case "closescore":
+		return epicBot.handleCloseScore(ctx, msg)
+	case "importepics":
+		return epicBot.handleImportEpics(ctx, msg)
+	case "auditlog":
+		return epicBot.handleAuditLog(ctx, msg)
+	case "viewas":
+		return epicBot.handleViewAs(ctx, msg)
+	case "whoami":
+		return epicBot.handleWhoAmI(ctx, msg)
+	case "ping":
+		return epicBot.handlePing(ctx, msg)
+	case "undo":
+		return epicBot.handleUndo(ctx, msg)
+	case "settings":
+		return epicBot.handleSettings(ctx, msg)
+	case "stats":
+		return epicBot.handleStats(ctx, msg)
+	case "settimezone":
+		return epicBot.handleSetTimezone(ctx, msg)
+	case "findepic":
+		return epicBot.handleFindEpic(ctx, msg)
+	case "mergeteams":
+		return epicBot.handleMergeTeams(ctx, msg)
+	case "deleteteam":
+		return epicBot.handleDeleteTeam(ctx, msg)
+	case "archiveteam":
+		return epicBot.handleArchiveTeam(ctx, msg)
+	case "unarchiveteam":
+		return epicBot.handleUnarchiveTeam(ctx, msg)
+	case "renameteam":
+		return epicBot.handleRenameTeam(ctx, msg)
+	case "cancel":
+		return epicBot.handleCancel(ctx, msg, hadSession)
 	default:
-		_, err := epicBot.sendReply(ctx, msg,
-			fmt.Sprintf("❓ Неизвестная команда: /%s\nИспользуйте /help для списка команд.",
-				commandText(msg)))
+		text := epicBot.t(ctx, msg, "unknown_command", commandText(msg))
+		if suggestion, ok := suggestCommand(commandText(msg)); ok {
+			text = epicBot.t(ctx, msg, "unknown_command_suggest", commandText(msg), suggestion)
+		}
+		_, err := epicBot.sendReply(ctx, msg, text)
 		return err
 	}
 }
 
 // ─── /start ───────────────────────────────────────────────────────────────
 
+// handleStart greets the user. In a private chat it also remembers the
+// chat of a registered user so the bot can message them directly. A
+// "score_<epicID>" payload from a deep link opens scoring of that epic
+// instead of the greeting.
 func (epicBot *Bot) handleStart(ctx context.Context, msg *models.Message) error {
-	text := fmt.Sprintf("👋 Привет, %s!\n\n"+
-		"Я бот для оценки трудоёмкости эпиков и рисков.\n"+
-		"Используйте /help для списка команд.",
-		msg.From.FirstName)
-	_, err := epicBot.sendReply(ctx, msg, text)
+	if msg.Chat.Type == models.ChatTypePrivate && msg.From.Username != "" {
+		epicBot.rememberPrivateChat(ctx, msg)
+	}
+	// Deep links pass "score_<epicID>"; anything else gets the greeting.
+	if epicID, ok := parseScorePayload(commandArguments(msg)); ok {
+		epicBot.startScoreDeepLink(ctx, msg, epicID)
+		return nil
+	}
+	_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "start", msg.From.FirstName))
 	return err
 }
 
+// rememberPrivateChat stores msg.Chat.ID for the registered sender.
+// Unregistered users are ignored; failures are only logged.
+func (epicBot *Bot) rememberPrivateChat(ctx context.Context, msg *models.Message) {
+	op := "bot.rememberPrivateChat"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, msg.From.Username)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrNotFound) {
+			log.Error("error finding user", sl.Err(err))
+		}
+		return
+	}
+	if err := epicBot.repo.UpdateUserChatID(ctx, user.ID, msg.Chat.ID); err != nil {
+		log.Error("error saving private chat", sl.Err(err))
+	}
+}
+
 // ─── /help ────────────────────────────────────────────────────────────────
 
+// handleHelp lists the commands available to the caller. With an argument,
+// it lists only the commands whose name or description mentions it.
 func (epicBot *Bot) handleHelp(ctx context.Context, msg *models.Message) error {
 	var sb strings.Builder
-	sb.WriteString("📋 <b>Команды бота</b>\n\n")
-	sb.WriteString("<b>👤 Для всех:</b>\n")
-	sb.WriteString("/score — меню оценки эпиков и рисков\n")
-	sb.WriteString("/epicstatus — статус оценки эпика\n")
-
-	if epicBot.isAdmin(msg) {
-		sb.WriteString("\n<b>🔧 Для администраторов:</b>\n")
-		sb.WriteString("/addteam &lt;название&gt; — создать команду\n")
-		sb.WriteString("/adduser — добавить пользователя\n")
-		sb.WriteString("/assignrole — назначить роль пользователю\n")
-		sb.WriteString("/addepic — создать эпик\n")
-		sb.WriteString("/addrisk — добавить риск к эпику\n")
-		sb.WriteString("/startscore — запустить оценку эпика\n")
-		sb.WriteString("/results — показать результаты эпика\n")
-		sb.WriteString("/list — список участников команды\n")
-	}
-
-	if epicBot.isSuperAdmin(msg) {
-		sb.WriteString("\n<b>⚡ Для супер-администраторов:</b>\n")
-		sb.WriteString("/assignteam — добавить пользователя в команду\n")
-		sb.WriteString("/renameuser — переименовать пользователя\n")
-		sb.WriteString("/changerate — изменить вес пользователя\n")
-		sb.WriteString("/unassignrole — снять роль у пользователя\n")
-		sb.WriteString("/removefromteam — удалить из команды\n")
-		sb.WriteString("/deleteepic — удалить эпик\n")
-		sb.WriteString("/deleterisk — удалить риск\n")
-		sb.WriteString("/deleteuser — удалить пользователя\n")
-		sb.WriteString("/addadmin — добавить администратора\n")
-		sb.WriteString("/removeadmin — удалить администратора\n")
-	}
-
-	if !epicBot.isAdmin(msg) {
-		sb.WriteString("\nДля управления — обратитесь к администратору.")
+	line := func(key string) {
+		sb.WriteString(epicBot.t(ctx, msg, key) + "\n")
+	}
+	query := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(commandArguments(msg)), "/"))
+	matched, sections := 0, 0
+	section := func(key string, commands ...string) {
+		if query == "" {
+			if sections > 0 {
+				sb.WriteString("\n")
+			}
+			line(key)
+		}
+		sections++
+		for _, c := range commands {
+			text := epicBot.t(ctx, msg, "help.cmd."+c)
+			if query != "" && c != resolveCommand(query) &&
+				!strings.Contains(strings.ToLower(text), query) {
+				continue
+			}
+			sb.WriteString(text + "\n")
+			matched++
+		}
+	}
+
+	line("help.title")
+	section("help.all", "score", "resetmyscore", "feedback", "epicstatus", "history", "findepic",
+		"watchepic", "unwatchepic", "whoami", "setlang", "settings", "help", "cancel", "resendkeyboard")
+
+	if epicBot.isAdmin(fromMessage(msg)) {
+		section("help.admin",
+			"addteam", "adduser", "assignrole", "addepic", "duplicateepic", "importepics", "addrisk",
+			"setepicroles", "startscore", "bulkstartscore", "closescore", "results", "report", "exportpdf", "list",
+			"listroles", "recalculate", "teamstats", "activescoring", "scoringtime", "settimezone", "agreement", "viewas",
+			"ping")
+	}
+
+	if epicBot.isSuperAdmin(fromMessage(msg)) {
+		section("help.superadmin",
+			"assignteam", "renameuser", "changerate", "setteamweights", "unassignrole", "changerole",
+			"removefromteam", "renameteam", "mergeteams", "archiveteam", "unarchiveteam", "deleteteam", "deleteepic", "deleterisk", "deleteuser",
+			"createrole", "deleterole", "addadmin", "removeadmin", "auditlog", "undo", "stats")
+	}
+
+	if query != "" && matched == 0 {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "help.no_match", query))
+		return err
+	}
+
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		sb.WriteString("\n" + epicBot.t(ctx, msg, "help.contact_admin"))
 	}
 
 	_, err := epicBot.sendHTML(ctx, msg, sb.String())
 	return err
 }
 
+// ─── /cancel ──────────────────────────────────────────────────────────────
+
+// epicNumberProblem explains why a new epic number is not accepted, or
+// returns "" if it is. The number must not be empty, must not contain
+// spaces and must match the configured pattern.
+func (epicBot *Bot) epicNumberProblem(number string) string {
+	switch {
+	case number == "":
+		return "❌ Номер не может быть пустым."
+	case strings.ContainsFunc(number, unicode.IsSpace):
+		return "❌ Номер не должен содержать пробелов."
+	case epicBot.epicNumber != nil && !epicBot.epicNumber.MatchString(number):
+		return fmt.Sprintf("❌ Номер должен соответствовать шаблону %s.", epicBot.cfg.BotConfig.EpicNumberPattern)
+	}
+	return ""
+}
+
+// textLengthProblem explains why entered text is longer than limit
+// characters, or returns "" if it fits. what names the field in the
+// message.
+func textLengthProblem(what, text string, limit int) string {
+	if !config.ExceedsLimit(text, limit) {
+		return ""
+	}
+	return fmt.Sprintf("❌ %s длиннее %d символов (сейчас %d).", what, limit, utf8.RuneCountInString(text))
+}
+
+// riskImportanceKeyboard lets the admin pick the importance of a new risk.
+func riskImportanceKeyboard() *models.InlineKeyboardMarkup {
+	return inlineKeyboard(
+		inlineRow(
+			inlineBtn("🔽 Низкая", "adm_importance_"+string(domain.ImportanceLow)),
+			inlineBtn("⏺ Средняя", "adm_importance_"+string(domain.ImportanceMedium)),
+			inlineBtn("🔼 Высокая", "adm_importance_"+string(domain.ImportanceHigh)),
+		),
+		inlineRow(inlineBtn("❌ Отмена", "adm_cancel")),
+	)
+}
+
+// cancelHint is appended to the first prompt of every interactive flow.
+const cancelHint = "\n(или /cancel для отмены)"
+
+// handleCancel confirms that the pending session was aborted. The session
+// itself is already cleared by commandHandler, like for any command.
+func (epicBot *Bot) handleCancel(ctx context.Context, msg *models.Message, hadSession bool) error {
+	key := "action.nothing_to_cancel"
+	if hadSession {
+		key = "action.cancelled"
+	}
+	_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, key))
+	return err
+}
+
 // ─── /addteam ─────────────────────────────────────────────────────────────
 
 func (epicBot *Bot) handleAddTeam(ctx context.Context, msg *models.Message) error {
@@ -151,8 +339,8 @@ func (epicBot *Bot) handleAddTeam(ctx context.Context, msg *models.Message) erro
 		slog.Int64("chat_id", msg.Chat.ID),
 		slog.String("username", msg.From.Username),
 	)
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	args := strings.TrimSpace(commandArguments(msg))
@@ -163,26 +351,31 @@ func (epicBot *Bot) handleAddTeam(ctx context.Context, msg *models.Message) erro
 
 	team, _ := epicBot.repo.GetTeamByName(ctx, args)
 	if team != nil {
-		_, err := epicBot.sendReply(ctx, msg, "❌ Команда с таким названием уже существует.")
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "team.exists"))
 		return err
 	}
 
 	team, err := epicBot.repo.CreateTeam(ctx, args, "")
 	if err != nil {
+		// Another admin may have added the same team since the check above.
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "team.exists"))
+			return retErr
+		}
 		log.Error("error creating team", sl.Err(err))
 		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка создания команды.")
 		return retErr
 	}
-	_, retErr := epicBot.sendReply(ctx, msg,
-		fmt.Sprintf("✅ Команда «%s» создана (ID: %s)", team.Name, team.ID))
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionTeamCreated, team.Name)
+	_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "team.created", team.Name, team.ID))
 	return retErr
 }
 
 // ─── /adduser ─────────────────────────────────────────────────────────────
 
 func (epicBot *Bot) handleAddUser(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return err
 	}
 
@@ -206,10 +399,16 @@ func (epicBot *Bot) handleAddUser(ctx context.Context, msg *models.Message) erro
 		}
 
 		user, err = epicBot.repo.CreateUser(ctx, args[1], args[2], username, weight)
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			_, retErr := epicBot.sendReply(ctx, msg, "❌ Пользователь с таким @username уже существует.")
+			return retErr
+		}
 		if err != nil {
 			_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка создания пользователя.")
 			return retErr
 		}
+		epicBot.recordAudit(ctx, msg.From.Username, audit.ActionUserAdded,
+			fmt.Sprintf("@%s %s %s, вес %d", user.TelegramID, user.FirstName, user.LastName, user.Weight))
 		_, retErr := epicBot.sendReply(ctx, msg,
 			fmt.Sprintf("✅ Пользователь %s %s (@%s) создан",
 				user.FirstName, user.LastName, user.TelegramID))
@@ -218,7 +417,7 @@ func (epicBot *Bot) handleAddUser(ctx context.Context, msg *models.Message) erro
 
 	// Interactive form: start session — first message is sent normally.
 	sk := sessionKey{ChatID: msg.Chat.ID, ThreadID: msg.MessageThreadID, Username: msg.From.Username}
-	sent, err := epicBot.sendReply(ctx, msg, "👤 Введите @username пользователя:")
+	sent, err := epicBot.sendReply(ctx, msg, "👤 Введите @username пользователя:"+cancelHint)
 	if err != nil {
 		return err
 	}
@@ -238,8 +437,8 @@ func (epicBot *Bot) handleAddUser(ctx context.Context, msg *models.Message) erro
 // ─── /assignrole — inline keyboard ────────────────────────────────────────
 
 func (epicBot *Bot) handleAssignRole(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return err
 	}
 	return epicBot.showUserPickerWithoutRole(ctx, msg)
@@ -267,7 +466,7 @@ func (epicBot *Bot) showUserPickerWithoutRole(ctx context.Context, msg *models.M
 		}
 		label := fmt.Sprintf("👤 %s %s (@%s)", u.FirstName, u.LastName, u.TelegramID)
 		data := fmt.Sprintf("adm_user_assignrole_%s", u.ID.String())
-		rows = append(rows, inlineRow(inlineBtn(label, data)))
+		rows = append(rows, inlineRow(epicBot.pickerBtn(label, data)))
 	}
 
 	if len(rows) == 0 {
@@ -299,8 +498,8 @@ func (epicBot *Bot) showUserPickerWithoutRole(ctx context.Context, msg *models.M
 // ─── /assignteam — inline keyboard ────────────────────────────────────────
 
 func (epicBot *Bot) handleAssignTeam(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	return epicBot.showUserPickerInitial(ctx, msg, "assignteam")
@@ -309,18 +508,102 @@ func (epicBot *Bot) handleAssignTeam(ctx context.Context, msg *models.Message) e
 // ─── /addepic — inline keyboard then session ──────────────────────────────
 
 func (epicBot *Bot) handleAddEpic(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return err
 	}
 	return epicBot.showTeamPickerInitial(ctx, msg, "addepic")
 }
 
+// ─── /mergeteams ──────────────────────────────────────────────────────────
+
+// handleMergeTeams moves all epics and members of one team to another.
+// Usage: /mergeteams <from> <to> [удалить]
+func (epicBot *Bot) handleMergeTeams(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleMergeTeams"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+	args := strings.Fields(commandArguments(msg))
+	deleteSource := len(args) == 3 && strings.EqualFold(args[2], "удалить")
+	if len(args) != 2 && !deleteSource {
+		_, err := epicBot.sendReply(ctx, msg,
+			"⚠️ Использование: /mergeteams <из команды> <в команду> [удалить]\n"+
+				"С «удалить» пустая исходная команда будет удалена.")
+		return err
+	}
+
+	from, err := epicBot.repo.GetTeamByName(ctx, args[0])
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "team.name_not_found", args[0]))
+		return retErr
+	}
+	to, err := epicBot.repo.GetTeamByName(ctx, args[1])
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "team.name_not_found", args[1]))
+		return retErr
+	}
+	if from.ID == to.ID {
+		_, err := epicBot.sendReply(ctx, msg, "❌ Исходная и целевая команды совпадают.")
+		return err
+	}
+
+	// Epics in scoring switch their quorum basis to the target team.
+	inFlight, err := epicBot.repo.GetEpicsByTeamIDAndStatus(ctx, from.ID, domain.StatusScoring)
+	if err != nil {
+		log.Error("error getting scoring epics", sl.Err(err))
+	}
+
+	epicsMoved, membersMoved, err := epicBot.repo.MergeTeams(ctx, from.ID, to.ID, deleteSource)
+	if errors.Is(err, repositories.ErrAlreadyExists) {
+		_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf(
+			"❌ В командах «%s» и «%s» есть эпики с одинаковыми номерами, а в одной команде номера не повторяются.",
+			from.Name, to.Name))
+		return retErr
+	}
+	if err != nil {
+		log.Error("error merging teams", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка объединения команд.")
+		return retErr
+	}
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionTeamsMerged,
+		fmt.Sprintf("%s → %s, эпиков %d, участников %d, удалена: %t",
+			from.Name, to.Name, epicsMoved, membersMoved, deleteSource))
+	log.Info("teams merged",
+		slog.String("from", from.Name),
+		slog.String("to", to.Name),
+		slog.Int("epics", epicsMoved),
+		slog.Int("members", membersMoved))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "✅ Команда «%s» перенесена в «%s».\n", from.Name, to.Name)
+	fmt.Fprintf(&sb, "Эпиков перенесено: %d\n", epicsMoved)
+	fmt.Fprintf(&sb, "Новых участников: %d\n", membersMoved)
+	if deleteSource {
+		fmt.Fprintf(&sb, "🗑️ Команда «%s» удалена.\n", from.Name)
+	}
+	if len(inFlight) > 0 {
+		nums := make([]string, 0, len(inFlight))
+		for _, e := range inFlight {
+			nums = append(nums, "#"+e.Number)
+		}
+		fmt.Fprintf(&sb, "\n⚠️ Эпики в оценке теперь считают кворум по составу «%s»: %s",
+			to.Name, strings.Join(nums, ", "))
+	}
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}
+
 // ─── /addrisk — inline keyboard then session ──────────────────────────────
 
 func (epicBot *Bot) handleAddRisk(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return err
 	}
 	return epicBot.showEpicPickerInitial(ctx, msg, "addrisk", "")
@@ -328,12 +611,38 @@ func (epicBot *Bot) handleAddRisk(ctx context.Context, msg *models.Message) erro
 
 // ─── /startscore — inline keyboard ───────────────────────────────────────
 
+// An optional duration argument (e.g. 24h or 3d) sets the scoring deadline.
 func (epicBot *Bot) handleStartScore(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return err
 	}
-	return epicBot.showEpicPickerInitial(ctx, msg, "startscore", string(domain.StatusNew))
+	var deadline time.Duration
+	for _, word := range strings.Fields(commandArguments(msg)) {
+		if d, ok := parseScoringDuration(word); ok {
+			deadline = d
+		}
+	}
+	if err := epicBot.showEpicPickerInitial(ctx, msg, "startscore", string(domain.StatusNew)); err != nil {
+		return err
+	}
+	if deadline > 0 {
+		sk := sessionKey{ChatID: msg.Chat.ID, ThreadID: msg.MessageThreadID, Username: msg.From.Username}
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.Data["deadline"] = deadline.String()
+		})
+	}
+	return nil
+}
+
+// ─── /closescore — inline keyboard ───────────────────────────────────────
+
+func (epicBot *Bot) handleCloseScore(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	return epicBot.showEpicPickerInitial(ctx, msg, "closescore", string(domain.StatusScoring))
 }
 
 // ─── /results — inline keyboard ──────────────────────────────────────────
@@ -342,6 +651,41 @@ func (epicBot *Bot) handleResults(ctx context.Context, msg *models.Message) erro
 	return epicBot.showEpicPickerInitial(ctx, msg, "results", "")
 }
 
+// ─── /findepic — search then inline keyboard ────────────────────────────
+
+// handleFindEpic searches epics by number, name and description.
+// Usage: /findepic <query>
+func (epicBot *Bot) handleFindEpic(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleFindEpic"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	query := strings.TrimSpace(commandArguments(msg))
+	if query == "" {
+		_, err := epicBot.sendReply(ctx, msg, "⚠️ Использование: /findepic <часть номера, названия или описания>")
+		return err
+	}
+
+	epics, err := epicBot.repo.SearchEpics(ctx, query)
+	if err != nil {
+		log.Error("error searching epics", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка поиска эпиков.")
+		return retErr
+	}
+	if len(epics) == 0 {
+		_, err := epicBot.sendReply(ctx, msg, fmt.Sprintf("🔍 По запросу «%s» ничего не найдено.", query))
+		return err
+	}
+	if limit := epicBot.cfg.BotConfig.EpicListLimit(); len(epics) > limit {
+		_, err := epicBot.sendReply(ctx, msg,
+			fmt.Sprintf("⚠️ Найдено эпиков: %d, за раз можно показать не больше %d.\nУточните запрос.",
+				len(epics), limit))
+		return err
+	}
+	return epicBot.sendEpicPicker(ctx, msg, "results", epics)
+}
+
 // ─── /epicstatus — inline keyboard ───────────────────────────────────────
 
 func (epicBot *Bot) handleEpicStatus(ctx context.Context, msg *models.Message) error {
@@ -351,18 +695,28 @@ func (epicBot *Bot) handleEpicStatus(ctx context.Context, msg *models.Message) e
 // ─── /unassignrole — inline keyboard ─────────────────────────────────────
 
 func (epicBot *Bot) handleUnassignRole(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	return epicBot.showUserPickerInitial(ctx, msg, "unassignrole")
 }
 
+// ─── /changerole — inline keyboard ───────────────────────────────────────
+
+func (epicBot *Bot) handleChangeRole(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+	return epicBot.showUserPickerInitial(ctx, msg, "changerole")
+}
+
 // ─── /removefromteam — inline keyboard ───────────────────────────────────
 
 func (epicBot *Bot) handleRemoveFromTeam(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	return epicBot.showUserPickerInitial(ctx, msg, "removefromteam")
@@ -371,8 +725,8 @@ func (epicBot *Bot) handleRemoveFromTeam(ctx context.Context, msg *models.Messag
 // ─── /deleteepic — inline keyboard ───────────────────────────────────────
 
 func (epicBot *Bot) handleDeleteEpic(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	return epicBot.showEpicPickerInitial(ctx, msg, "deleteepic", "")
@@ -381,8 +735,8 @@ func (epicBot *Bot) handleDeleteEpic(ctx context.Context, msg *models.Message) e
 // ─── /deleterisk — inline keyboard ───────────────────────────────────────
 
 func (epicBot *Bot) handleDeleteRisk(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	return epicBot.showEpicPickerInitial(ctx, msg, "deleterisk", "")
@@ -391,8 +745,8 @@ func (epicBot *Bot) handleDeleteRisk(ctx context.Context, msg *models.Message) e
 // ─── /deleteuser — inline keyboard ───────────────────────────────────────
 
 func (epicBot *Bot) handleDeleteUser(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для суперадминистраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	return epicBot.showUserPickerInitial(ctx, msg, "deleteuser")
@@ -401,8 +755,8 @@ func (epicBot *Bot) handleDeleteUser(ctx context.Context, msg *models.Message) e
 // ─── /renameuser ──────────────────────────────────────────────────────────
 
 func (epicBot *Bot) handleRenameUser(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	return epicBot.showUserPickerInitial(ctx, msg, "renameuser")
@@ -411,8 +765,8 @@ func (epicBot *Bot) handleRenameUser(ctx context.Context, msg *models.Message) e
 // ─── /changerate ──────────────────────────────────────────────────────────
 
 func (epicBot *Bot) handleChangeRate(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	return epicBot.showUserPickerInitial(ctx, msg, "changerate")
@@ -421,8 +775,8 @@ func (epicBot *Bot) handleChangeRate(ctx context.Context, msg *models.Message) e
 // ─── /list ──────────────────────────────────────────────────────────
 
 func (epicBot *Bot) handleList(ctx context.Context, msg *models.Message) error {
-	if !epicBot.isAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для администраторов.")
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
 		return err
 	}
 	return epicBot.showTeamPickerInitial(ctx, msg, "list")
@@ -445,12 +799,13 @@ func (epicBot *Bot) handleScoreMenu(ctx context.Context, msg *models.Message) er
 
 	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		if errors.Is(err, repositories.ErrNotFound) {
 			_, retErr := epicBot.sendReply(ctx, msg,
 				"❌ Вы не зарегистрированы в системе. Обратитесь к администратору.")
 			return retErr
 		}
-		_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка: %v", err))
+		log.Error("error finding user", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal"))
 		return retErr
 	}
 
@@ -465,7 +820,7 @@ func (epicBot *Bot) handleScoreMenu(ctx context.Context, msg *models.Message) er
 
 	var rows [][]models.InlineKeyboardButton
 	for _, team := range teams {
-		rows = append(rows, inlineRow(inlineBtn(
+		rows = append(rows, inlineRow(epicBot.pickerBtn(
 			fmt.Sprintf("👥 %s", team.Name),
 			fmt.Sprintf("team_%s", team.ID.String()),
 		)))
@@ -478,8 +833,12 @@ func (epicBot *Bot) handleScoreMenu(ctx context.Context, msg *models.Message) er
 
 // ─── Inline picker helpers (Initial — send first message, save ID) ─────────
 
-// showUserPickerInitial sends an inline keyboard with all registered users.
-// The sent message ID is stored in a new session for editing later.
+// userPickerPageSize is how many users a single picker page shows.
+const userPickerPageSize = 20
+
+// showUserPickerInitial sends the first page of an inline keyboard with
+// registered users. The sent message ID is stored in a new session for
+// editing later.
 func (epicBot *Bot) showUserPickerInitial(ctx context.Context, msg *models.Message, action string) error {
 	op := "bot.showUserPickerInitial"
 	log := epicBot.log.With(
@@ -487,22 +846,14 @@ func (epicBot *Bot) showUserPickerInitial(ctx context.Context, msg *models.Messa
 		slog.Int64("chat_id", msg.Chat.ID),
 		slog.String("action", action),
 	)
-	users, err := epicBot.repo.GetAllUsers(ctx)
-	if err != nil || len(users) == 0 {
+	kb, err := epicBot.userPickerKeyboard(ctx, action, 0)
+	if err != nil || kb == nil {
 		if err != nil {
-			log.Error("error getting all users", sl.Err(err))
+			log.Error("error getting users page", sl.Err(err))
 		}
 		_, retErr := epicBot.sendReply(ctx, msg, "❌ Пользователи не найдены.")
 		return retErr
 	}
-	var rows [][]models.InlineKeyboardButton
-	for _, u := range users {
-		label := fmt.Sprintf("👤 %s %s (@%s)", u.FirstName, u.LastName, u.TelegramID)
-		data := fmt.Sprintf("adm_user_%s_%s", action, u.ID.String())
-		rows = append(rows, inlineRow(inlineBtn(label, data)))
-	}
-	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
-	kb := inlineKeyboard(rows...)
 
 	sent, err := epicBot.sendWithKeyboard(ctx, msg, "👤 Выберите пользователя:", kb)
 	if err != nil {
@@ -522,7 +873,50 @@ func (epicBot *Bot) showUserPickerInitial(ctx context.Context, msg *models.Messa
 	return nil
 }
 
-// showTeamPickerInitial sends an inline keyboard with all teams.
+// userPickerKeyboard builds one page of the user picker starting at offset.
+// It returns a nil keyboard when the page is empty.
+func (epicBot *Bot) userPickerKeyboard(
+	ctx context.Context,
+	action string,
+	offset int,
+) (*models.InlineKeyboardMarkup, error) {
+	// Fetch one extra user to find out whether a next page exists.
+	users, err := epicBot.repo.GetUsersPage(ctx, userPickerPageSize+1, offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, nil
+	}
+	hasNext := len(users) > userPickerPageSize
+	if hasNext {
+		users = users[:userPickerPageSize]
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	for _, u := range users {
+		label := fmt.Sprintf("👤 %s %s (@%s)", u.FirstName, u.LastName, u.TelegramID)
+		data := fmt.Sprintf("adm_user_%s_%s", action, u.ID.String())
+		rows = append(rows, inlineRow(epicBot.pickerBtn(label, data)))
+	}
+	var nav []models.InlineKeyboardButton
+	if offset > 0 {
+		prev := max(offset-userPickerPageSize, 0)
+		nav = append(nav, inlineBtn("⬅️ Назад", fmt.Sprintf("adm_userpage_%s_%d", action, prev)))
+	}
+	if hasNext {
+		nav = append(nav, inlineBtn("➡️ Далее",
+			fmt.Sprintf("adm_userpage_%s_%d", action, offset+userPickerPageSize)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, inlineRow(nav...))
+	}
+	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
+	return inlineKeyboard(rows...), nil
+}
+
+// showTeamPickerInitial sends an inline keyboard with the teams offered for
+// action; see pickerTeams.
 func (epicBot *Bot) showTeamPickerInitial(ctx context.Context, msg *models.Message, action string) error {
 	op := "bot.showTeamPickerInitial"
 	log := epicBot.log.With(
@@ -530,7 +924,7 @@ func (epicBot *Bot) showTeamPickerInitial(ctx context.Context, msg *models.Messa
 		slog.Int64("chat_id", msg.Chat.ID),
 		slog.String("action", action),
 	)
-	teams, err := epicBot.repo.GetAllTeams(ctx)
+	teams, err := epicBot.pickerTeams(ctx, action)
 	if err != nil || len(teams) == 0 {
 		if err != nil {
 			log.Error("error getting all teams", sl.Err(err))
@@ -541,7 +935,7 @@ func (epicBot *Bot) showTeamPickerInitial(ctx context.Context, msg *models.Messa
 	var rows [][]models.InlineKeyboardButton
 	for _, t := range teams {
 		data := fmt.Sprintf("adm_team_%s_%s", action, t.ID.String())
-		rows = append(rows, inlineRow(inlineBtn("👥 "+t.Name, data)))
+		rows = append(rows, inlineRow(epicBot.pickerBtn(teamPickerLabel(t), data)))
 	}
 	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
 	kb := inlineKeyboard(rows...)
@@ -564,6 +958,8 @@ func (epicBot *Bot) showTeamPickerInitial(ctx context.Context, msg *models.Messa
 }
 
 // showEpicPickerInitial sends an inline keyboard with epics, optionally filtered by status.
+// Command arguments may narrow the list by team name and creation date; when
+// more epics than the configured cap match, the user is asked to narrow it.
 func (epicBot *Bot) showEpicPickerInitial(ctx context.Context, msg *models.Message, action, statusFilter string) error {
 	op := "bot.showEpicPickerInitial"
 	log := epicBot.log.With(
@@ -572,30 +968,74 @@ func (epicBot *Bot) showEpicPickerInitial(ctx context.Context, msg *models.Messa
 		slog.String("action", action),
 		slog.String("status_filter", statusFilter),
 	)
+	filter, err := epicBot.parseEpicFilter(ctx, msg)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, err.Error())
+		return retErr
+	}
+
+	// Without narrowing arguments the full list is paged through instead.
+	if filter.empty() {
+		text, kb, err := epicBot.epicPickerPage(ctx, action, domain.Status(statusFilter), 0)
+		if err != nil || kb == nil {
+			if err != nil {
+				log.Error("error getting epics page", sl.Err(err))
+			}
+			_, retErr := epicBot.sendReply(ctx, msg, "❌ Эпики не найдены.")
+			return retErr
+		}
+		return epicBot.sendEpicKeyboard(ctx, msg, text, kb)
+	}
+
 	var epics []domain.Epic
-	var err error
-	if statusFilter != "" {
+	switch {
+	case filter.teamID != nil && statusFilter != "":
+		epics, err = epicBot.repo.GetEpicsByTeamIDAndStatus(ctx, *filter.teamID, domain.Status(statusFilter))
+	case filter.teamID != nil:
+		epics, err = epicBot.repo.GetEpicsByTeamID(ctx, *filter.teamID)
+	case statusFilter != "":
 		epics, err = epicBot.repo.GetEpicsByStatus(ctx, domain.Status(statusFilter))
-	} else {
+	default:
 		epics, err = epicBot.repo.GetAllEpics(ctx)
 	}
-	if err != nil || len(epics) == 0 {
-		if err != nil {
-			log.Error("error getting epics by status", sl.Err(err))
-		}
+	if err != nil {
+		log.Error("error getting epics", sl.Err(err))
 		_, retErr := epicBot.sendReply(ctx, msg, "❌ Эпики не найдены.")
 		return retErr
 	}
-	var rows [][]models.InlineKeyboardButton
-	for _, e := range epics {
-		label := fmt.Sprintf("📝 #%s %s [%s]", e.Number, e.Name, string(e.Status))
-		data := fmt.Sprintf("adm_epic_%s_%s", action, e.ID.String())
-		rows = append(rows, inlineRow(inlineBtn(label, data)))
+	epics = filter.apply(epics)
+	if len(epics) == 0 {
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Эпики не найдены.")
+		return retErr
+	}
+	if limit := epicBot.cfg.BotConfig.EpicListLimit(); len(epics) > limit {
+		_, retErr := epicBot.sendReply(ctx, msg,
+			epicLimitGuidance(commandText(msg), len(epics), limit))
+		return retErr
 	}
+	return epicBot.sendEpicPicker(ctx, msg, action, epics)
+}
+
+// epicPickerPageSize is how many epics a single picker page shows.
+const epicPickerPageSize = 20
+
+// sendEpicPicker sends an inline keyboard with the given epics and stores
+// the sent message ID in a new session for editing later.
+func (epicBot *Bot) sendEpicPicker(ctx context.Context, msg *models.Message, action string, epics []domain.Epic) error {
+	rows := epicBot.epicPickerRows(action, epics)
 	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
-	kb := inlineKeyboard(rows...)
+	return epicBot.sendEpicKeyboard(ctx, msg, "📝 Выберите эпик:", inlineKeyboard(rows...))
+}
 
-	sent, err := epicBot.sendWithKeyboard(ctx, msg, "📝 Выберите эпик:", kb)
+// sendEpicKeyboard sends an epic picker keyboard and stores the sent
+// message ID in a new session for editing later.
+func (epicBot *Bot) sendEpicKeyboard(
+	ctx context.Context,
+	msg *models.Message,
+	text string,
+	kb *models.InlineKeyboardMarkup,
+) error {
+	sent, err := epicBot.sendWithKeyboard(ctx, msg, text, kb)
 	if err != nil {
 		return err
 	}
@@ -612,6 +1052,77 @@ func (epicBot *Bot) showEpicPickerInitial(ctx context.Context, msg *models.Messa
 	return nil
 }
 
+// epicPickerRows builds one picker button per epic.
+func (epicBot *Bot) epicPickerRows(action string, epics []domain.Epic) [][]models.InlineKeyboardButton {
+	var rows [][]models.InlineKeyboardButton
+	for _, e := range epics {
+		label := fmt.Sprintf("📝 #%s %s [%s]", e.Number, e.Name, string(e.Status))
+		data := fmt.Sprintf("adm_epic_%s_%s", action, e.ID.String())
+		rows = append(rows, inlineRow(epicBot.pickerBtn(label, data)))
+	}
+	return rows
+}
+
+// epicPickerPage builds the page of the epic picker starting at offset,
+// optionally limited to one status. It returns a nil keyboard when the
+// page is empty.
+func (epicBot *Bot) epicPickerPage(
+	ctx context.Context,
+	action string,
+	status domain.Status,
+	offset int,
+) (string, *models.InlineKeyboardMarkup, error) {
+	epics, total, err := epicBot.repo.GetEpicsPage(ctx, epicPickerPageSize, offset, status)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(epics) == 0 {
+		return "", nil, nil
+	}
+
+	rows := epicBot.epicPickerRows(action, epics)
+	statusArg := string(status)
+	if statusArg == "" {
+		statusArg = epicPageAnyStatus
+	}
+	var nav []models.InlineKeyboardButton
+	if offset > 0 {
+		prev := max(offset-epicPickerPageSize, 0)
+		nav = append(nav, inlineBtn("⬅️ Назад",
+			fmt.Sprintf("adm_epicpage_%s_%s_%d", action, statusArg, prev)))
+	}
+	if offset+len(epics) < total {
+		nav = append(nav, inlineBtn("➡️ Далее",
+			fmt.Sprintf("adm_epicpage_%s_%s_%d", action, statusArg, offset+epicPickerPageSize)))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, inlineRow(nav...))
+	}
+	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
+
+	text := "📝 Выберите эпик:"
+	if pages := (total + epicPickerPageSize - 1) / epicPickerPageSize; pages > 1 {
+		text = fmt.Sprintf("📝 Выберите эпик (стр. %d из %d):", offset/epicPickerPageSize+1, pages)
+	}
+	return text, inlineKeyboard(rows...), nil
+}
+
+// rememberPendingUser stores the user picked in the first step of a
+// user → role/team flow, starting a session if the picker had none.
+func (epicBot *Bot) rememberPendingUser(sk sessionKey, initiator, userID string, msgID int) {
+	if _, ok := epicBot.sessions.update(sk, func(s *Session) {
+		s.Data["pendingUserID"] = userID
+		s.MessageID = msgID
+	}); ok {
+		return
+	}
+	epicBot.sessions.set(sk, &Session{
+		Username:  initiator,
+		MessageID: msgID,
+		Data:      map[string]string{"pendingUserID": userID},
+	})
+}
+
 // showRolePicker sends an inline keyboard with all roles (editing existing message).
 func (epicBot *Bot) showRolePicker(
 	ctx context.Context,
@@ -639,22 +1150,12 @@ func (epicBot *Bot) showRolePicker(
 		return
 	}
 
-	sk := sessionKeyFromCallback(msg, callback)
-	sess, _ := epicBot.sessions.get(sk)
-	if sess == nil {
-		sess = &Session{
-			Data:     make(map[string]string),
-			Username: callback.From.Username,
-		}
-	}
-	sess.Data["pendingUserID"] = userIDStr
-	sess.MessageID = msgID
-	epicBot.sessions.set(sk, sess)
+	epicBot.rememberPendingUser(sessionKeyFromCallback(msg, callback), callback.From.Username, userIDStr, msgID)
 
 	var rows [][]models.InlineKeyboardButton
 	for _, r := range roles {
 		data := fmt.Sprintf("adm_role_%s_%s", action, r.ID.String())
-		rows = append(rows, inlineRow(inlineBtn("🎭 "+r.Name, data)))
+		rows = append(rows, inlineRow(epicBot.pickerBtn("🎭 "+r.Name, data)))
 	}
 	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
 	kb := inlineKeyboard(rows...)
@@ -680,21 +1181,11 @@ func (epicBot *Bot) showUserRolePicker(
 		epicBot.editOrSend(ctx, msg, msgID, "❌ У пользователя нет назначенных ролей.")
 		return
 	}
-	sk := sessionKeyFromCallback(msg, callback)
-	sess, _ := epicBot.sessions.get(sk)
-	if sess == nil {
-		sess = &Session{
-			Data:     make(map[string]string),
-			Username: callback.From.Username,
-		}
-	}
-	sess.Data["pendingUserID"] = userID.String()
-	sess.MessageID = msgID
-	epicBot.sessions.set(sk, sess)
+	epicBot.rememberPendingUser(sessionKeyFromCallback(msg, callback), callback.From.Username, userID.String(), msgID)
 
 	data := fmt.Sprintf("adm_role_%s_%s", action, role.ID.String())
 	kb := inlineKeyboard(
-		inlineRow(inlineBtn("🎭 "+role.Name, data)),
+		inlineRow(epicBot.pickerBtn("🎭 "+role.Name, data)),
 		inlineRow(inlineBtn("❌ Отмена", "adm_cancel")),
 	)
 	epicBot.editOrSendWithKeyboard(ctx, msg, msgID, "🎭 Выберите роль для снятия:", kb)
@@ -724,22 +1215,12 @@ func (epicBot *Bot) showUserTeamPicker(
 		epicBot.editOrSend(ctx, msg, msgID, "❌ Пользователь не состоит ни в одной команде.")
 		return
 	}
-	sk := sessionKeyFromCallback(msg, callback)
-	sess, _ := epicBot.sessions.get(sk)
-	if sess == nil {
-		sess = &Session{
-			Data:     make(map[string]string),
-			Username: callback.From.Username,
-		}
-	}
-	sess.Data["pendingUserID"] = user.ID.String()
-	sess.MessageID = msgID
-	epicBot.sessions.set(sk, sess)
+	epicBot.rememberPendingUser(sessionKeyFromCallback(msg, callback), callback.From.Username, user.ID.String(), msgID)
 
 	var rows [][]models.InlineKeyboardButton
 	for _, t := range teams {
 		data := fmt.Sprintf("adm_team_%s_%s", action, t.ID.String())
-		rows = append(rows, inlineRow(inlineBtn("👥 "+t.Name, data)))
+		rows = append(rows, inlineRow(epicBot.pickerBtn("👥 "+t.Name, data)))
 	}
 	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
 	kb := inlineKeyboard(rows...)
@@ -748,21 +1229,35 @@ func (epicBot *Bot) showUserTeamPicker(
 
 // ─── /results logic (called by callback) ──────────────────────────────────
 
-func (epicBot *Bot) showEpicResults(ctx context.Context, msg *models.Message, epicID uuid.UUID) {
-	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
+// showEpicResults prints the results of an epic. Everyone sees the role
+// averages, the risks and the final score; with full set, which is for
+// admins, each participant's effort score is listed as well.
+func (epicBot *Bot) showEpicResults(ctx context.Context, msg *models.Message, epicID uuid.UUID, full bool) {
+	op := "bot.showEpicResults"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("epic_id", epicID.String()),
+	)
+	epic, err := epicBot.repo.GetEpicWithRisks(ctx, epicID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, "❌ Эпик не найден.")
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
 		return
 	}
 
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "📊 *Результаты эпика \\#%s «%s»*\n", escapeMarkdownV2(epic.Number), escapeMarkdownV2(epic.Name))
-	fmt.Fprintf(&sb, "Статус: %s\n\n", escapeMarkdownV2(string(epic.Status)))
+	sb.WriteString(epicTitleMarkdown("Результаты эпика", epic.Number, epic.Name))
+	fmt.Fprintf(&sb, "Статус: %s\n", escapeMarkdownV2(string(epic.Status)))
+	loc := epicBot.teamLocation(ctx, epic.TeamID)
+	fmt.Fprintf(&sb, "Создан: %s\n", escapeMarkdownV2(formatTime(epic.CreatedAt, loc)))
+	if epic.ScoringDeadline != nil {
+		fmt.Fprintf(&sb, "Дедлайн оценки: %s\n", escapeMarkdownV2(formatTime(*epic.ScoringDeadline, loc)))
+	}
+	sb.WriteString("\n")
 
-	roleScores, err := epicBot.repo.GetEpicRoleScoresByEpicID(ctx, epic.ID)
-	if err == nil && len(roleScores) > 0 {
+	if len(epic.RoleScores) > 0 {
 		sb.WriteString("📋 *Оценки по ролям:*\n")
-		for _, rs := range roleScores {
+		for _, rs := range epic.RoleScores {
 			role, err := epicBot.repo.GetRoleByID(ctx, rs.RoleID)
 			roleName := rs.RoleID.String()
 			if err == nil {
@@ -773,29 +1268,132 @@ func (epicBot *Bot) showEpicResults(ctx context.Context, msg *models.Message, ep
 		sb.WriteString("\n")
 	}
 
-	risks, err := epicBot.repo.GetRisksByEpicID(ctx, epic.ID)
-	if err == nil && len(risks) > 0 {
+	if full {
+		if err := epicBot.writeParticipantScores(ctx, &sb, epic.ID); err != nil {
+			log.Error("error getting participant scores", sl.Err(err))
+		}
+	}
+
+	if err := epicBot.writeEpicComments(ctx, msg, &sb, epic.ID); err != nil {
+		log.Error("error getting epic comments", sl.Err(err))
+	}
+
+	risks, hidden := resultRisks(epic.Risks, epicBot.chatHideBaselineRisks(ctx, msg.Chat.ID))
+	if len(epic.Risks) > 0 {
 		sb.WriteString("⚠️ *Риски:*\n")
 		for _, risk := range risks {
-			coeff := ""
-			if risk.WeightedScore != nil {
-				c := scoring.RiskCoefficient(*risk.WeightedScore)
-				coeff = fmt.Sprintf(" \\(оценка: %s, коэфф: %s\\)",
-					escapeMarkdownV2(fmt.Sprintf("%.2f", *risk.WeightedScore)),
-					escapeMarkdownV2(fmt.Sprintf("%.2f", c)))
-			}
-			fmt.Fprintf(&sb, "  • %s \\[%s\\]%s\n", escapeMarkdownV2(risk.Description), escapeMarkdownV2(string(risk.Status)), coeff)
+			sb.WriteString(resultRiskMarkdown(risk))
+		}
+		if hidden > 0 {
+			fmt.Fprintf(&sb, "  _…и ещё %d с базовым коэффициентом %s_\n",
+				hidden, escapeMarkdownV2(fmt.Sprintf("%.2f", scoring.BaselineRiskCoefficient)))
 		}
 		sb.WriteString("\n")
 	}
 
 	if epic.FinalScore != nil {
-		fmt.Fprintf(&sb, "🏆 *Итоговая оценка: %s*\n", escapeMarkdownV2(fmt.Sprintf("%.0f", *epic.FinalScore)))
+		fmt.Fprintf(&sb, "🏆 *Итоговая оценка: %s*\n", escapeMarkdownV2(strconv.FormatFloat(*epic.FinalScore, 'f', -1, 64)))
 	} else {
 		sb.WriteString("⏳ Итоговая оценка ещё не рассчитана\\.\n")
 	}
 
-	epicBot.sendMarkdown(ctx, msg, sb.String())
+	if _, err := epicBot.sendMarkdownOrPlain(ctx, msg, sb.String()); err != nil {
+		log.Error("failed to send epic results", sl.Err(err))
+	}
+}
+
+// writeParticipantScores adds the effort score of every participant of an
+// epic to a /results message.
+func (epicBot *Bot) writeParticipantScores(ctx context.Context, sb *strings.Builder, epicID uuid.UUID) error {
+	scores, err := epicBot.repo.GetEpicScoresByEpicID(ctx, epicID)
+	if err != nil || len(scores) == 0 {
+		return err
+	}
+	users, err := epicBot.repo.GetUsersWhoScoredEpic(ctx, epicID)
+	if err != nil {
+		return err
+	}
+	byUser := make(map[uuid.UUID]domain.EpicScore, len(scores))
+	for _, s := range scores {
+		byUser[s.UserID] = s
+	}
+	roleNames := make(map[uuid.UUID]string)
+
+	sb.WriteString("👤 *Оценки участников:*\n")
+	for _, u := range users {
+		s, ok := byUser[u.ID]
+		if !ok {
+			continue
+		}
+		roleName, ok := roleNames[s.RoleID]
+		if !ok {
+			roleName = s.RoleID.String()
+			if role, err := epicBot.repo.GetRoleByID(ctx, s.RoleID); err == nil {
+				roleName = role.Name
+			}
+			roleNames[s.RoleID] = roleName
+		}
+		fmt.Fprintf(sb, "  • %s %s \\(%s\\): %d\n",
+			escapeMarkdownV2(u.FirstName), escapeMarkdownV2(u.LastName), escapeMarkdownV2(roleName), s.Score)
+	}
+	sb.WriteString("\n")
+	return nil
+}
+
+// resultRisks returns the risks to list in /results. With hideBaseline,
+// scored risks in the lowest coefficient band are left out and counted
+// instead; unscored risks are always listed.
+func resultRisks(risks []domain.Risk, hideBaseline bool) ([]domain.Risk, int) {
+	if !hideBaseline {
+		return risks, 0
+	}
+	shown := make([]domain.Risk, 0, len(risks))
+	for _, risk := range risks {
+		if risk.WeightedScore != nil &&
+			scoring.RiskCoefficient(*risk.WeightedScore) == scoring.BaselineRiskCoefficient {
+			continue
+		}
+		shown = append(shown, risk)
+	}
+	return shown, len(risks) - len(shown)
+}
+
+// epicTitleMarkdown renders the bold title line of an epic report. title is
+// trusted text; the epic number and name are escaped.
+func epicTitleMarkdown(title, number, name string) string {
+	return fmt.Sprintf("📊 *%s \\#%s «%s»*\n", title, escapeMarkdownV2(number), escapeMarkdownV2(name))
+}
+
+// resultRiskMarkdown renders a risk of /results as a list item, with its
+// score and coefficient once it is scored.
+func resultRiskMarkdown(risk domain.Risk) string {
+	coeff := ""
+	if risk.WeightedScore != nil {
+		c := scoring.EffectiveRiskCoefficient(*risk.WeightedScore, risk.Importance)
+		coeff = fmt.Sprintf(" \\(оценка: %s, коэфф: %s\\)",
+			escapeMarkdownV2(fmt.Sprintf("%.2f", *risk.WeightedScore)),
+			escapeMarkdownV2(fmt.Sprintf("%.2f", c)))
+	}
+	return fmt.Sprintf("  • %s \\[%s\\]%s\n", escapeMarkdownV2(risk.Description), escapeMarkdownV2(string(risk.Status)), coeff)
+}
+
+// statusRiskMarkdown renders the heading of a risk in /epicstatus: its
+// description, cut to 40 characters, its status and how many of total
+// members scored it.
+func statusRiskMarkdown(risk domain.Risk, done, total int) string {
+	desc := risk.Description
+	if len([]rune(desc)) > 40 {
+		desc = string([]rune(desc)[:37]) + "..."
+	}
+	return fmt.Sprintf("\n*%s* \\[%s\\] %s\n",
+		escapeMarkdownV2(desc), escapeMarkdownV2(string(risk.Status)),
+		escapeMarkdownV2(progressBar(done, total)))
+}
+
+// userMarkdown renders a user as a list item with their username.
+func userMarkdown(u domain.User) string {
+	return fmt.Sprintf("  • %s %s \\(@%s\\)\n",
+		escapeMarkdownV2(u.FirstName), escapeMarkdownV2(u.LastName), escapeMarkdownV2(u.TelegramID))
 }
 
 // ─── /epicstatus logic (called by callback) ───────────────────────────────
@@ -809,7 +1407,7 @@ func (epicBot *Bot) showEpicStatusReport(ctx context.Context, msg *models.Messag
 	)
 	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, "❌ Эпик не найден.")
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
 		return
 	}
 	log.Debug(
@@ -819,7 +1417,8 @@ func (epicBot *Bot) showEpicStatusReport(ctx context.Context, msg *models.Messag
 
 	teamMembers, err := epicBot.repo.GetUsersByTeamID(ctx, epic.TeamID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка получения участников: %v", err))
+		log.Error("error getting team members", sl.Err(err))
+		epicBot.sendReply(ctx, msg, "❌ Ошибка получения участников.")
 		return
 	}
 
@@ -828,6 +1427,18 @@ func (epicBot *Bot) showEpicStatusReport(ctx context.Context, msg *models.Messag
 		slog.Int("count", len(teamMembers)),
 	)
 
+	// Effort is estimated only by members holding a required role, if any.
+	scorers, err := epicBot.repo.GetEpicScorers(ctx, epic.ID)
+	if err != nil {
+		log.Error("error getting epic scorers", sl.Err(err))
+		epicBot.sendReply(ctx, msg, "❌ Ошибка получения участников.")
+		return
+	}
+	requiredRoles, err := epicBot.repo.GetEpicRequiredRoles(ctx, epic.ID)
+	if err != nil {
+		log.Error("error getting required roles", sl.Err(err))
+	}
+
 	scoredEpic, _ := epicBot.repo.GetUsersWhoScoredEpic(ctx, epic.ID)
 	scoredSet := make(map[uuid.UUID]bool)
 	for _, u := range scoredEpic {
@@ -840,15 +1451,31 @@ func (epicBot *Bot) showEpicStatusReport(ctx context.Context, msg *models.Messag
 	)
 
 	var sb strings.Builder
-	fmt.Fprintf(&sb, "📊 *Статус оценки эпика \\#%s «%s»*\n\n",
-		escapeMarkdownV2(epic.Number), escapeMarkdownV2(epic.Name))
+	sb.WriteString(epicTitleMarkdown("Статус оценки эпика", epic.Number, epic.Name))
+	if epic.ScoringDeadline != nil {
+		fmt.Fprintf(&sb, "⏰ Дедлайн: %s\n",
+			escapeMarkdownV2(formatTime(*epic.ScoringDeadline, epicBot.teamLocation(ctx, epic.TeamID))))
+	}
+	sb.WriteString("\n")
 
-	sb.WriteString("📋 *Трудоёмкость — не оценили:*\n")
+	effortDone, err := epicBot.repo.CountEpicScores(ctx, epic.ID)
+	if err != nil {
+		log.Error("error counting epic scores", sl.Err(err))
+	}
+	fmt.Fprintf(&sb, "📋 *Трудоёмкость:* %s\n",
+		escapeMarkdownV2(progressBar(effortDone, len(scorers))))
+	if len(requiredRoles) > 0 {
+		names := make([]string, len(requiredRoles))
+		for i, r := range requiredRoles {
+			names[i] = r.Name
+		}
+		fmt.Fprintf(&sb, "Оценивают роли: %s\n", escapeMarkdownV2(strings.Join(names, ", ")))
+	}
+	sb.WriteString("Не оценили:\n")
 	missing := 0
-	for _, u := range teamMembers {
+	for _, u := range scorers {
 		if !scoredSet[u.ID] {
-			fmt.Fprintf(&sb, "  • %s %s \\(@%s\\)\n",
-				escapeMarkdownV2(u.FirstName), escapeMarkdownV2(u.LastName), escapeMarkdownV2(u.TelegramID))
+			sb.WriteString(userMarkdown(u))
 			missing++
 		}
 	}
@@ -858,24 +1485,28 @@ func (epicBot *Bot) showEpicStatusReport(ctx context.Context, msg *models.Messag
 
 	risks, _ := epicBot.repo.GetRisksByEpicID(ctx, epic.ID)
 	if len(risks) > 0 {
+		// One query for all risks instead of one per risk.
+		riskScores, err := epicBot.repo.GetRiskScoresByEpicID(ctx, epic.ID)
+		if err != nil {
+			log.Error("error getting risk scores", sl.Err(err))
+		}
+		scoredByRisk := make(map[uuid.UUID]map[uuid.UUID]bool)
+		for _, rs := range riskScores {
+			if scoredByRisk[rs.RiskID] == nil {
+				scoredByRisk[rs.RiskID] = make(map[uuid.UUID]bool)
+			}
+			scoredByRisk[rs.RiskID][rs.UserID] = true
+		}
+
 		sb.WriteString("\n⚠️ *Риски:*\n")
 		for _, risk := range risks {
-			scoredRisk, _ := epicBot.repo.GetUsersWhoScoredRisk(ctx, risk.ID)
-			riskScoredSet := make(map[uuid.UUID]bool)
-			for _, u := range scoredRisk {
-				riskScoredSet[u.ID] = true
-			}
-			desc := risk.Description
-			if len([]rune(desc)) > 40 {
-				desc = string([]rune(desc)[:37]) + "..."
-			}
-			fmt.Fprintf(&sb, "\n*%s* \\[%s\\] — не оценили:\n",
-				escapeMarkdownV2(desc), escapeMarkdownV2(string(risk.Status)))
+			riskScoredSet := scoredByRisk[risk.ID]
+			sb.WriteString(statusRiskMarkdown(risk, len(riskScoredSet), len(teamMembers)))
+			sb.WriteString("Не оценили:\n")
 			riskMissing := 0
 			for _, u := range teamMembers {
 				if !riskScoredSet[u.ID] {
-					fmt.Fprintf(&sb, "  • %s %s \\(@%s\\)\n",
-						escapeMarkdownV2(u.FirstName), escapeMarkdownV2(u.LastName), escapeMarkdownV2(u.TelegramID))
+					sb.WriteString(userMarkdown(u))
 					riskMissing++
 				}
 			}
@@ -885,12 +1516,38 @@ func (epicBot *Bot) showEpicStatusReport(ctx context.Context, msg *models.Messag
 		}
 	}
 
+	var comments strings.Builder
+	if err := epicBot.writeEpicComments(ctx, msg, &comments, epicID); err != nil {
+		log.Error("error getting epic comments", sl.Err(err))
+	}
+	if comments.Len() > 0 {
+		sb.WriteString("\n" + comments.String())
+	}
+
 	log.Debug(
 		"status report",
 		slog.String("report", sb.String()),
 	)
 
-	epicBot.sendMarkdown(ctx, msg, sb.String())
+	if _, err := epicBot.sendMarkdownOrPlain(ctx, msg, sb.String()); err != nil {
+		log.Error("failed to send status report", sl.Err(err))
+	}
+}
+
+// progressBarWidth is the maximum number of segments in a progress bar.
+const progressBarWidth = 10
+
+// progressBar renders completion as e.g. "▰▰▰▱▱ 3/5". Teams of up to
+// progressBarWidth members get one segment per member.
+func progressBar(done, total int) string {
+	if total <= 0 {
+		return fmt.Sprintf("%d/%d", done, total)
+	}
+	done = max(0, min(done, total))
+	width := min(total, progressBarWidth)
+	filled := done * width / total
+	return fmt.Sprintf("%s%s %d/%d",
+		strings.Repeat("▰", filled), strings.Repeat("▱", width-filled), done, total)
 }
 
 // ─── Session input handler ────────────────────────────────────────────────
@@ -952,9 +1609,10 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 			epicBot.editOrSend(ctx, msg, msgID, "❌ Некорректный @username. Попробуйте ещё раз:")
 			return
 		}
-		sess.Data["username"] = username
-		sess.Step = StepAddUserFirstName
-		epicBot.sessions.set(sk, sess)
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.Data["username"] = username
+			s.Step = StepAddUserFirstName
+		})
 		epicBot.editOrSend(ctx, msg, msgID, "📝 Введите имя:")
 
 	case StepAddUserFirstName:
@@ -962,9 +1620,10 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 			epicBot.editOrSend(ctx, msg, msgID, "❌ Имя не может быть пустым. Введите имя:")
 			return
 		}
-		sess.Data["firstName"] = text
-		sess.Step = StepAddUserLastName
-		epicBot.sessions.set(sk, sess)
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.Data["firstName"] = text
+			s.Step = StepAddUserLastName
+		})
 		epicBot.editOrSend(ctx, msg, msgID, "📝 Введите фамилию:")
 
 	case StepAddUserLastName:
@@ -972,9 +1631,10 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 			epicBot.editOrSend(ctx, msg, msgID, "❌ Фамилия не может быть пустой. Введите фамилию:")
 			return
 		}
-		sess.Data["lastName"] = text
-		sess.Step = StepAddUserWeight
-		epicBot.sessions.set(sk, sess)
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.Data["lastName"] = text
+			s.Step = StepAddUserWeight
+		})
 		epicBot.editOrSend(ctx, msg, msgID, "📝 Введите вес пользователя (0–100):")
 
 	case StepAddUserWeight:
@@ -987,10 +1647,17 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 			sess.Data["firstName"], sess.Data["lastName"],
 			sess.Data["username"], weight)
 		epicBot.sessions.clear(sk)
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Пользователь с таким @username уже существует.")
+			return
+		}
 		if err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Ошибка создания пользователя: %v", err))
+			log.Error("error creating user", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка создания пользователя.")
 			return
 		}
+		epicBot.recordAudit(ctx, msg.From.Username, audit.ActionUserAdded,
+			fmt.Sprintf("@%s %s %s, вес %d", user.TelegramID, user.FirstName, user.LastName, user.Weight))
 		epicBot.deleteAndSend(ctx, msg, msgID,
 			fmt.Sprintf("✅ Пользователь %s %s (@%s) создан",
 				user.FirstName, user.LastName, user.TelegramID))
@@ -1002,9 +1669,10 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 			epicBot.editOrSend(ctx, msg, msgID, "❌ Имя не может быть пустым. Введите новое имя:")
 			return
 		}
-		sess.Data["firstName"] = text
-		sess.Step = StepRenameUserLastName
-		epicBot.sessions.set(sk, sess)
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.Data["firstName"] = text
+			s.Step = StepRenameUserLastName
+		})
 		epicBot.editOrSend(ctx, msg, msgID, "📝 Введите новую фамилию:")
 
 	case StepRenameUserLastName:
@@ -1020,12 +1688,38 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 			return
 		}
 		if err := epicBot.repo.UpdateUserName(ctx, userID, sess.Data["firstName"], text); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Пользователь не найден.")
+				return
+			}
 			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка переименования.")
 			return
 		}
+		epicBot.recordAudit(ctx, msg.From.Username, audit.ActionUserRenamed,
+			fmt.Sprintf("%s → %s %s", userIDStr, sess.Data["firstName"], text))
 		epicBot.deleteAndSend(ctx, msg, msgID,
 			fmt.Sprintf("✅ Пользователь переименован: %s %s", sess.Data["firstName"], text))
 
+	// ── /renameteam interactive steps ─────────────────────────────────
+
+	case StepRenameTeamName:
+		if text == "" {
+			epicBot.editOrSend(ctx, msg, msgID, "❌ Название не может быть пустым. Введите новое название:")
+			return
+		}
+		epicBot.sessions.update(sk, func(s *Session) {
+			if text != "-" {
+				s.Data["name"] = text
+			}
+			s.Step = StepRenameTeamDesc
+		})
+		epicBot.editOrSend(ctx, msg, msgID,
+			"📝 Введите новое описание команды («=» — оставить текущее, «-» — без описания):")
+
+	case StepRenameTeamDesc:
+		epicBot.sessions.clear(sk)
+		epicBot.renameTeam(ctx, msg, msgID, sess.Data, text)
+
 	// ── /changerate interactive steps ─────────────────────────────────
 
 	case StepChangeRateWeight:
@@ -1042,40 +1736,75 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 			return
 		}
 		if err := epicBot.repo.UpdateUserWeight(ctx, userID, weight); err != nil {
+			if errors.Is(err, repositories.ErrNotFound) {
+				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Пользователь не найден.")
+				return
+			}
 			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка изменения веса.")
 			return
 		}
+		epicBot.recordAudit(ctx, msg.From.Username, audit.ActionWeightChanged,
+			fmt.Sprintf("%s → %d", userIDStr, weight))
 		epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("✅ Вес пользователя изменён на %d", weight))
 
 	// ── /addepic interactive steps ─────────────────────────────────────
 
 	case StepAddEpicNumber:
-		sess.Data["number"] = text
-		epic, _ := epicBot.repo.GetEpicByNumber(ctx, sess.Data["number"])
-		// if err != nil {
-		// 	epicBot.editOrSend(ctx, msg, msgID, "❌ Ошибка поиска эпика.")
-		// 	return
-		// }
-		if epic != nil {
-			epicBot.editOrSend(ctx, msg, msgID, "❌ Эпик с таким номером уже существует.")
+		number := strings.TrimSpace(text)
+		if problem := epicBot.epicNumberProblem(number); problem != "" {
+			epicBot.editOrSend(ctx, msg, msgID, problem+" Введите номер эпика:")
+			return
+		}
+		teamID, err := uuid.Parse(sess.Data["teamID"])
+		if err != nil {
+			epicBot.sessions.clear(sk)
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка: неверный ID команды.")
+			return
+		}
+		_, err = epicBot.repo.GetEpicByNumberAndTeam(ctx, number, teamID)
+		switch {
+		case err == nil:
+			epicBot.editOrSend(ctx, msg, msgID, "❌ В команде уже есть эпик с таким номером. Введите другой номер:")
+			return
+		case !errors.Is(err, repositories.ErrNotFound):
+			log.Error("error finding epic", sl.Err(err))
+			epicBot.sessions.clear(sk)
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка поиска эпика.")
 			return
 		}
 
-		sess.Step = StepAddEpicName
-		epicBot.sessions.set(sk, sess)
+		// Stored only once it is known to be free.
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.Data["number"] = number
+			s.Step = StepAddEpicName
+		})
 		epicBot.editOrSend(ctx, msg, msgID, "📝 Введите название эпика:")
 
 	case StepAddEpicName:
-		sess.Data["name"] = text
-		sess.Step = StepAddEpicDesc
-		epicBot.sessions.set(sk, sess)
+		name := strings.TrimSpace(text)
+		if name == "" {
+			epicBot.editOrSend(ctx, msg, msgID, "❌ Название не может быть пустым. Введите название эпика:")
+			return
+		}
+		if problem := textLengthProblem("Название", name, epicBot.cfg.Limits.EpicName); problem != "" {
+			epicBot.editOrSend(ctx, msg, msgID, problem+" Введите название короче:")
+			return
+		}
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.Data["name"] = name
+			s.Step = StepAddEpicDesc
+		})
 		epicBot.editOrSend(ctx, msg, msgID, "📝 Введите описание эпика (или напишите «-» чтобы пропустить):")
 
 	case StepAddEpicDesc:
-		desc := text
+		desc := strings.TrimSpace(text)
 		if desc == "-" {
 			desc = ""
 		}
+		if problem := textLengthProblem("Описание", desc, epicBot.cfg.Limits.Description); problem != "" {
+			epicBot.editOrSend(ctx, msg, msgID, problem+" Введите описание короче (или «-» чтобы пропустить):")
+			return
+		}
 		teamIDStr := sess.Data["teamID"]
 		epicBot.sessions.clear(sk)
 		teamID, err := uuid.Parse(teamIDStr)
@@ -1084,99 +1813,82 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 			return
 		}
 
-		epic, _ := epicBot.repo.GetEpicByNumber(ctx, sess.Data["number"])
-		// if err != nil {
-		// 	epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка поиска эпика.")
-		// 	return
-		// }
-		if epic != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Эпик с таким номером уже существует.")
-			return
-		}
-
-		epic, err = epicBot.repo.CreateEpic(ctx, sess.Data["number"], sess.Data["name"], desc, teamID)
+		// The number may have been taken while the name was entered; the
+		// unique index decides.
+		epic, err := epicBot.repo.CreateEpic(ctx, sess.Data["number"], sess.Data["name"], desc, teamID)
 		if err != nil {
+			switch {
+			case errors.Is(err, repositories.ErrAlreadyExists):
+				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Эпик с таким номером уже существует.")
+				return
+			case errors.Is(err, repositories.ErrInvalidInput):
+				epicBot.deleteAndSend(ctx, msg, msgID, "❌ Название или описание эпика не подходит по длине.")
+				return
+			}
+			log.Error("error creating epic", sl.Err(err))
 			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка создания эпика.")
 			return
 		}
+		epicBot.recordAudit(ctx, msg.From.Username, audit.ActionEpicCreated,
+			fmt.Sprintf("#%s %s", epic.Number, epic.Name))
 		epicBot.deleteAndSend(ctx, msg, msgID,
-			fmt.Sprintf("✅ Эпик #%s «%s» создан (статус: NEW)", epic.Number, epic.Name))
+			epicBot.t(ctx, msg, "epic.created", epic.Number, epic.Name))
+
+	// ── /duplicateepic interactive steps ───────────────────────────────
+
+	case StepDuplicateEpicNumber:
+		epicBot.askDuplicateEpicTeam(ctx, msg, sk, sess, strings.TrimSpace(text))
 
 	// ── /addrisk interactive steps ─────────────────────────────────────
 
 	case StepAddRiskDesc:
-		epicIDStr := sess.Data["epicID"]
-		epicBot.sessions.clear(sk)
-		epicID, err := uuid.Parse(epicIDStr)
-		if err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка: неверный ID эпика.")
+		desc := strings.TrimSpace(text)
+		if desc == "" {
+			epicBot.editOrSend(ctx, msg, msgID, "❌ Описание не может быть пустым. Введите описание риска:")
 			return
 		}
-		risk, err := epicBot.repo.CreateRisk(ctx, text, epicID)
-		if err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Ошибка создания риска: %v", err))
+		if problem := textLengthProblem("Описание", desc, epicBot.cfg.Limits.Description); problem != "" {
+			epicBot.editOrSend(ctx, msg, msgID, problem+" Введите описание риска короче:")
 			return
 		}
-		epic, _ := epicBot.repo.GetEpicByID(ctx, epicID)
-		epicNum := epicID.String()
-		if epic != nil {
-			epicNum = epic.Number
-		}
-		epicBot.deleteAndSend(ctx, msg, msgID,
-			fmt.Sprintf("✅ Риск создан для эпика #%s (ID: %s)", epicNum, risk.ID))
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.Data["riskDesc"] = desc
+			s.Step = StepAddRiskImportance
+		})
+		epicBot.editOrSendWithKeyboard(ctx, msg, msgID,
+			"⚖️ Выберите важность риска (насколько сильно он влияет на итоговую оценку):",
+			riskImportanceKeyboard())
 
 	// ── /score epic effort text-input step ────────────────────────────
 
 	case StepScoreEpicEffort:
 		score, err := strconv.Atoi(text)
-		if err != nil || score < 0 || score > 500 {
+		if err == nil {
+			err = epicBot.scoring.ValidateEffort(score)
+		}
+		if err != nil {
 			epicBot.editOrSend(ctx, msg, msgID,
-				"❌ Некорректный ввод. Введите целое число от 0 до 500:")
+				fmt.Sprintf("❌ Некорректный ввод. Введите целое число от %d до %d:",
+					scoring.MinEffortScore, epicBot.cfg.Scoring.EffortMax()))
 			return
 		}
 
-		epicIDStr := sess.Data["epicID"]
 		username := sess.Data["username"]
 		epicBot.sessions.clear(sk)
 
-		epicID, err := uuid.Parse(epicIDStr)
+		epicID, err := uuid.Parse(sess.Data["epicID"])
 		if err != nil {
 			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка: неверный ID эпика.")
 			return
 		}
+		epicBot.submitEpicScore(ctx, msg, msgID, username, epicID, score, false)
 
-		user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
-		if err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Пользователь не найден.")
-			return
-		}
-
-		role, err := epicBot.repo.GetRoleByUserID(ctx, user.ID)
-		if err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, "❌ У вас нет назначенной роли.")
-			return
-		}
-
-		if err := epicBot.repo.CreateEpicScore(ctx, epicID, user.ID, role.ID, score); err != nil {
-			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Ошибка сохранения оценки: %v", err))
-			return
-		}
-
-		epic, _ := epicBot.repo.GetEpicByID(ctx, epicID)
-		epicNum := epicIDStr
-		if epic != nil {
-			epicNum = epic.Number
-		}
-		epicBot.deleteAndSend(ctx, msg, msgID,
-			fmt.Sprintf("✅ Оценка %d для эпика #%s сохранена!", score, epicNum))
-
-		if err := epicBot.scoring.TryCompleteEpicScoring(ctx, epicID); err != nil {
-			epicBot.log.Error("failed to try complete epic scoring",
-				slog.String("epicID", epicID.String()), sl.Err(err))
-		}
+	case StepEpicComment:
+		epicBot.saveEpicComment(ctx, msg, sk, sess, text)
 
-		// Show unscored risks if any remain.
-		epicBot.showEpicRisks(ctx, msg, username, epicID)
+	case StepScoreRiskReply:
+		// Only replies to the risk prompt are accepted (see handleRiskReply);
+		// unrelated chatter in a group must not drop the prompt.
 
 	default:
 		epicBot.sessions.clear(sk)
@@ -1185,35 +1897,45 @@ func (epicBot *Bot) handleSessionInput(update *models.Update) {
 
 // ─── /startscore execution (called by callback) ───────────────────────────
 
-func (epicBot *Bot) execStartScore(ctx context.Context, msg *models.Message, epicID uuid.UUID) {
+func (epicBot *Bot) execStartScore(ctx context.Context, msg *models.Message, actor string, epicID uuid.UUID, deadline time.Duration) {
 	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
 	if err != nil {
-		epicBot.sendReply(ctx, msg, "❌ Эпик не найден.")
-		return
-	}
-	if epic.Status != domain.StatusNew {
-		epicBot.sendReply(ctx, msg,
-			fmt.Sprintf("⚠️ Эпик #%s уже в статусе %s.", epic.Number, string(epic.Status)))
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
 		return
 	}
-	if err := epicBot.repo.UpdateEpicStatus(ctx, epic.ID, domain.StatusScoring); err != nil {
-		epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка смены статуса эпика: %v", err))
+	risks, started, err := epicBot.repo.StartEpicScoring(ctx, epic.ID)
+	if err != nil {
+		epicBot.log.Error("error starting epic scoring", sl.Err(err))
+		epicBot.sendReply(ctx, msg, "❌ Ошибка смены статуса эпика.")
 		return
 	}
-	risks, err := epicBot.repo.GetRisksByEpicID(ctx, epic.ID)
-	if err != nil {
-		epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка получения рисков: %v", err))
+	if !started {
+		// Re-read: the status may have changed since the picker was shown.
+		if current, err := epicBot.repo.GetEpicByID(ctx, epic.ID); err == nil {
+			epic = current
+		}
+		epicBot.sendReply(ctx, msg,
+			fmt.Sprintf("⚠️ Эпик #%s уже в статусе %s.", epic.Number, string(epic.Status)))
 		return
 	}
-	for _, risk := range risks {
-		if err := epicBot.repo.UpdateRiskStatus(ctx, risk.ID, domain.StatusScoring); err != nil {
-			epicBot.log.Error("failed to update risk status",
-				slog.String("riskID", risk.ID.String()), sl.Err(err))
+	epicBot.recordAudit(ctx, actor, audit.ActionScoringStarted, "#"+epic.Number)
+	text := fmt.Sprintf("🚀 Эпик #%s «%s» и %d рисков отправлены на оценку!",
+		epic.Number, epic.Name, risks)
+	if deadline > 0 {
+		due := time.Now().Add(deadline)
+		if err := epicBot.repo.SetEpicScoringDeadline(ctx, epic.ID, &due); err != nil {
+			epicBot.log.Error("failed to set scoring deadline",
+				slog.String("epicID", epic.ID.String()), sl.Err(err))
+			text += "\n⚠️ Не удалось установить дедлайн оценки."
+		} else {
+			text += fmt.Sprintf("\n⏰ Оценка закроется автоматически %s.",
+				formatTime(due, epicBot.teamLocation(ctx, epic.TeamID)))
 		}
 	}
-	epicBot.sendReply(ctx, msg,
-		fmt.Sprintf("🚀 Эпик #%s «%s» и %d рисков отправлены на оценку!",
-			epic.Number, epic.Name, len(risks)))
+	if link := epicBot.scoreDeepLink(epic.ID); link != "" {
+		text += "\n👉 Оценить: " + link
+	}
+	epicBot.sendReply(ctx, msg, text)
 }
 
 func (epicBot *Bot) handleAddAdmin(ctx context.Context, msg *models.Message) error {
@@ -1223,8 +1945,8 @@ func (epicBot *Bot) handleAddAdmin(ctx context.Context, msg *models.Message) err
 		slog.Int64("chatID", msg.Chat.ID),
 	)
 
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	args := strings.TrimSpace(commandArguments(msg))
@@ -1234,15 +1956,28 @@ func (epicBot *Bot) handleAddAdmin(ctx context.Context, msg *models.Message) err
 	}
 	username := strings.TrimPrefix(args, "@")
 
-	epicBot.cfg.BotConfig.Admins = append(epicBot.cfg.BotConfig.Admins, username)
+	// The list is read again under the lock, so concurrent commands see
+	// each other's changes and the file is written in the same order.
+	epicBot.adminsMu.Lock()
+	prev := epicBot.cfg.BotConfig.Admins
+	if slices.Contains(prev, username) {
+		epicBot.adminsMu.Unlock()
+		_, err := epicBot.sendReply(ctx, msg, fmt.Sprintf("ℹ️ @%s уже администратор.", username))
+		return err
+	}
+	epicBot.cfg.BotConfig.Admins = append(slices.Clip(prev), username)
 	err := epicBot.cfg.Write()
 	if err != nil {
-		epicBot.cfg.BotConfig.Admins = epicBot.cfg.BotConfig.Admins[:len(epicBot.cfg.BotConfig.Admins)-1]
+		epicBot.cfg.BotConfig.Admins = prev
+	}
+	epicBot.adminsMu.Unlock()
+	if err != nil {
 		log.Error("failed to add admin", slog.String("username", username), sl.Err(err))
-		_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка добавления администратора: %v", err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка добавления администратора.")
 		return retErr
 	}
 	log.Info("admin added", slog.String("username", username))
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionAdminAdded, "@"+username)
 	_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("✅ Администратор @%s добавлен.", username))
 	return retErr
 }
@@ -1254,8 +1989,8 @@ func (epicBot *Bot) handleRemoveAdmin(ctx context.Context, msg *models.Message)
 		slog.Int64("chatID", msg.Chat.ID),
 	)
 
-	if !epicBot.isSuperAdmin(msg) {
-		_, err := epicBot.sendReply(ctx, msg, "⛔ Только для супер-администраторов.")
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
 		return err
 	}
 	args := strings.TrimSpace(commandArguments(msg))
@@ -1265,23 +2000,29 @@ func (epicBot *Bot) handleRemoveAdmin(ctx context.Context, msg *models.Message)
 	}
 	username := strings.TrimPrefix(args, "@")
 
-	idx := slices.Index(epicBot.cfg.BotConfig.Admins, username)
+	epicBot.adminsMu.Lock()
+	prev := epicBot.cfg.BotConfig.Admins
+	idx := slices.Index(prev, username)
 	if idx == -1 {
+		epicBot.adminsMu.Unlock()
 		_, err := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Администратор @%s не найден.", username))
 		return err
 	}
+	epicBot.cfg.BotConfig.Admins = slices.Delete(slices.Clone(prev), idx, idx+1)
+	err := epicBot.cfg.Write()
+	if err != nil {
+		epicBot.cfg.BotConfig.Admins = prev
+	}
+	epicBot.adminsMu.Unlock()
 
-	removed := epicBot.cfg.BotConfig.Admins[idx]
-	epicBot.cfg.BotConfig.Admins = slices.Delete(epicBot.cfg.BotConfig.Admins, idx, idx+1)
-
-	if err := epicBot.cfg.Write(); err != nil {
-		epicBot.cfg.BotConfig.Admins = slices.Insert(epicBot.cfg.BotConfig.Admins, idx, removed)
+	if err != nil {
 		log.Error("failed to remove admin", slog.String("username", username), sl.Err(err))
-		_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка удаления администратора: %v", err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка удаления администратора.")
 		return retErr
 	}
 
 	log.Info("admin removed", slog.String("username", username))
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionAdminRemoved, "@"+username)
 	_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("✅ Администратор @%s удалён.", username))
 	return retErr
 }
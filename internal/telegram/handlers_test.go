@@ -0,0 +1,308 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+)
+
+// markdownSpecials holds every character reserved by MarkdownV2, and
+// escapedSpecials the same characters as they must appear in a message.
+const (
+	markdownSpecials = "_*[]()~`>#+-=|{}.!\\"
+	escapedSpecials  = "\\_\\*\\[\\]\\(\\)\\~\\`\\>\\#\\+\\-\\=\\|\\{\\}\\.\\!\\\\"
+)
+
+func TestEpicTitleMarkdown(t *testing.T) {
+	tests := []struct {
+		name   string
+		number string
+		epic   string
+		want   string
+	}{
+		{"plain", "7", "Login", "📊 *Результаты \\#7 «Login»*\n"},
+		{"specials", "EP-1.2", "a" + markdownSpecials + "b", "📊 *Результаты \\#EP\\-1\\.2 «a" + escapedSpecials + "b»*\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := epicTitleMarkdown("Результаты", tt.number, tt.epic); got != tt.want {
+				t.Errorf("epicTitleMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		user domain.User
+		want string
+	}{
+		{"plain", domain.User{FirstName: "Ann", LastName: "Lee", TelegramID: "ann"}, "  • Ann Lee \\(@ann\\)\n"},
+		{
+			"specials",
+			domain.User{FirstName: markdownSpecials, LastName: "O'Neil-Smith", TelegramID: "ann_lee"},
+			"  • " + escapedSpecials + " O'Neil\\-Smith \\(@ann\\_lee\\)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := userMarkdown(tt.user); got != tt.want {
+				t.Errorf("userMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResultRiskMarkdown(t *testing.T) {
+	score := 9.4
+	tests := []struct {
+		name string
+		risk domain.Risk
+		want string
+	}{
+		{
+			"unscored",
+			domain.Risk{Description: "API " + markdownSpecials, Status: domain.StatusNew},
+			"  • API " + escapedSpecials + " \\[NEW\\]\n",
+		},
+		{
+			"scored",
+			domain.Risk{Description: "DB (v2)", Status: domain.StatusScored, WeightedScore: &score, Importance: domain.ImportanceHigh},
+			"  • DB \\(v2\\) \\[SCORED\\] \\(оценка: 9\\.40, коэфф: 1\\.30\\)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resultRiskMarkdown(tt.risk); got != tt.want {
+				t.Errorf("resultRiskMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusRiskMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		desc  string
+		done  int
+		total int
+		want  string
+	}{
+		{"specials", markdownSpecials, 1, 2, "\n*" + escapedSpecials + "* \\[NEW\\] ▰▱ 1/2\n"},
+		{
+			"long description is cut",
+			"0123456789012345678901234567890123456789-tail",
+			0, 1,
+			"\n*0123456789012345678901234567890123456\\.\\.\\.* \\[NEW\\] ▱ 0/1\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			risk := domain.Risk{Description: tt.desc, Status: domain.StatusNew}
+			if got := statusRiskMarkdown(risk, tt.done, tt.total); got != tt.want {
+				t.Errorf("statusRiskMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		name        string
+		done, total int
+		want        string
+	}{
+		{"nobody to score", 0, 0, "0/0"},
+		{"none done", 0, 4, "▱▱▱▱ 0/4"},
+		{"half done", 2, 4, "▰▰▱▱ 2/4"},
+		{"all done", 4, 4, "▰▰▰▰ 4/4"},
+		{"width is capped", 5, 20, "▰▰▱▱▱▱▱▱▱▱ 5/20"},
+		{"partial cell rounds down", 19, 20, "▰▰▰▰▰▰▰▰▰▱ 19/20"},
+		{"more done than total", 5, 4, "▰▰▰▰ 4/4"},
+		{"negative done", -1, 3, "▱▱▱ 0/3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := progressBar(tt.done, tt.total); got != tt.want {
+				t.Errorf("progressBar(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextLengthProblem(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  string
+	}{
+		{"fits", "Вход", 4, ""},
+		{"no limit", "Вход через SSO", 0, ""},
+		{"too long", "Вход через SSO", 10, "❌ Название эпика длиннее 10 символов (сейчас 14)."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := textLengthProblem("Название эпика", tt.text, tt.limit); got != tt.want {
+				t.Errorf("textLengthProblem() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEpicNumberProblem(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		number  string
+		want    string
+	}{
+		{"any number", "", "whatever-1", ""},
+		{"empty", "", "", "❌ Номер не может быть пустым."},
+		{"spaces", "", "EP 1", "❌ Номер не должен содержать пробелов."},
+		{"matches the pattern", `EP-\d+`, "EP-12", ""},
+		{"pattern must match in full", `EP-\d+`, "XEP-12a", "❌ Номер должен соответствовать шаблону EP-\\d+."},
+		{"alternatives are anchored together", `EP-\d+|OPS-\d+`, "OPS-3x", "❌ Номер должен соответствовать шаблону EP-\\d+|OPS-\\d+."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epicBot := &Bot{cfg: &config.Config{BotConfig: config.BotConfig{EpicNumberPattern: tt.pattern}}}
+			if tt.pattern != "" {
+				re, err := compileEpicNumberPattern(tt.pattern)
+				if err != nil {
+					t.Fatalf("compileEpicNumberPattern(%q) error = %v", tt.pattern, err)
+				}
+				epicBot.epicNumber = re
+			}
+			if got := epicBot.epicNumberProblem(tt.number); got != tt.want {
+				t.Errorf("epicNumberProblem(%q) = %q, want %q", tt.number, got, tt.want)
+			}
+		})
+	}
+}
+
+// startSession puts the test user of testMessage into a session step.
+func startSession(epicBot *Bot, step SessionStep, data map[string]string) sessionKey {
+	sk := sessionKey{ChatID: 1, Username: "ann"}
+	epicBot.sessions.set(sk, &Session{Step: step, Username: "ann", MessageID: 5, Data: data})
+	return sk
+}
+
+func TestAddEpicNumberChecksTheTypedNumber(t *testing.T) {
+	teamID := uuid.New()
+	repo := &fakeRepo{epic: &domain.Epic{Number: "EP-1", TeamID: teamID}}
+	epicBot, _ := newTestBot(t, nil, repo)
+	// A number left over from an earlier attempt must not be checked
+	// instead of the new one.
+	sk := startSession(epicBot, StepAddEpicNumber, map[string]string{"teamID": teamID.String(), "number": "EP-1"})
+
+	epicBot.handleSessionInput(&models.Update{Message: testMessage(" EP-2 ")})
+
+	if want := []string{"EP-2"}; !slices.Equal(repo.lookedUpNumbers, want) {
+		t.Errorf("looked up %q, want %q", repo.lookedUpNumbers, want)
+	}
+	sess, ok := epicBot.sessions.get(sk)
+	if !ok {
+		t.Fatal("session ended")
+	}
+	if sess.Step != StepAddEpicName || sess.Data["number"] != "EP-2" {
+		t.Errorf("session = %s with number %q, want %s with EP-2", sess.Step, sess.Data["number"], StepAddEpicName)
+	}
+}
+
+func TestAddEpicNumberTaken(t *testing.T) {
+	teamID := uuid.New()
+	repo := &fakeRepo{epic: &domain.Epic{Number: "EP-1", TeamID: teamID}}
+	epicBot, api := newTestBot(t, nil, repo)
+	sk := startSession(epicBot, StepAddEpicNumber, map[string]string{"teamID": teamID.String()})
+
+	epicBot.handleSessionInput(&models.Update{Message: testMessage("EP-1")})
+
+	sess, ok := epicBot.sessions.get(sk)
+	if !ok || sess.Step != StepAddEpicNumber || sess.Data["number"] != "" {
+		t.Errorf("session = %+v, want it to wait for another number", sess)
+	}
+	if texts := api.Texts(); len(texts) != 1 || !strings.Contains(texts[0], "уже есть эпик") {
+		t.Errorf("replies = %q, want a taken-number message", texts)
+	}
+}
+
+func TestAddEpicNumberTakenMeanwhile(t *testing.T) {
+	repo := &fakeRepo{createErr: fmt.Errorf("Repository.CreateEpic: %w", repositories.ErrAlreadyExists)}
+	epicBot, api := newTestBot(t, nil, repo)
+	sk := startSession(epicBot, StepAddEpicDesc, map[string]string{
+		"teamID": uuid.NewString(), "number": "EP-1", "name": "Login",
+	})
+
+	// Another admin created EP-1 after the number step had checked it.
+	epicBot.handleSessionInput(&models.Update{Message: testMessage("-")})
+
+	if _, ok := epicBot.sessions.get(sk); ok {
+		t.Error("session still active")
+	}
+	texts := api.Texts()
+	if len(texts) != 1 || !strings.Contains(texts[0], "уже существует") {
+		t.Errorf("replies = %q, want an already-exists message", texts)
+	}
+}
+
+func TestAdminChangesConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	initial := "bot:\n  tgbot_apitoken: token\n  superadmins: [ann]\n  admins: [old0, old1, old2, old3]\n"
+	if err := os.WriteFile(path, []byte(initial), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	epicBot, _ := newTestBot(t, config.MustLoadPath(path), &fakeRepo{})
+	ctx := context.Background()
+
+	const n = 4
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			epicBot.handleAddAdmin(ctx, testCommand(fmt.Sprintf("/addadmin @new%d", i)))
+		}()
+		go func() {
+			defer wg.Done()
+			epicBot.handleRemoveAdmin(ctx, testCommand(fmt.Sprintf("/removeadmin old%d", i)))
+		}()
+		go func() {
+			defer wg.Done()
+			epicBot.isAdmin(fromMessage(testMessage("/start")))
+		}()
+	}
+	wg.Wait()
+
+	want := []string{"new0", "new1", "new2", "new3"}
+	got := slices.Sorted(slices.Values(epicBot.cfg.BotConfig.Admins))
+	if !slices.Equal(got, want) {
+		t.Errorf("admins = %v, want %v", got, want)
+	}
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written config.Config
+	if err := yaml.Unmarshal(buf, &written); err != nil {
+		t.Fatalf("unmarshal written config: %v", err)
+	}
+	if got := slices.Sorted(slices.Values(written.BotConfig.Admins)); !slices.Equal(got, want) {
+		t.Errorf("admins on disk = %v, want %v", got, want)
+	}
+}
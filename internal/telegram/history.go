@@ -0,0 +1,68 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ─── /history ─────────────────────────────────────────────────────────────
+
+// handleEpicHistory shows when an epic was created and moved between
+// statuses. Usage: /history <number>
+func (epicBot *Bot) handleEpicHistory(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleEpicHistory"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	number := strings.TrimPrefix(strings.TrimSpace(commandArguments(msg)), "#")
+	if number == "" {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "history.usage"))
+		return err
+	}
+
+	epic, err := epicBot.repo.GetEpicByNumber(ctx, number)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.epicNumberErrorText(ctx, msg, err, number))
+		return retErr
+	}
+	history, err := epicBot.repo.GetEpicStatusHistory(ctx, epic.ID)
+	if err != nil {
+		log.Error("error getting epic status history", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "history.error"))
+		return retErr
+	}
+
+	loc := epicBot.teamLocation(ctx, epic.TeamID)
+	_, err = epicBot.sendReply(ctx, msg, epicBot.renderEpicHistory(ctx, msg, epic, history, loc))
+	return err
+}
+
+// renderEpicHistory formats the timeline of an epic, starting with its
+// creation, in the language of the chat.
+func (epicBot *Bot) renderEpicHistory(
+	ctx context.Context,
+	msg *models.Message,
+	epic *domain.Epic,
+	history []domain.EpicStatusChange,
+	loc *time.Location,
+) string {
+	var sb strings.Builder
+	sb.WriteString(epicBot.t(ctx, msg, "history.title", epic.Number, epic.Name) + "\n\n")
+	fmt.Fprintln(&sb, epicBot.t(ctx, msg, "history.created", formatTime(epic.CreatedAt, loc)))
+	for _, c := range history {
+		fmt.Fprintf(&sb, "%s — %s → %s\n", formatTime(c.ChangedAt, loc), c.From, c.To)
+	}
+	if len(history) == 0 {
+		sb.WriteString("\n" + epicBot.t(ctx, msg, "history.unchanged", epic.Status))
+	}
+	return sb.String()
+}
@@ -0,0 +1,176 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/importer"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// maxImportFileSize limits the size of an uploaded import file.
+const maxImportFileSize = 1 << 20
+
+// upsertFlag makes /importepics update epics whose number already exists.
+const upsertFlag = "--upsert"
+
+// ─── /importepics ─────────────────────────────────────────────────────────
+
+// handleImportEpics creates epics from a CSV document sent with the caption
+// /importepics <team> [--upsert]. Columns: number,name[,description].
+func (epicBot *Bot) handleImportEpics(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleImportEpics"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+
+	var upsert bool
+	var teamWords []string
+	for _, word := range strings.Fields(commandArguments(msg)) {
+		if word == upsertFlag {
+			upsert = true
+			continue
+		}
+		teamWords = append(teamWords, word)
+	}
+	teamName := strings.Join(teamWords, " ")
+	if msg.Document == nil || teamName == "" {
+		_, err := epicBot.sendReply(ctx, msg,
+			"⚠️ Отправьте CSV-файл с подписью /importepics <команда> [--upsert]\n"+
+				"Колонки: number,name,description (описание необязательно).\n"+
+				"С --upsert существующие эпики обновляются, иначе импорт отклоняется.")
+		return err
+	}
+
+	team, err := epicBot.repo.GetTeamByName(ctx, teamName)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "team.name_not_found", teamName))
+		return retErr
+	}
+
+	data, err := epicBot.downloadFile(ctx, msg.Document.FileID, maxImportFileSize)
+	if err != nil {
+		log.Error("error downloading import file", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Не удалось получить файл.")
+		return retErr
+	}
+
+	rows, rowErrs, err := importer.ParseEpicsCSV(bytes.NewReader(data))
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Ошибка чтения CSV: %v", err))
+		return retErr
+	}
+	rows, rowErrs = epicBot.dropOverlongRows(rows, rowErrs)
+	if len(rows) == 0 {
+		_, err := epicBot.sendReply(ctx, msg, "❌ В файле нет корректных строк.\n"+formatRowErrors(rowErrs))
+		return err
+	}
+
+	numbers := make([]string, 0, len(rows))
+	epics := make([]domain.Epic, 0, len(rows))
+	for _, row := range rows {
+		numbers = append(numbers, row.Number)
+		epics = append(epics, domain.Epic{
+			Number:      row.Number,
+			Name:        row.Name,
+			Description: row.Description,
+		})
+	}
+
+	if !upsert {
+		existing, err := epicBot.repo.GetExistingEpicNumbers(ctx, team.ID, numbers)
+		if err != nil {
+			log.Error("error checking existing epics", sl.Err(err))
+			_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка проверки существующих эпиков.")
+			return retErr
+		}
+		if len(existing) > 0 {
+			_, err := epicBot.sendReply(ctx, msg,
+				fmt.Sprintf("❌ Импорт отклонён: в команде уже есть эпики: #%s\n"+
+					"Добавьте %s в подпись, чтобы обновить их.",
+					strings.Join(existing, ", #"), upsertFlag))
+			return err
+		}
+	}
+
+	created, updated, err := epicBot.repo.ImportEpics(ctx, team.ID, epics, upsert)
+	if errors.Is(err, repositories.ErrAlreadyExists) {
+		_, retErr := epicBot.sendReply(ctx, msg,
+			"❌ Импорт отменён: эпики с такими номерами только что добавили в команду.")
+		return retErr
+	}
+	if err != nil {
+		log.Error("error importing epics", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Импорт отменён: ошибка сохранения эпиков.")
+		return retErr
+	}
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionEpicsImported,
+		fmt.Sprintf("%s: создано %d, обновлено %d", team.Name, created, updated))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "✅ Импорт в команду «%s» завершён.\n", team.Name)
+	fmt.Fprintf(&sb, "Создано эпиков: %d\n", created)
+	if upsert {
+		fmt.Fprintf(&sb, "Обновлено эпиков: %d\n", updated)
+	}
+	if len(rowErrs) > 0 {
+		sb.WriteString("\n" + formatRowErrors(rowErrs))
+	}
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}
+
+// dropOverlongRows moves rows whose name or description is over the
+// configured limits to the row errors, keeping those ordered by line.
+func (epicBot *Bot) dropOverlongRows(
+	rows []importer.EpicRow,
+	rowErrs []importer.RowError,
+) ([]importer.EpicRow, []importer.RowError) {
+	limits := epicBot.cfg.Limits
+	kept := rows[:0]
+	dropped := false
+	for _, row := range rows {
+		problem := textLengthProblem("Название", row.Name, limits.EpicName)
+		if problem == "" {
+			problem = textLengthProblem("Описание", row.Description, limits.Description)
+		}
+		if problem != "" {
+			rowErrs = append(rowErrs, importer.RowError{Line: row.Line, Reason: strings.TrimPrefix(problem, "❌ ")})
+			dropped = true
+			continue
+		}
+		kept = append(kept, row)
+	}
+	if dropped {
+		slices.SortFunc(rowErrs, func(a, b importer.RowError) int { return a.Line - b.Line })
+	}
+	return kept, rowErrs
+}
+
+// formatRowErrors lists rejected import lines with reasons.
+func formatRowErrors(rowErrs []importer.RowError) string {
+	if len(rowErrs) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "⚠️ Пропущено строк: %d\n", len(rowErrs))
+	for _, re := range rowErrs {
+		fmt.Fprintf(&sb, "  строка %d: %s\n", re.Line, re.Reason)
+	}
+	return sb.String()
+}
@@ -2,8 +2,10 @@ package telegram
 
 import (
 	"context"
+	"time"
 
 	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/scoring"
 
 	"github.com/google/uuid"
 )
@@ -15,58 +17,140 @@ type Repository interface {
 	FindUserByTelegramID(ctx context.Context, telegramID string) (*domain.User, error)
 	GetUserByID(ctx context.Context, userID uuid.UUID) (*domain.User, error)
 	GetUsersByTeamID(ctx context.Context, teamID uuid.UUID) ([]domain.User, error)
+	GetTeamMembersWithChatID(ctx context.Context, teamID uuid.UUID) ([]domain.User, error)
+	UpdateWeights(ctx context.Context, weights map[uuid.UUID]int) error
+	UpdateUserChatID(ctx context.Context, userID uuid.UUID, chatID int64) error
 	GetAllUsers(ctx context.Context) ([]domain.User, error)
-	DeleteUser(ctx context.Context, userID uuid.UUID) error
+	GetUsersPage(ctx context.Context, limit, offset int) ([]domain.User, error)
+	IsUserInTeam(ctx context.Context, userID, teamID uuid.UUID) (bool, error)
+	DeleteUserTx(ctx context.Context, userID uuid.UUID) (domain.UserSnapshot, error)
 	UpdateUserName(ctx context.Context, userID uuid.UUID, firstName, lastName string) error
 	UpdateUserWeight(ctx context.Context, userID uuid.UUID, weight int) error
 
 	// Roles
 	GetAllRoles(ctx context.Context) ([]domain.Role, error)
+	CreateRole(ctx context.Context, name, description string) (*domain.Role, error)
+	GetRolesWithUserCounts(ctx context.Context) ([]domain.RoleWithUserCount, error)
+	GetRoleReferences(ctx context.Context, roleID uuid.UUID) (domain.RoleReferences, error)
+	DeleteRole(ctx context.Context, roleID uuid.UUID) error
 	GetRoleByID(ctx context.Context, roleID uuid.UUID) (*domain.Role, error)
 	GetRoleByUserID(ctx context.Context, userID uuid.UUID) (*domain.Role, error)
 	AssignUserRole(ctx context.Context, userID, roleID uuid.UUID) error
-	RemoveUserRole(ctx context.Context, userID, roleID uuid.UUID) error
+	RemoveUserRole(ctx context.Context, userID, roleID uuid.UUID) (int, error)
+	ReplaceUserRole(ctx context.Context, userID, newRoleID uuid.UUID) error
 
 	// Teams
 	CreateTeam(ctx context.Context, name, description string) (*domain.Team, error)
 	GetTeamByName(ctx context.Context, name string) (*domain.Team, error)
 	GetTeamByID(ctx context.Context, teamID uuid.UUID) (*domain.Team, error)
+	SetTeamTimezone(ctx context.Context, teamID uuid.UUID, timezone string) error
 	GetAllTeams(ctx context.Context) ([]domain.Team, error)
+	GetTeams(ctx context.Context, includeArchived bool) ([]domain.Team, error)
+	ArchiveTeam(ctx context.Context, teamID uuid.UUID) error
+	UnarchiveTeam(ctx context.Context, teamID uuid.UUID) error
 	GetTeamsByUserTelegramID(ctx context.Context, telegramID string) ([]domain.Team, error)
 	AssignUserTeam(ctx context.Context, userID, teamID uuid.UUID) error
-	RemoveUserTeam(ctx context.Context, userID, teamID uuid.UUID) error
+	RemoveUserTeam(ctx context.Context, userID, teamID uuid.UUID) (int, error)
+	MergeTeams(ctx context.Context, fromID, toID uuid.UUID, deleteSource bool) (int, int, error)
 
 	// Epics
 	CreateEpic(ctx context.Context, number, name, description string, teamID uuid.UUID) (*domain.Epic, error)
 	GetEpicByID(ctx context.Context, epicID uuid.UUID) (*domain.Epic, error)
 	GetEpicByNumber(ctx context.Context, number string) (*domain.Epic, error)
+	GetEpicByNumberAndTeam(ctx context.Context, number string, teamID uuid.UUID) (*domain.Epic, error)
 	GetEpicsByStatus(ctx context.Context, status domain.Status) ([]domain.Epic, error)
+	SetEpicRequiredRoles(ctx context.Context, epicID uuid.UUID, roleIDs []uuid.UUID) error
+	GetEpicRequiredRoles(ctx context.Context, epicID uuid.UUID) ([]domain.Role, error)
+	CloneEpic(ctx context.Context, srcID uuid.UUID, newNumber string, targetTeamID uuid.UUID) (*domain.Epic, int, error)
+	GetScoringProgress(ctx context.Context, epicIDs []uuid.UUID) (map[uuid.UUID]domain.ScoringProgress, error)
+	GetScoringTimeStats(ctx context.Context) ([]domain.TeamScoringTime, error)
+	GetEpicStatusHistory(ctx context.Context, epicID uuid.UUID) ([]domain.EpicStatusChange, error)
+	SearchEpics(ctx context.Context, query string) ([]domain.Epic, error)
+	GetEpicsByTeamID(ctx context.Context, teamID uuid.UUID) ([]domain.Epic, error)
+	GetEpicsByTeamIDAndStatus(ctx context.Context, teamID uuid.UUID, status domain.Status) ([]domain.Epic, error)
+	CountEpicsByStatusForTeam(ctx context.Context, teamID uuid.UUID) (map[domain.Status]int, error)
+	CountEpicsForTeam(ctx context.Context, teamID uuid.UUID) (int, error)
+	CountAllEpicsByStatus(ctx context.Context) (map[domain.Status]int, error)
+	GetSystemCounts(ctx context.Context) (domain.SystemCounts, error)
+	DeleteTeam(ctx context.Context, teamID uuid.UUID) error
+	UpdateTeam(ctx context.Context, teamID uuid.UUID, name, description string) error
 	GetAllEpics(ctx context.Context) ([]domain.Epic, error)
+	GetEpicsPage(ctx context.Context, limit, offset int, status domain.Status) ([]domain.Epic, int, error)
 	GetUnscoredEpicsByUser(ctx context.Context, userID, teamID uuid.UUID) ([]domain.Epic, error)
-	UpdateEpicStatus(ctx context.Context, epicID uuid.UUID, status domain.Status) error
-	DeleteEpic(ctx context.Context, epicID uuid.UUID) error
+	GetScoringEpicsScoredByUser(ctx context.Context, userID uuid.UUID) ([]domain.Epic, error)
+	GetExistingEpicNumbers(ctx context.Context, teamID uuid.UUID, numbers []string) ([]string, error)
+	ImportEpics(ctx context.Context, teamID uuid.UUID, epics []domain.Epic, upsert bool) (int, int, error)
+	StartEpicScoring(ctx context.Context, epicID uuid.UUID) (int, bool, error)
+	StartTeamScoring(ctx context.Context, teamID uuid.UUID) ([]string, int, error)
+	SetEpicScoringDeadline(ctx context.Context, epicID uuid.UUID, deadline *time.Time) error
+	DeleteEpicTx(ctx context.Context, epicID uuid.UUID) (domain.EpicSnapshot, error)
 
 	// Risks
-	CreateRisk(ctx context.Context, description string, epicID uuid.UUID) (*domain.Risk, error)
+	CreateRisk(ctx context.Context, description string, epicID uuid.UUID, importance domain.Importance) (*domain.Risk, error)
 	GetRisksByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.Risk, error)
 	GetRiskByID(ctx context.Context, riskID uuid.UUID) (*domain.Risk, error)
 	GetUnscoredRisksByUser(ctx context.Context, userID, epicID uuid.UUID) ([]domain.Risk, error)
-	UpdateRiskStatus(ctx context.Context, riskID uuid.UUID, status domain.Status) error
-	DeleteRisk(ctx context.Context, riskID uuid.UUID) error
+	DeleteRisk(ctx context.Context, riskID uuid.UUID) (domain.RiskSnapshot, error)
 
 	// Scoring data
-	CreateEpicScore(ctx context.Context, epicID, userID, roleID uuid.UUID, score int) error
+	CreateEpicScore(ctx context.Context, epicID, userID, roleID uuid.UUID, score int) (bool, error)
 	HasUserScoredEpic(ctx context.Context, epicID, userID uuid.UUID) (bool, error)
+	IsUserEpicScorer(ctx context.Context, epicID, userID uuid.UUID) (bool, error)
+	GetEpicScorers(ctx context.Context, epicID uuid.UUID) ([]domain.User, error)
 	GetUsersWhoScoredEpic(ctx context.Context, epicID uuid.UUID) ([]domain.User, error)
-	GetUsersWhoScoredRisk(ctx context.Context, riskID uuid.UUID) ([]domain.User, error)
+	GetEpicScoresByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.EpicScore, error)
 	GetEpicRoleScoresByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.EpicRoleScore, error)
-	CreateRiskScore(ctx context.Context, riskID, userID uuid.UUID, probability, impact int) error
+	CreateRiskScore(ctx context.Context, riskID, userID uuid.UUID, probability, impact int) (bool, error)
+	SkipRisk(ctx context.Context, riskID, userID uuid.UUID) (bool, error)
+	GetRiskScoresByRiskID(ctx context.Context, riskID uuid.UUID) ([]domain.RiskScore, error)
+	CountTeamMembers(ctx context.Context, teamID uuid.UUID) (int, error)
+	GetUserAgreement(ctx context.Context, userID uuid.UUID, teamID *uuid.UUID) (domain.UserAgreement, error)
+	GetRoleDistributionForTeam(ctx context.Context, teamID uuid.UUID) ([]domain.RoleWithUserCount, error)
+	CountEpicScores(ctx context.Context, epicID uuid.UUID) (int, error)
+	CountEpicScorers(ctx context.Context, epicID uuid.UUID) (int, error)
+	GetRiskScoresByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.RiskScore, error)
+	GetEpicWithRisks(ctx context.Context, epicID uuid.UUID) (*domain.EpicDetail, error)
+	GetUserEpicScore(ctx context.Context, epicID, userID uuid.UUID) (*domain.EpicScore, error)
+	DeleteUserEpicScore(ctx context.Context, epicID, userID uuid.UUID) error
+	GetUserRiskScore(ctx context.Context, riskID, userID uuid.UUID) (*domain.RiskScore, error)
+	RestoreRisk(ctx context.Context, snap domain.RiskSnapshot) error
+	RestoreEpic(ctx context.Context, snap domain.EpicSnapshot) error
+	RestoreUser(ctx context.Context, snap domain.UserSnapshot) error
+
+	// Comments
+	CreateEpicComment(ctx context.Context, epicID, userID uuid.UUID, text string) (*domain.EpicComment, error)
+	GetCommentsByEpicID(ctx context.Context, epicID uuid.UUID) ([]domain.EpicComment, error)
+
+	// Watchers
+	AddEpicWatcher(ctx context.Context, w domain.EpicWatcher) (bool, error)
+	RemoveEpicWatcher(ctx context.Context, w domain.EpicWatcher) error
+	TakeEpicWatchers(ctx context.Context, epicID uuid.UUID) ([]domain.EpicWatcher, error)
+
+	// Audit
+	GetRecentAuditEntries(ctx context.Context, limit int) ([]domain.AuditEntry, error)
+
+	// Chat settings
+	GetChatLanguage(ctx context.Context, chatID int64) (string, error)
+	SetChatLanguage(ctx context.Context, chatID int64, lang string) error
+	GetChatSettings(ctx context.Context, chatID int64) (domain.ChatSettings, error)
+	SaveChatSettings(ctx context.Context, s domain.ChatSettings) error
+
+	// Health
+	HealthCheck(ctx context.Context) (time.Duration, error)
+}
+
+// AuditRecorder records administrative actions.
+type AuditRecorder interface {
+	Record(ctx context.Context, actor, action, details string)
 }
 
 // ScoringService defines the scoring business-logic contract.
 type ScoringService interface {
+	ValidateEffort(score int) error
 	TryCompleteEpicScoring(ctx context.Context, epicID uuid.UUID) error
 	TryCompleteRiskScoring(ctx context.Context, riskID uuid.UUID) error
+	CloseEpicScoring(ctx context.Context, epicID uuid.UUID) error
+	RecalculateEpicRoleScores(ctx context.Context, epicID uuid.UUID) (*scoring.Recalculation, error)
 }
 
 // AIClient defines the AI question-answering contract.
@@ -0,0 +1,80 @@
+package telegram
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// chatLanguage returns the interface language of a chat, falling back to
+// the configured default. Choices are cached after the first lookup.
+func (epicBot *Bot) chatLanguage(ctx context.Context, chatID int64) string {
+	epicBot.langMu.RLock()
+	lang, ok := epicBot.chatLangs[chatID]
+	epicBot.langMu.RUnlock()
+	if ok {
+		return lang
+	}
+
+	lang, err := epicBot.repo.GetChatLanguage(ctx, chatID)
+	if err != nil {
+		epicBot.log.Error("failed to get chat language",
+			slog.Int64("chat_id", chatID), sl.Err(err))
+		return epicBot.i18n.DefaultLanguage()
+	}
+	if lang == "" || !epicBot.i18n.Supported(lang) {
+		lang = epicBot.i18n.DefaultLanguage()
+	}
+	epicBot.langMu.Lock()
+	epicBot.chatLangs[chatID] = lang
+	epicBot.langMu.Unlock()
+	return lang
+}
+
+// t renders a catalog message in the language of the message's chat.
+func (epicBot *Bot) t(ctx context.Context, msg *models.Message, key string, args ...any) string {
+	return epicBot.i18n.T(epicBot.chatLanguage(ctx, msg.Chat.ID), key, args...)
+}
+
+// ─── /setlang ─────────────────────────────────────────────────────────────
+
+// handleSetLang stores the interface language of the chat.
+// Usage: /setlang <ru|en>
+func (epicBot *Bot) handleSetLang(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleSetLang"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	// In group chats the language affects everyone, so only admins may change it.
+	if msg.Chat.Type != models.ChatTypePrivate && !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	available := strings.Join(epicBot.i18n.Languages(), ", ")
+	lang := strings.ToLower(strings.TrimSpace(commandArguments(msg)))
+	if lang == "" {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "lang.usage", available))
+		return err
+	}
+	if !epicBot.i18n.Supported(lang) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "lang.unsupported", lang, available))
+		return err
+	}
+
+	if err := epicBot.repo.SetChatLanguage(ctx, msg.Chat.ID, lang); err != nil {
+		log.Error("failed to set chat language", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "lang.error"))
+		return retErr
+	}
+	epicBot.langMu.Lock()
+	epicBot.chatLangs[msg.Chat.ID] = lang
+	epicBot.langMu.Unlock()
+
+	_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "lang.set"))
+	return err
+}
@@ -0,0 +1,45 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ─── /ping ────────────────────────────────────────────────────────────────
+
+// handlePing reports whether the database answers and how fast, together
+// with the bot's uptime, environment and version.
+func (epicBot *Bot) handlePing(ctx context.Context, msg *models.Message) error {
+	op := "bot.handlePing"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🏓 Понг\n")
+	latency, err := epicBot.repo.HealthCheck(ctx)
+	if err != nil {
+		log.Error("database health check failed", sl.Err(err))
+		sb.WriteString("База данных: ❌ недоступна\n")
+	} else {
+		fmt.Fprintf(&sb, "База данных: ✅ %d мс\n", latency.Milliseconds())
+	}
+	fmt.Fprintf(&sb, "Аптайм: %s\n", formatAge(time.Since(epicBot.startedAt)))
+	fmt.Fprintf(&sb, "Окружение: %s\n", epicBot.cfg.Env)
+	fmt.Fprintf(&sb, "Версия: %s", epicBot.version)
+
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}
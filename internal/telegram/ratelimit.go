@@ -0,0 +1,69 @@
+package telegram
+
+import (
+	"sync"
+	"time"
+)
+
+// ─── Per-user rate limiting ───────────────────────────────────────────────
+
+// rateLimiter is a per-user token bucket: each user may send burst events
+// at once, refilled at rate events per second. A zero rate disables it.
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[int64]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// maxIdleBuckets is how many buckets are kept before full ones are
+// dropped; a full bucket is the same as no bucket.
+const maxIdleBuckets = 1024
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		buckets: make(map[int64]*tokenBucket),
+	}
+}
+
+// allow takes a token from the user's bucket and reports whether the
+// event may be handled.
+func (l *rateLimiter) allow(userID int64, now time.Time) bool {
+	if l == nil || l.rate <= 0 {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.buckets) > maxIdleBuckets {
+		l.prune(now)
+	}
+	b, ok := l.buckets[userID]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[userID] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// prune drops buckets that have refilled completely.
+func (l *rateLimiter) prune(now time.Time) {
+	for id, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, id)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	start := time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		rate   float64
+		burst  int
+		events []time.Duration // offsets from start
+		want   []bool
+	}{
+		{"burst then blocked", 1, 3, []time.Duration{0, 0, 0, 0}, []bool{true, true, true, false}},
+		{"refills at the rate", 1, 1, []time.Duration{0, 0, 500 * time.Millisecond, time.Second}, []bool{true, false, false, true}},
+		{"refill is capped at the burst", 1, 2, []time.Duration{0, time.Hour, time.Hour, time.Hour}, []bool{true, true, true, false}},
+		{"burst below one allows one", 1, 0, []time.Duration{0, 0}, []bool{true, false}},
+		{"zero rate disables it", 0, 1, []time.Duration{0, 0, 0}, []bool{true, true, true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(tt.rate, tt.burst)
+			for i, offset := range tt.events {
+				if got := l.allow(1, start.Add(offset)); got != tt.want[i] {
+					t.Errorf("event %d at %v: allow() = %v, want %v", i, offset, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimiterIsPerUser(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	now := time.Now()
+	if !l.allow(1, now) || l.allow(1, now) {
+		t.Fatal("first user: want one event allowed, then blocked")
+	}
+	if !l.allow(2, now) {
+		t.Error("second user was blocked by the first one's bucket")
+	}
+}
+
+func TestRateLimiterPrunesFullBuckets(t *testing.T) {
+	l := newRateLimiter(1, 1)
+	now := time.Now()
+	for id := range int64(maxIdleBuckets + 1) {
+		l.allow(id, now)
+	}
+	// Every bucket has refilled a minute later, so all but the new one go.
+	l.allow(-1, now.Add(time.Minute))
+	if len(l.buckets) != 1 {
+		t.Errorf("%d buckets kept, want 1", len(l.buckets))
+	}
+}
+
+func TestNilRateLimiterAllows(t *testing.T) {
+	var l *rateLimiter
+	if !l.allow(1, time.Now()) {
+		t.Error("nil limiter blocked an event")
+	}
+}
@@ -0,0 +1,90 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/scoring"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ─── /recalculate ─────────────────────────────────────────────────────────
+
+// handleRecalculate recomputes the role averages and final score of a
+// scored epic with the current user weights, e.g. after /changerate.
+// Usage: /recalculate <epic number>
+func (epicBot *Bot) handleRecalculate(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleRecalculate"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	number := strings.TrimPrefix(strings.TrimSpace(commandArguments(msg)), "#")
+	if number == "" {
+		_, err := epicBot.sendReply(ctx, msg, "⚠️ Использование: /recalculate <номер эпика>")
+		return err
+	}
+
+	epic, err := epicBot.repo.GetEpicByNumber(ctx, number)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.epicNumberErrorText(ctx, msg, err, number))
+		return retErr
+	}
+
+	res, err := epicBot.scoring.RecalculateEpicRoleScores(ctx, epic.ID)
+	if err != nil {
+		if errors.Is(err, scoring.ErrNotScored) {
+			_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf(
+				"ℹ️ Эпик #%s ещё не оценён — оценки будут рассчитаны по текущим весам при завершении.",
+				epic.Number))
+			return retErr
+		}
+		log.Error("error recalculating epic scores", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка пересчёта оценок.")
+		return retErr
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "🔄 Оценки эпика #%s «%s» пересчитаны.\n", epic.Number, epic.Name)
+	if len(res.RoleAvgs) > 0 {
+		sb.WriteString("\n📋 Оценки по ролям:\n")
+		lines := make([]string, 0, len(res.RoleAvgs))
+		for roleID, avg := range res.RoleAvgs {
+			name := roleID.String()
+			if role, err := epicBot.repo.GetRoleByID(ctx, roleID); err == nil {
+				name = role.Name
+			}
+			lines = append(lines, fmt.Sprintf("  • %s: %.2f", name, avg))
+		}
+		sort.Strings(lines)
+		sb.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+	fmt.Fprintf(&sb, "\nБазовая оценка: %.2f\n", res.BaseScore)
+	final := strconv.FormatFloat(res.FinalScore, 'f', -1, 64)
+	change := final
+	switch {
+	case res.PreviousFinal == nil:
+	case *res.PreviousFinal == res.FinalScore:
+		change += " (не изменилась)"
+	default:
+		change = strconv.FormatFloat(*res.PreviousFinal, 'f', -1, 64) + " → " + final
+	}
+	fmt.Fprintf(&sb, "🏆 Итоговая оценка: %s", change)
+
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionScoresRecomputed,
+		fmt.Sprintf("#%s: %s", epic.Number, change))
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}
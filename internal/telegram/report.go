@@ -0,0 +1,280 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/export"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/scoring"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /report ──────────────────────────────────────────────────────────────
+
+// handleReport sends a per-epic report as a Markdown file.
+// Usage: /report <epic number>
+func (epicBot *Bot) handleReport(ctx context.Context, msg *models.Message) error {
+	markdown := func(r export.EpicReport) ([]byte, error) { return export.EpicToMarkdown(r), nil }
+	return epicBot.sendEpicReport(ctx, msg, "report", markdown, "epic-%s.md", "report.markdown_caption")
+}
+
+// handleExportPDF sends the results of an epic as a PDF document for
+// sharing outside Telegram.
+// Usage: /exportpdf <epic number>
+func (epicBot *Bot) handleExportPDF(ctx context.Context, msg *models.Message) error {
+	if !epicBot.cfg.BotConfig.PDFExport {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "report.pdf_disabled"))
+		return err
+	}
+	return epicBot.sendEpicReport(ctx, msg, "exportpdf", export.EpicToPDF, "epic-%s.pdf", "report.pdf_caption")
+}
+
+// sendEpicReport looks up the epic named in the arguments of an admin
+// command and sends its report as a file rendered by render. filename is a
+// format and captionKey a catalog message, both taking the epic number.
+func (epicBot *Bot) sendEpicReport(
+	ctx context.Context,
+	msg *models.Message,
+	command string,
+	render func(export.EpicReport) ([]byte, error),
+	filename, captionKey string,
+) error {
+	op := "bot.sendEpicReport"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.String("command", command),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	number := strings.TrimPrefix(strings.TrimSpace(commandArguments(msg)), "#")
+	if number == "" {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "report.usage", command))
+		return err
+	}
+
+	epic, err := epicBot.repo.GetEpicByNumber(ctx, number)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.epicNumberErrorText(ctx, msg, err, number))
+		return retErr
+	}
+
+	report, err := epicBot.buildEpicReport(ctx, epic)
+	if err != nil {
+		log.Error("error building epic report", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "report.error"))
+		return retErr
+	}
+	data, err := render(report)
+	if errors.Is(err, export.ErrPDFUnavailable) {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "report.pdf_unavailable"))
+		return retErr
+	}
+	if err != nil {
+		log.Error("error rendering epic report", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "report.error"))
+		return retErr
+	}
+
+	_, err = epicBot.sendDocument(ctx, msg,
+		fmt.Sprintf(filename, epic.Number), data, epicBot.t(ctx, msg, captionKey, epic.Number))
+	return err
+}
+
+// buildEpicReport assembles the report read-model for an epic.
+func (epicBot *Bot) buildEpicReport(ctx context.Context, epic *domain.Epic) (export.EpicReport, error) {
+	report := export.EpicReport{
+		Epic:        *epic,
+		GeneratedAt: time.Now(),
+	}
+
+	report.Location = time.UTC
+	if team, err := epicBot.repo.GetTeamByID(ctx, epic.TeamID); err == nil {
+		report.TeamName = team.Name
+		report.Location = team.Location()
+	}
+
+	// Members whose role does not score the effort are left out, as in
+	// the completion quorum.
+	scorers, err := epicBot.repo.CountEpicScorers(ctx, epic.ID)
+	if err != nil {
+		return report, err
+	}
+	report.EffortScorers = scorers
+
+	scored, err := epicBot.repo.CountEpicScores(ctx, epic.ID)
+	if err != nil {
+		return report, err
+	}
+	report.EffortScored = scored
+
+	roleScores, err := epicBot.repo.GetEpicRoleScoresByEpicID(ctx, epic.ID)
+	if err != nil {
+		return report, err
+	}
+	for _, rs := range roleScores {
+		roleName := rs.RoleID.String()
+		if role, err := epicBot.repo.GetRoleByID(ctx, rs.RoleID); err == nil {
+			roleName = role.Name
+		}
+		report.RoleScores = append(report.RoleScores, export.RoleScore{
+			RoleName:    roleName,
+			WeightedAvg: rs.WeightedAvg,
+		})
+	}
+
+	risks, err := epicBot.repo.GetRisksByEpicID(ctx, epic.ID)
+	if err != nil {
+		return report, err
+	}
+	allRiskScores, err := epicBot.repo.GetRiskScoresByEpicID(ctx, epic.ID)
+	if err != nil {
+		return report, err
+	}
+	scoresByRisk := make(map[uuid.UUID][]domain.RiskScore)
+	for _, rs := range allRiskScores {
+		scoresByRisk[rs.RiskID] = append(scoresByRisk[rs.RiskID], rs)
+	}
+	for _, risk := range risks {
+		row := export.RiskRow{
+			Description:   risk.Description,
+			Status:        risk.Status,
+			Importance:    risk.Importance,
+			WeightedScore: risk.WeightedScore,
+		}
+		riskScores := scoresByRisk[risk.ID]
+		if len(riskScores) > 0 {
+			var probSum, impSum int
+			for _, rs := range riskScores {
+				probSum += rs.Probability
+				impSum += rs.Impact
+			}
+			row.Assessments = len(riskScores)
+			row.AvgProbability = float64(probSum) / float64(len(riskScores))
+			row.AvgImpact = float64(impSum) / float64(len(riskScores))
+		}
+		if risk.WeightedScore != nil {
+			c := scoring.EffectiveRiskCoefficient(*risk.WeightedScore, risk.Importance)
+			row.Coefficient = &c
+		}
+		report.Risks = append(report.Risks, row)
+	}
+
+	return report, nil
+}
+
+// ─── Announcements ────────────────────────────────────────────────────────
+
+// AnnounceEpicClosed posts the results of an epic whose scoring was closed
+// automatically to the configured announce chat.
+func (epicBot *Bot) AnnounceEpicClosed(ctx context.Context, epicID uuid.UUID) {
+	chatID := epicBot.cfg.BotConfig.AnnounceChatID
+	if chatID == 0 {
+		epicBot.log.Debug("announce chat not configured, skipping",
+			slog.String("epicID", epicID.String()))
+		return
+	}
+	msg := &models.Message{
+		Chat:            models.Chat{ID: chatID},
+		MessageThreadID: epicBot.cfg.BotConfig.AnnounceThreadID,
+	}
+	if _, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "report.deadline_closed")); err != nil {
+		epicBot.log.Error("failed to announce closed epic",
+			slog.String("epicID", epicID.String()), sl.Err(err))
+		return
+	}
+	// The announce chat is shared with participants.
+	epicBot.showEpicResults(ctx, msg, epicID, false)
+}
+
+// AnnounceEpicScored posts how the final score of an epic that reached
+// its quorum was derived from the base score to the configured announce
+// chat.
+func (epicBot *Bot) AnnounceEpicScored(ctx context.Context, epicID uuid.UUID, baseScore float64) {
+	chatID := epicBot.cfg.BotConfig.AnnounceChatID
+	if chatID == 0 {
+		epicBot.log.Debug("announce chat not configured, skipping",
+			slog.String("epicID", epicID.String()))
+		return
+	}
+	epic, err := epicBot.repo.GetEpicWithRisks(ctx, epicID)
+	if err != nil {
+		epicBot.log.Error("failed to load scored epic",
+			slog.String("epicID", epicID.String()), sl.Err(err))
+		return
+	}
+
+	msg := &models.Message{
+		Chat:            models.Chat{ID: chatID},
+		MessageThreadID: epicBot.cfg.BotConfig.AnnounceThreadID,
+	}
+	var sb strings.Builder
+	sb.WriteString(epicBot.t(ctx, msg, "report.scored", epic.Number, epic.Name) + "\n\n")
+	sb.WriteString(epicBot.explainFinalScore(ctx, msg, baseScore, epic.Risks, epicBot.cfg.Scoring.RiskAggregation))
+	if epic.FinalScore != nil {
+		sb.WriteString("\n" + epicBot.t(ctx, msg, "epic.final_score", strconv.FormatFloat(*epic.FinalScore, 'f', -1, 64)))
+	}
+	if _, err := epicBot.sendReply(ctx, msg, sb.String()); err != nil {
+		epicBot.log.Error("failed to announce scored epic",
+			slog.String("epicID", epicID.String()), sl.Err(err))
+	}
+}
+
+// explainFinalScore shows the effective coefficient of every scored risk,
+// one per line, and how the base score becomes the final one. Unless the
+// coefficients are multiplied, their combined multiplier is shown as the
+// single factor.
+func (epicBot *Bot) explainFinalScore(
+	ctx context.Context,
+	msg *models.Message,
+	base float64,
+	risks []domain.Risk,
+	aggregation string,
+) string {
+	var combined string
+	switch strings.ToLower(aggregation) {
+	case config.RiskAggregationMax:
+		combined = epicBot.t(ctx, msg, "explain.max")
+	case config.RiskAggregationSumCapped:
+		combined = epicBot.t(ctx, msg, "explain.sumcapped", scoring.MaxSummedRiskCoefficient)
+	}
+	bullet := "×"
+	if combined != "" {
+		bullet = "•"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintln(&sb, epicBot.t(ctx, msg, "explain.base", base))
+	applied := 0
+	for _, risk := range risks {
+		if risk.Status != domain.StatusScored || risk.WeightedScore == nil {
+			continue
+		}
+		c := scoring.EffectiveRiskCoefficient(*risk.WeightedScore, risk.Importance)
+		applied++
+		fmt.Fprintln(&sb, bullet+" "+epicBot.t(ctx, msg, "explain.risk",
+			c, risk.Description, *risk.WeightedScore, risk.Importance))
+	}
+	if applied == 0 {
+		fmt.Fprintln(&sb, epicBot.t(ctx, msg, "explain.no_risks"))
+		return sb.String()
+	}
+	multiplier := scoring.RiskMultiplier(aggregation, risks)
+	if combined != "" {
+		fmt.Fprintf(&sb, "× %.2f — %s\n", multiplier, combined)
+	}
+	fmt.Fprintln(&sb, epicBot.t(ctx, msg, "explain.before_rounding", base*multiplier))
+	return sb.String()
+}
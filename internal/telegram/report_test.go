@@ -0,0 +1,142 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+)
+
+// explainRisks are two scored risks with effective coefficients 1.30 and
+// 1.10, and one risk still being scored.
+func explainRisks() []domain.Risk {
+	high, medium, pending := 9.4, 5.0, 13.0
+	return []domain.Risk{
+		{Description: "API", Status: domain.StatusScored, WeightedScore: &high, Importance: domain.ImportanceHigh},
+		{Description: "DB", Status: domain.StatusScored, WeightedScore: &medium, Importance: domain.ImportanceMedium},
+		{Description: "UI", Status: domain.StatusScoring, WeightedScore: &pending, Importance: domain.ImportanceHigh},
+	}
+}
+
+func TestExplainFinalScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		risks []domain.Risk
+		mode  string
+		want  string
+	}{
+		{
+			"no risks",
+			nil,
+			config.RiskAggregationProduct,
+			"Базовая оценка (сумма по ролям): 10.00\nРиски не повлияли на оценку.\n",
+		},
+		{
+			"only unscored risks",
+			explainRisks()[2:],
+			config.RiskAggregationProduct,
+			"Базовая оценка (сумма по ролям): 10.00\nРиски не повлияли на оценку.\n",
+		},
+		{
+			"product",
+			explainRisks(),
+			config.RiskAggregationProduct,
+			"Базовая оценка (сумма по ролям): 10.00\n" +
+				"× 1.30 — риск «API» (оценка 9.40, важность HIGH)\n" +
+				"× 1.10 — риск «DB» (оценка 5.00, важность MEDIUM)\n" +
+				"= 14.30 до округления\n",
+		},
+		{
+			"mode is case-insensitive",
+			explainRisks(),
+			"MAX",
+			"Базовая оценка (сумма по ролям): 10.00\n" +
+				"• 1.30 — риск «API» (оценка 9.40, важность HIGH)\n" +
+				"• 1.10 — риск «DB» (оценка 5.00, важность MEDIUM)\n" +
+				"× 1.30 — наибольший коэффициент риска\n" +
+				"= 13.00 до округления\n",
+		},
+		{
+			"sumcapped",
+			explainRisks(),
+			config.RiskAggregationSumCapped,
+			"Базовая оценка (сумма по ролям): 10.00\n" +
+				"• 1.30 — риск «API» (оценка 9.40, важность HIGH)\n" +
+				"• 1.10 — риск «DB» (оценка 5.00, важность MEDIUM)\n" +
+				"× 1.40 — сумма надбавок рисков (не больше 1.50)\n" +
+				"= 14.00 до округления\n",
+		},
+	}
+	epicBot, _ := newTestBot(t, nil, &fakeRepo{})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := epicBot.explainFinalScore(context.Background(), testMessage(""), 10, tt.risks, tt.mode)
+			if got != tt.want {
+				t.Errorf("explainFinalScore() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExplainFinalScoreInEnglish(t *testing.T) {
+	epicBot, _ := newTestBot(t, nil, &fakeRepo{})
+	msg := testMessage("")
+	epicBot.chatLangs[msg.Chat.ID] = "en"
+
+	want := "Base score (sum over roles): 10.00\n" +
+		"• 1.30 — risk «API» (score 9.40, importance HIGH)\n" +
+		"• 1.10 — risk «DB» (score 5.00, importance MEDIUM)\n" +
+		"× 1.30 — the largest risk coefficient\n" +
+		"= 13.00 before rounding\n"
+	got := epicBot.explainFinalScore(context.Background(), msg, 10, explainRisks(), config.RiskAggregationMax)
+	if got != want {
+		t.Errorf("explainFinalScore() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestExportPDFDisabled(t *testing.T) {
+	cfg := &config.Config{BotConfig: config.BotConfig{Admins: []string{"ann"}, PDFExport: false}}
+	// The fake repository has no epic lookup, so reaching it would panic.
+	epicBot, api := newTestBot(t, cfg, &fakeRepo{})
+
+	if err := epicBot.handleExportPDF(context.Background(), testMessage("/exportpdf EP-1")); err != nil {
+		t.Fatalf("handleExportPDF() error = %v", err)
+	}
+	if texts := api.Texts(); len(texts) != 1 || !strings.Contains(texts[0], "отключён") {
+		t.Errorf("replies = %q, want a disabled message", texts)
+	}
+}
+
+func TestReportEpicLookupErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		lookupErr error
+		want      string
+	}{
+		{"missing epic", nil, "❌ Эпик #EP-404 не найден."},
+		{
+			"number used by several teams",
+			fmt.Errorf("Repository.GetEpicByNumber: %w", repositories.ErrAmbiguous),
+			"❌ Эпик #EP-404 есть в нескольких командах. Найдите нужный через /findepic.",
+		},
+		{"query error", errors.New("pq: relation \"epics\" does not exist"), "❌ Ошибка базы данных. Попробуйте позже."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{BotConfig: config.BotConfig{Admins: []string{"ann"}}}
+			epicBot, api := newTestBot(t, cfg, &fakeRepo{lookupErr: tt.lookupErr})
+
+			if err := epicBot.handleReport(context.Background(), testCommand("/report EP-404")); err != nil {
+				t.Fatalf("handleReport() error = %v", err)
+			}
+			if texts := api.Texts(); len(texts) != 1 || texts[0] != tt.want {
+				t.Errorf("replies = %q, want [%q]", texts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package telegram
+
+import (
+	"context"
+	"log/slog"
+
+	"EpicScoreBot/internal/scoring"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /resendkeyboard ──────────────────────────────────────────────────────
+
+// handleResendKeyboard shows the prompt of the current session step again,
+// for when the message holding it was deleted or lost. The session and the
+// data collected so far are kept; the new message replaces the old one.
+func (epicBot *Bot) handleResendKeyboard(
+	ctx context.Context,
+	msg *models.Message,
+	sk sessionKey,
+	sess *Session,
+	hadSession bool,
+) error {
+	op := "bot.handleResendKeyboard"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !hadSession {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "resend.no_session"))
+		return err
+	}
+
+	text, kb, ok := epicBot.stepPrompt(ctx, msg, sess)
+	if !ok {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "resend.unavailable"))
+		return err
+	}
+
+	if sess.MessageID > 0 {
+		// Usually already gone; then there is nothing to delete.
+		if err := epicBot.deleteMessage(ctx, msg.Chat.ID, sess.MessageID); err != nil {
+			log.Debug("old prompt not deleted", sl.Err(err))
+		}
+	}
+	var sent *models.Message
+	var err error
+	if kb != nil {
+		sent, err = epicBot.sendWithKeyboard(ctx, msg, text, kb)
+	} else {
+		sent, err = epicBot.sendReply(ctx, msg, text)
+	}
+	if err != nil {
+		return err
+	}
+	if sent != nil {
+		epicBot.sessions.update(sk, func(s *Session) {
+			s.MessageID = sent.ID
+		})
+	}
+	return nil
+}
+
+// stepPrompt renders the prompt shown for a session step. It reports false
+// for steps answered by a picker, which cannot be rebuilt from the session.
+func (epicBot *Bot) stepPrompt(
+	ctx context.Context,
+	msg *models.Message,
+	sess *Session,
+) (string, *models.InlineKeyboardMarkup, bool) {
+	switch sess.Step {
+	case StepAddUserUsername:
+		return epicBot.promptText(ctx, msg, "prompt.username"), nil, true
+	case StepAddUserFirstName:
+		return epicBot.promptText(ctx, msg, "prompt.first_name"), nil, true
+	case StepAddUserLastName:
+		return epicBot.promptText(ctx, msg, "prompt.last_name"), nil, true
+	case StepAddUserWeight:
+		return epicBot.promptText(ctx, msg, "prompt.weight"), nil, true
+
+	case StepRenameUserFirstName:
+		return epicBot.promptText(ctx, msg, "prompt.new_first_name"), nil, true
+	case StepRenameUserLastName:
+		return epicBot.promptText(ctx, msg, "prompt.new_last_name"), nil, true
+
+	case StepRenameTeamName:
+		return epicBot.promptText(ctx, msg, "prompt.team_name"), nil, true
+	case StepRenameTeamDesc:
+		return epicBot.promptText(ctx, msg, "prompt.team_desc"), nil, true
+
+	case StepChangeRateWeight:
+		return epicBot.promptText(ctx, msg, "prompt.new_weight"), nil, true
+
+	case StepAddEpicNumber:
+		return epicBot.promptText(ctx, msg, "prompt.epic_number"), nil, true
+	case StepAddEpicName:
+		return epicBot.promptText(ctx, msg, "prompt.epic_name"), nil, true
+	case StepAddEpicDesc:
+		return epicBot.promptText(ctx, msg, "prompt.epic_desc"), nil, true
+
+	case StepDuplicateEpicNumber:
+		return epicBot.promptText(ctx, msg, "prompt.duplicate_number"), nil, true
+
+	case StepAddRiskDesc:
+		return epicBot.promptText(ctx, msg, "prompt.risk_desc"), nil, true
+	case StepAddRiskImportance:
+		return epicBot.t(ctx, msg, "prompt.risk_importance"), riskImportanceKeyboard(), true
+
+	case StepScoreEpicEffort:
+		return epicBot.promptText(ctx, msg, "prompt.effort", scoring.MinEffortScore, epicBot.cfg.Scoring.EffortMax()),
+			nil, true
+
+	case StepScoreRiskReply:
+		riskID, err := uuid.Parse(sess.Data["riskID"])
+		if err != nil {
+			return "", nil, false
+		}
+		risk, err := epicBot.repo.GetRiskByID(ctx, riskID)
+		if err != nil {
+			return "", nil, false
+		}
+		return epicBot.t(ctx, msg, "prompt.risk_probability", risk.Description), riskProbabilityKeyboard(riskID), true
+	}
+	return "", nil, false
+}
+
+// promptText renders a catalog prompt followed by the hint that /cancel
+// aborts the dialog.
+func (epicBot *Bot) promptText(ctx context.Context, msg *models.Message, key string, args ...any) string {
+	return epicBot.t(ctx, msg, key, args...) + epicBot.t(ctx, msg, "action.cancel_hint")
+}
@@ -0,0 +1,103 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /resetmyscore — withdraw an effort score while scoring is open ──────
+
+// handleResetMyScore lists the epics still being scored whose effort the
+// caller has already estimated, so one of the scores can be withdrawn and
+// entered again.
+func (epicBot *Bot) handleResetMyScore(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleResetMyScore"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	username := msg.From.Username
+	if username == "" {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "user.no_username"))
+		return err
+	}
+
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "user.not_registered"))
+			return retErr
+		}
+		log.Error("error finding user", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal"))
+		return retErr
+	}
+
+	epics, err := epicBot.repo.GetScoringEpicsScoredByUser(ctx, user.ID)
+	if err != nil {
+		log.Error("error getting scored epics", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "resetscore.list_error"))
+		return retErr
+	}
+	if len(epics) == 0 {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "resetscore.nothing"))
+		return retErr
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	for _, epic := range epics {
+		label := fmt.Sprintf("🗑 #%s %s", epic.Number, epic.Name)
+		if s, err := epicBot.repo.GetUserEpicScore(ctx, epic.ID, user.ID); err == nil {
+			label = fmt.Sprintf("🗑 #%s %s — %d", epic.Number, epic.Name, s.Score)
+		}
+		rows = append(rows, inlineRow(epicBot.pickerBtn(label, "resetscore_"+epic.ID.String())))
+	}
+	_, err = epicBot.sendWithKeyboard(ctx, msg, epicBot.t(ctx, msg, "resetscore.pick"), inlineKeyboard(rows...))
+	return err
+}
+
+// handleResetScore withdraws the caller's effort score for the picked epic
+// and asks for a new one. Epics whose scoring has finished are refused.
+// data = "resetscore_<epicID>".
+func (epicBot *Bot) handleResetScore(ctx context.Context, msg *models.Message, username string, epicID uuid.UUID) {
+	op := "bot.handleResetScore"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
+	if err != nil {
+		epicBot.editOrSend(ctx, msg, msg.ID, epicBot.lookupErrorText(ctx, msg, err, "user.not_found"))
+		return
+	}
+	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
+	if err != nil {
+		epicBot.editOrSend(ctx, msg, msg.ID, epicBot.lookupErrorText(ctx, msg, err, "epic.not_found"))
+		return
+	}
+
+	if err := epicBot.repo.DeleteUserEpicScore(ctx, epicID, user.ID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrWrongStatus):
+			epicBot.editOrSend(ctx, msg, msg.ID, epicBot.t(ctx, msg, "resetscore.finished", epic.Number))
+		case errors.Is(err, repositories.ErrNotFound):
+			epicBot.editOrSend(ctx, msg, msg.ID, epicBot.t(ctx, msg, "resetscore.no_score", epic.Number))
+		default:
+			log.Error("error deleting epic score", sl.Err(err))
+			epicBot.editOrSend(ctx, msg, msg.ID, epicBot.t(ctx, msg, "resetscore.error"))
+		}
+		return
+	}
+
+	epicBot.editOrSend(ctx, msg, msg.ID, epicBot.t(ctx, msg, "resetscore.done", epic.Number))
+	epicBot.showEpicScoreOptions(ctx, msg, username, epicID)
+}
@@ -0,0 +1,247 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /listroles ───────────────────────────────────────────────────────────
+
+// handleListRoles prints all roles with the number of users holding each.
+func (epicBot *Bot) handleListRoles(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleListRoles"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+
+	roles, err := epicBot.repo.GetRolesWithUserCounts(ctx)
+	if err != nil {
+		log.Error("error getting roles with user counts", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка получения ролей.")
+		return retErr
+	}
+	if len(roles) == 0 {
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Роли не найдены.")
+		return retErr
+	}
+
+	var sb strings.Builder
+	sb.WriteString("🎭 Роли:\n")
+	for _, r := range roles {
+		fmt.Fprintf(&sb, "\n• %s — участников: %d", r.Name, r.UserCount)
+		if r.Description != "" {
+			fmt.Fprintf(&sb, "\n  %s", r.Description)
+		}
+	}
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}
+
+// ─── /createrole ──────────────────────────────────────────────────────────
+
+// handleCreateRole creates a new role.
+// Usage: /createrole <name> [| description]
+func (epicBot *Bot) handleCreateRole(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleCreateRole"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+	name, description, _ := strings.Cut(commandArguments(msg), "|")
+	name = strings.TrimSpace(name)
+	description = strings.TrimSpace(description)
+	if name == "" {
+		_, err := epicBot.sendReply(ctx, msg, "⚠️ Использование: /createrole <название> [| описание]")
+		return err
+	}
+
+	role, err := epicBot.repo.CreateRole(ctx, name, description)
+	if err != nil {
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			_, retErr := epicBot.sendReply(ctx, msg, fmt.Sprintf("❌ Роль «%s» уже существует.", name))
+			return retErr
+		}
+		log.Error("error creating role", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка создания роли.")
+		return retErr
+	}
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionRoleCreated, role.Name)
+	_, err = epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "role.created", role.Name))
+	return err
+}
+
+// ─── /deleterole — inline keyboard ───────────────────────────────────────
+
+// handleDeleteRole shows a role picker for deletion. Roles still assigned
+// to users or referenced by scores cannot be deleted.
+func (epicBot *Bot) handleDeleteRole(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleDeleteRole"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+
+	roles, err := epicBot.repo.GetRolesWithUserCounts(ctx)
+	if err != nil || len(roles) == 0 {
+		if err != nil {
+			log.Error("error getting roles with user counts", sl.Err(err))
+		}
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Роли не найдены.")
+		return retErr
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	for _, r := range roles {
+		label := fmt.Sprintf("🎭 %s (%d)", r.Name, r.UserCount)
+		data := fmt.Sprintf("adm_role_deleterole_%s", r.ID.String())
+		rows = append(rows, inlineRow(epicBot.pickerBtn(label, data)))
+	}
+	rows = append(rows, inlineRow(inlineBtn("❌ Отмена", "adm_cancel")))
+
+	sent, err := epicBot.sendWithKeyboard(ctx, msg, "🎭 Выберите роль для удаления:", inlineKeyboard(rows...))
+	if err != nil {
+		return err
+	}
+	sk := sessionKey{ChatID: msg.Chat.ID, ThreadID: msg.MessageThreadID, Username: msg.From.Username}
+	sess := &Session{
+		ThreadID: msg.MessageThreadID,
+		Username: msg.From.Username,
+		Data:     make(map[string]string),
+	}
+	if sent != nil {
+		sess.MessageID = sent.ID
+	}
+	epicBot.sessions.set(sk, sess)
+	return nil
+}
+
+// roleInUseText explains why a role cannot be deleted, or returns an empty
+// string when nothing refers to it.
+func roleInUseText(role *domain.Role, refs domain.RoleReferences) string {
+	if refs.Users == 0 && refs.EpicScores == 0 && refs.EpicRoleScores == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "⛔ Роль «%s» используется и не может быть удалена:\n", role.Name)
+	if refs.Users > 0 {
+		fmt.Fprintf(&sb, "• назначена участникам: %d\n", refs.Users)
+	}
+	if refs.EpicScores > 0 {
+		fmt.Fprintf(&sb, "• оценок эпиков: %d\n", refs.EpicScores)
+	}
+	if refs.EpicRoleScores > 0 {
+		fmt.Fprintf(&sb, "• итогов по ролям: %d\n", refs.EpicRoleScores)
+	}
+	if refs.Users > 0 {
+		sb.WriteString("\nСнимите роль с участников через /unassignrole и повторите.")
+	}
+	return sb.String()
+}
+
+// confirmDeleteRole asks to confirm deletion of a role, or explains why the
+// role cannot be deleted.
+func (epicBot *Bot) confirmDeleteRole(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	roleID uuid.UUID,
+) {
+	op := "bot.confirmDeleteRole"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("role_id", roleID.String()),
+	)
+	if !epicBot.isSuperAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return
+	}
+
+	sk := sessionKeyFromCallback(msg, callback)
+	msgID := 0
+	if sess, ok := epicBot.sessions.get(sk); ok {
+		msgID = sess.MessageID
+	}
+
+	role, err := epicBot.repo.GetRoleByID(ctx, roleID)
+	if err != nil {
+		epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "role.not_found"))
+		return
+	}
+	refs, err := epicBot.repo.GetRoleReferences(ctx, roleID)
+	if err != nil {
+		log.Error("error getting role references", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка проверки роли.")
+		return
+	}
+	if text := roleInUseText(role, refs); text != "" {
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, msgID, text)
+		return
+	}
+
+	kb := inlineKeyboard(inlineRow(
+		inlineBtn("✅ Да, удалить", "adm_confirm_deleterole_"+roleID.String()),
+		inlineBtn("❌ Отмена", "adm_deny_deleterole"),
+	))
+	epicBot.editOrSendWithKeyboard(ctx, msg, msgID,
+		fmt.Sprintf("⚠️ Удалить роль «%s»?\nЭто действие необратимо.", role.Name), kb)
+}
+
+// deleteRole deletes a confirmed role after re-checking that nothing
+// refers to it.
+func (epicBot *Bot) deleteRole(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	roleID uuid.UUID,
+	msgID int,
+) {
+	role, err := epicBot.repo.GetRoleByID(ctx, roleID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "role.not_found"))
+		return
+	}
+	// Scores may have been submitted since the confirmation was shown.
+	refs, err := epicBot.repo.GetRoleReferences(ctx, roleID)
+	if err != nil {
+		epicBot.log.Error("failed to get role references", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка удаления роли.")
+		return
+	}
+	if text := roleInUseText(role, refs); text != "" {
+		epicBot.deleteAndSend(ctx, msg, msgID, text)
+		return
+	}
+	if err := epicBot.repo.DeleteRole(ctx, roleID); err != nil {
+		epicBot.log.Error("failed to delete role", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка удаления роли.")
+		return
+	}
+	epicBot.recordAudit(ctx, callback.From.Username, audit.ActionRoleDeleted, role.Name)
+	epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("🗑️ Роль «%s» удалена.", role.Name))
+}
@@ -0,0 +1,56 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ─── /scoringtime ─────────────────────────────────────────────────────────
+
+// handleScoringTime reports per team how long scoring of epics takes from
+// starting it to the final score.
+func (epicBot *Bot) handleScoringTime(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleScoringTime"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+
+	stats, err := epicBot.repo.GetScoringTimeStats(ctx)
+	if err != nil {
+		log.Error("error getting scoring time stats", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка получения статистики.")
+		return retErr
+	}
+	if len(stats) == 0 {
+		_, err := epicBot.sendReply(ctx, msg, "ℹ️ Пока нет оценённых эпиков с известным временем оценки.")
+		return err
+	}
+
+	_, err = epicBot.sendReply(ctx, msg, renderScoringTime(stats))
+	return err
+}
+
+// renderScoringTime formats the per-team scoring durations.
+func renderScoringTime(stats []domain.TeamScoringTime) string {
+	var sb strings.Builder
+	sb.WriteString("⏱ Время оценки эпиков (от начала оценки до итога):\n")
+	for _, st := range stats {
+		fmt.Fprintf(&sb, "\n👥 %s — эпиков: %d\n", st.TeamName, st.Epics)
+		fmt.Fprintf(&sb, "  В среднем: %s\n", formatAge(st.Avg))
+		fmt.Fprintf(&sb, "  Быстрее всего: %s\n", formatAge(st.Min))
+		fmt.Fprintf(&sb, "  Дольше всего: %s\n", formatAge(st.Max))
+	}
+	return sb.String()
+}
@@ -0,0 +1,27 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	"EpicScoreBot/internal/models/domain"
+)
+
+func TestRenderScoringTime(t *testing.T) {
+	stats := []domain.TeamScoringTime{
+		{TeamName: "Core", Epics: 3, Avg: 26 * time.Hour, Min: 90 * time.Minute, Max: 50 * time.Hour},
+		{TeamName: "Mobile", Epics: 1, Avg: 30 * time.Second, Min: 30 * time.Second, Max: 30 * time.Second},
+	}
+	want := "⏱ Время оценки эпиков (от начала оценки до итога):\n" +
+		"\n👥 Core — эпиков: 3\n" +
+		"  В среднем: 1 д 2 ч\n" +
+		"  Быстрее всего: 1 ч 30 мин\n" +
+		"  Дольше всего: 2 д 2 ч\n" +
+		"\n👥 Mobile — эпиков: 1\n" +
+		"  В среднем: меньше минуты\n" +
+		"  Быстрее всего: меньше минуты\n" +
+		"  Дольше всего: меньше минуты\n"
+	if got := renderScoringTime(stats); got != want {
+		t.Errorf("renderScoringTime() =\n%s\nwant\n%s", got, want)
+	}
+}
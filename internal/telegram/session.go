@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"maps"
 	"sync"
 	"time"
 )
@@ -20,16 +21,32 @@ const (
 	StepAddEpicName   SessionStep = "addepic_name"
 	StepAddEpicDesc   SessionStep = "addepic_desc"
 
-	// /addrisk interactive flow (epic is picked via inline keyboard)
-	StepAddRiskDesc SessionStep = "addrisk_desc"
+	// /duplicateepic interactive flow (source epic and target team are
+	// picked via inline keyboard)
+	StepDuplicateEpicNumber SessionStep = "duplicateepic_number"
+
+	// /addrisk interactive flow (epic is picked via inline keyboard,
+	// importance via inline keyboard after the description)
+	StepAddRiskDesc       SessionStep = "addrisk_desc"
+	StepAddRiskImportance SessionStep = "addrisk_importance"
 
 	// /score epic effort text-input flow
 	StepScoreEpicEffort SessionStep = "score_epic_effort"
 
+	// /score risk prompt that also accepts a "P I" reply
+	StepScoreRiskReply SessionStep = "score_risk_reply"
+
+	// /feedback comment text (epic is picked via inline keyboard)
+	StepEpicComment SessionStep = "epic_comment"
+
 	// /renameuser interactive flow (user is picked via inline keyboard)
 	StepRenameUserFirstName SessionStep = "renameuser_firstname"
 	StepRenameUserLastName  SessionStep = "renameuser_lastname"
 
+	// /renameteam interactive flow (team is picked via inline keyboard)
+	StepRenameTeamName SessionStep = "renameteam_name"
+	StepRenameTeamDesc SessionStep = "renameteam_desc"
+
 	// /changerate interactive flow (user is picked via inline keyboard)
 	StepChangeRateWeight SessionStep = "changerate_weight"
 
@@ -38,8 +55,8 @@ const (
 	StepConfirmDeleteRisk SessionStep = "confirm_delete_risk"
 )
 
-// sessionTTL is the inactivity timeout for a session.
-const sessionTTL = 5 * time.Minute
+// defaultSessionTTL is the inactivity timeout used when none is configured.
+const defaultSessionTTL = 5 * time.Minute
 
 // Session holds the state of a multi-step admin interaction for one chat.
 type Session struct {
@@ -59,13 +76,30 @@ type sessionKey struct {
 }
 
 // sessions stores active sessions keyed by (chatID, threadID, username).
+//
+// The store keeps its own copies: get and findByChat return snapshots and
+// set stores a copy, so a *Session held by a handler is never shared with
+// another update. Changes to a stored session go through update.
 type sessionStore struct {
 	mu   sync.RWMutex
 	data map[sessionKey]*Session
+	ttl  time.Duration // inactivity timeout
 }
 
-func newSessionStore() *sessionStore {
-	return &sessionStore{data: make(map[sessionKey]*Session)}
+// newSessionStore creates a store whose sessions expire after ttl without
+// activity. A non-positive ttl falls back to defaultSessionTTL.
+func newSessionStore(ttl time.Duration) *sessionStore {
+	if ttl <= 0 {
+		ttl = defaultSessionTTL
+	}
+	return &sessionStore{data: make(map[sessionKey]*Session), ttl: ttl}
+}
+
+// clone returns a copy of sess that shares no map with it.
+func (sess *Session) clone() *Session {
+	c := *sess
+	c.Data = maps.Clone(sess.Data)
+	return &c
 }
 
 func (s *sessionStore) get(key sessionKey) (*Session, bool) {
@@ -75,21 +109,43 @@ func (s *sessionStore) get(key sessionKey) (*Session, bool) {
 	if !ok || time.Now().After(sess.ExpiresAt) {
 		return nil, false
 	}
-	return sess, true
+	return sess.clone(), true
 }
 
 func (s *sessionStore) set(key sessionKey, sess *Session) {
-	sess.ExpiresAt = time.Now().Add(sessionTTL)
+	stored := sess.clone()
+	if stored.Data == nil {
+		stored.Data = make(map[string]string)
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.data[key] = sess
+	stored.ExpiresAt = time.Now().Add(s.ttl)
+	s.data[key] = stored
+}
+
+// update applies fn to the active session under the store's lock and
+// extends its lifetime. It returns a snapshot of the updated session, or
+// false if there is no active session, in which case fn is not called.
+func (s *sessionStore) update(key sessionKey, fn func(*Session)) (*Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.data[key]
+	if !ok || time.Now().After(sess.ExpiresAt) {
+		return nil, false
+	}
+	if sess.Data == nil {
+		sess.Data = make(map[string]string)
+	}
+	fn(sess)
+	sess.ExpiresAt = time.Now().Add(s.ttl)
+	return sess.clone(), true
 }
 
 func (s *sessionStore) touch(key sessionKey) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if sess, ok := s.data[key]; ok {
-		sess.ExpiresAt = time.Now().Add(sessionTTL)
+		sess.ExpiresAt = time.Now().Add(s.ttl)
 	}
 }
 
@@ -107,7 +163,7 @@ func (s *sessionStore) findByChat(chatID int64, threadID int) (*Session, session
 	defer s.mu.RUnlock()
 	for k, sess := range s.data {
 		if k.ChatID == chatID && k.ThreadID == threadID && !time.Now().After(sess.ExpiresAt) {
-			return sess, k, true
+			return sess.clone(), k, true
 		}
 	}
 	return nil, sessionKey{}, false
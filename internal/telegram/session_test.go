@@ -0,0 +1,84 @@
+package telegram
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSessionStoreExpires(t *testing.T) {
+	s := newSessionStore(50 * time.Millisecond)
+	key := sessionKey{ChatID: 1, Username: "ann"}
+	s.set(key, &Session{Step: StepAddUserUsername})
+
+	if _, ok := s.get(key); !ok {
+		t.Fatal("get() right after set() found no session")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if _, ok := s.get(key); ok {
+		t.Error("get() found a session past its TTL")
+	}
+	if _, _, ok := s.findByChat(1, 0); ok {
+		t.Error("findByChat() found a session past its TTL")
+	}
+	if _, ok := s.update(key, func(*Session) { t.Error("update() ran fn on an expired session") }); ok {
+		t.Error("update() found a session past its TTL")
+	}
+}
+
+func TestNewSessionStoreDefaultTTL(t *testing.T) {
+	for _, ttl := range []time.Duration{0, -time.Second} {
+		if got := newSessionStore(ttl).ttl; got != defaultSessionTTL {
+			t.Errorf("newSessionStore(%v).ttl = %v, want %v", ttl, got, defaultSessionTTL)
+		}
+	}
+}
+
+func TestSessionStoreReturnsCopies(t *testing.T) {
+	s := newSessionStore(time.Minute)
+	key := sessionKey{ChatID: 1, Username: "ann"}
+	orig := &Session{Step: StepAddUserUsername, Data: map[string]string{"a": "1"}}
+	s.set(key, orig)
+	orig.Data["a"] = "changed"
+
+	got, _ := s.get(key)
+	got.Data["b"] = "2"
+	found, _, _ := s.findByChat(1, 0)
+	found.Data["c"] = "3"
+
+	again, _ := s.get(key)
+	if len(again.Data) != 1 || again.Data["a"] != "1" {
+		t.Errorf("stored data = %v, want the data passed to set() only", again.Data)
+	}
+}
+
+// TestSessionStoreConcurrentUpdates is meant for go test -race: updates
+// and lookups of one session run concurrently and no update is lost.
+func TestSessionStoreConcurrentUpdates(t *testing.T) {
+	s := newSessionStore(time.Minute)
+	key := sessionKey{ChatID: 1, Username: "ann"}
+	s.set(key, &Session{Step: StepAddUserUsername})
+
+	const n = 50
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.update(key, func(sess *Session) { sess.Data[strconv.Itoa(i)] = "x" })
+		}()
+		go func() {
+			defer wg.Done()
+			if sess, _, ok := s.findByChat(1, 0); ok {
+				_ = len(sess.Data)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sess, _ := s.get(key)
+	if len(sess.Data) != n {
+		t.Errorf("%d keys after %d updates", len(sess.Data), n)
+	}
+}
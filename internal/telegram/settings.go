@@ -0,0 +1,146 @@
+package telegram
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ─── /settings — inline toggles ───────────────────────────────────────────
+
+// canChangeSettings reports whether sender may change the settings of
+// chat. In group chats they affect everyone, so only admins may.
+func (epicBot *Bot) canChangeSettings(chat models.Chat, sender MessageInfo) bool {
+	return chat.Type == models.ChatTypePrivate || epicBot.isAdmin(sender)
+}
+
+// handleSettings shows the settings of the chat with a button per option.
+func (epicBot *Bot) handleSettings(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleSettings"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.canChangeSettings(msg.Chat, fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	settings, err := epicBot.repo.GetChatSettings(ctx, msg.Chat.ID)
+	if err != nil {
+		log.Error("failed to get chat settings", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "settings.error"))
+		return retErr
+	}
+	_, err = epicBot.sendWithKeyboard(ctx, msg, epicBot.t(ctx, msg, "settings.title"),
+		epicBot.settingsKeyboard(ctx, msg, settings))
+	return err
+}
+
+// settingsKeyboard renders one toggle button per setting.
+func (epicBot *Bot) settingsKeyboard(
+	ctx context.Context,
+	msg *models.Message,
+	settings domain.ChatSettings,
+) *models.InlineKeyboardMarkup {
+	hide := epicBot.t(ctx, msg, "settings.off")
+	if epicBot.hideBaselineRisks(settings) {
+		hide = epicBot.t(ctx, msg, "settings.on")
+	}
+	return inlineKeyboard(
+		inlineRow(inlineBtn(epicBot.t(ctx, msg, "settings.language", epicBot.settingsLanguage(settings)),
+			"settings_lang")),
+		inlineRow(inlineBtn(epicBot.t(ctx, msg, "settings.hide_baseline", hide), "settings_hidebaseline")),
+		inlineRow(inlineBtn(epicBot.t(ctx, msg, "settings.done"), "settings_done")),
+	)
+}
+
+// settingsLanguage returns the effective language of settings.
+func (epicBot *Bot) settingsLanguage(settings domain.ChatSettings) string {
+	if settings.Language == "" || !epicBot.i18n.Supported(settings.Language) {
+		return epicBot.i18n.DefaultLanguage()
+	}
+	return settings.Language
+}
+
+// hideBaselineRisks returns the effective HideBaselineRisks of settings.
+func (epicBot *Bot) hideBaselineRisks(settings domain.ChatSettings) bool {
+	if settings.HideBaselineRisks != nil {
+		return *settings.HideBaselineRisks
+	}
+	return epicBot.cfg.BotConfig.HideBaselineRisks
+}
+
+// chatHideBaselineRisks reports whether /results in a chat leaves out
+// risks with the baseline coefficient.
+func (epicBot *Bot) chatHideBaselineRisks(ctx context.Context, chatID int64) bool {
+	settings, err := epicBot.repo.GetChatSettings(ctx, chatID)
+	if err != nil {
+		epicBot.log.Error("failed to get chat settings",
+			slog.Int64("chat_id", chatID), sl.Err(err))
+	}
+	return epicBot.hideBaselineRisks(settings)
+}
+
+// handleSettingsCallback toggles a setting and redraws the keyboard.
+// data = "settings_lang", "settings_hidebaseline" or "settings_done".
+func (epicBot *Bot) handleSettingsCallback(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	data string,
+) {
+	op := "bot.handleSettingsCallback"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.canChangeSettings(msg.Chat, fromCallback(callback)) {
+		epicBot.sendCallbackAlert(ctx, callback, epicBot.t(ctx, msg, "access.admin_only"))
+		return
+	}
+	action := strings.TrimPrefix(data, "settings_")
+	if action == "done" {
+		if err := epicBot.editReply(ctx, msg.Chat.ID, msg.ID, epicBot.t(ctx, msg, "settings.saved")); err != nil {
+			log.Error("failed to edit message", sl.Err(err))
+		}
+		return
+	}
+
+	settings, err := epicBot.repo.GetChatSettings(ctx, msg.Chat.ID)
+	if err != nil {
+		log.Error("failed to get chat settings", sl.Err(err))
+		epicBot.sendCallbackAlert(ctx, callback, epicBot.t(ctx, msg, "settings.error"))
+		return
+	}
+	switch action {
+	case "lang":
+		langs := epicBot.i18n.Languages()
+		next := (slices.Index(langs, epicBot.settingsLanguage(settings)) + 1) % len(langs)
+		settings.Language = langs[next]
+	case "hidebaseline":
+		hide := !epicBot.hideBaselineRisks(settings)
+		settings.HideBaselineRisks = &hide
+	default:
+		log.Warn("unknown settings action", slog.String("action", action))
+		return
+	}
+	if err := epicBot.repo.SaveChatSettings(ctx, settings); err != nil {
+		log.Error("failed to save chat settings", sl.Err(err))
+		epicBot.sendCallbackAlert(ctx, callback, epicBot.t(ctx, msg, "settings.error"))
+		return
+	}
+	if action == "lang" {
+		epicBot.langMu.Lock()
+		epicBot.chatLangs[msg.Chat.ID] = settings.Language
+		epicBot.langMu.Unlock()
+	}
+
+	epicBot.editOrSendWithKeyboard(ctx, msg, msg.ID, epicBot.t(ctx, msg, "settings.title"),
+		epicBot.settingsKeyboard(ctx, msg, settings))
+}
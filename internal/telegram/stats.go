@@ -0,0 +1,70 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ─── /stats ───────────────────────────────────────────────────────────────
+
+// handleStats prints bot-wide counts: teams, users, roles, epics by status,
+// risks and recorded scores.
+func (epicBot *Bot) handleStats(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleStats"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+
+	counts, err := epicBot.repo.GetSystemCounts(ctx)
+	if err != nil {
+		log.Error("error counting rows", sl.Err(err))
+		_, err = epicBot.sendReply(ctx, msg, "❌ Ошибка получения статистики.")
+		return err
+	}
+	statuses, err := epicBot.repo.CountAllEpicsByStatus(ctx)
+	if err != nil {
+		log.Error("error counting epics by status", sl.Err(err))
+		_, err = epicBot.sendReply(ctx, msg, "❌ Ошибка получения статистики.")
+		return err
+	}
+
+	_, err = epicBot.sendMarkdownOrPlain(ctx, msg, formatSystemStats(counts, statuses))
+	return err
+}
+
+// formatSystemStats renders the /stats message as a Markdown code block.
+func formatSystemStats(counts domain.SystemCounts, statuses map[domain.Status]int) string {
+	var sb strings.Builder
+	sb.WriteString("📈 Статистика бота\n")
+	sb.WriteString("```\n")
+	fmt.Fprintf(&sb, "Команд: %d\n", counts.Teams)
+	fmt.Fprintf(&sb, "Пользователей: %d\n", counts.Users)
+	fmt.Fprintf(&sb, "Ролей: %d\n", counts.Roles)
+
+	total := 0
+	for _, n := range statuses {
+		total += n
+	}
+	fmt.Fprintf(&sb, "\nЭпиков: %d\n", total)
+	for _, status := range []domain.Status{domain.StatusNew, domain.StatusScoring, domain.StatusScored} {
+		fmt.Fprintf(&sb, "  %s: %d\n", status, statuses[status])
+	}
+
+	fmt.Fprintf(&sb, "\nРисков: %d\n", counts.Risks)
+	fmt.Fprintf(&sb, "\nОценок трудоёмкости: %d\n", counts.EpicScores)
+	fmt.Fprintf(&sb, "Оценок рисков: %d\n", counts.RiskScores)
+	sb.WriteString("```")
+	return sb.String()
+}
@@ -0,0 +1,28 @@
+package telegram
+
+import (
+	"testing"
+
+	"EpicScoreBot/internal/models/domain"
+)
+
+func TestFormatSystemStats(t *testing.T) {
+	counts := domain.SystemCounts{Teams: 2, Users: 7, Roles: 3, Risks: 4, EpicScores: 11, RiskScores: 9}
+	statuses := map[domain.Status]int{domain.StatusNew: 1, domain.StatusScored: 5}
+	want := "📈 Статистика бота\n" +
+		"```\n" +
+		"Команд: 2\n" +
+		"Пользователей: 7\n" +
+		"Ролей: 3\n" +
+		"\nЭпиков: 6\n" +
+		"  NEW: 1\n" +
+		"  SCORING: 0\n" +
+		"  SCORED: 5\n" +
+		"\nРисков: 4\n" +
+		"\nОценок трудоёмкости: 11\n" +
+		"Оценок рисков: 9\n" +
+		"```"
+	if got := formatSystemStats(counts, statuses); got != want {
+		t.Errorf("formatSystemStats() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,236 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /deleteteam — inline keyboard ───────────────────────────────────────
+
+// handleDeleteTeam shows a team picker for deletion. Teams that still own
+// epics cannot be deleted.
+func (epicBot *Bot) handleDeleteTeam(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+	return epicBot.showTeamPickerInitial(ctx, msg, "deleteteam")
+}
+
+// teamHasEpicsText explains why a team that owns epics cannot be deleted.
+func teamHasEpicsText(teamName string, epics int) string {
+	return fmt.Sprintf("⛔ Команда «%s» не может быть удалена: у неё эпиков: %d.\n"+
+		"Удалите эпики или перенесите их через /mergeteams и повторите.\n"+
+		"Чтобы скрыть команду, сохранив эпики, используйте /archiveteam.", teamName, epics)
+}
+
+// confirmDeleteTeam asks to confirm deletion of a team, or explains why the
+// team cannot be deleted.
+func (epicBot *Bot) confirmDeleteTeam(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	teamID uuid.UUID,
+) {
+	op := "bot.confirmDeleteTeam"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("team_id", teamID.String()),
+	)
+	if !epicBot.isSuperAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return
+	}
+
+	sk := sessionKeyFromCallback(msg, callback)
+	msgID := 0
+	if sess, ok := epicBot.sessions.get(sk); ok {
+		msgID = sess.MessageID
+	}
+
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+	epics, err := epicBot.repo.CountEpicsForTeam(ctx, teamID)
+	if err != nil {
+		log.Error("error counting team epics", sl.Err(err))
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка проверки команды.")
+		return
+	}
+	if epics > 0 {
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, msgID, teamHasEpicsText(team.Name, epics))
+		return
+	}
+	members, err := epicBot.repo.CountTeamMembers(ctx, teamID)
+	if err != nil {
+		log.Error("error counting team members", sl.Err(err))
+	}
+
+	kb := inlineKeyboard(inlineRow(
+		inlineBtn("✅ Да, удалить", "adm_confirm_deleteteam_"+teamID.String()),
+		inlineBtn("❌ Отмена", "adm_deny_deleteteam"),
+	))
+	epicBot.editOrSendWithKeyboard(ctx, msg, msgID,
+		fmt.Sprintf("⚠️ Удалить команду «%s»?\nУчастников будет исключено: %d.\nЭто действие необратимо.",
+			team.Name, members), kb)
+}
+
+// deleteTeam deletes a confirmed team after re-checking that it owns no
+// epics.
+func (epicBot *Bot) deleteTeam(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	teamID uuid.UUID,
+	msgID int,
+) {
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+	// Epics may have been added since the confirmation was shown.
+	epics, err := epicBot.repo.CountEpicsForTeam(ctx, teamID)
+	if err != nil {
+		epicBot.log.Error("failed to count team epics", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка удаления команды.")
+		return
+	}
+	if epics > 0 {
+		epicBot.deleteAndSend(ctx, msg, msgID, teamHasEpicsText(team.Name, epics))
+		return
+	}
+	if err := epicBot.repo.DeleteTeam(ctx, teamID); err != nil {
+		epicBot.log.Error("failed to delete team", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка удаления команды.")
+		return
+	}
+	epicBot.recordAudit(ctx, callback.From.Username, audit.ActionTeamDeleted, team.Name)
+	epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("🗑️ Команда «%s» удалена.", team.Name))
+}
+
+// ─── /renameteam — inline keyboard then session ──────────────────────────
+
+// handleRenameTeam shows a team picker for renaming. The new name and
+// description are then asked for in a session.
+func (epicBot *Bot) handleRenameTeam(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+	return epicBot.showTeamPickerInitial(ctx, msg, "renameteam")
+}
+
+// startRenameTeam asks for the new name of the picked team.
+func (epicBot *Bot) startRenameTeam(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	teamID uuid.UUID,
+) {
+	if !epicBot.isSuperAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return
+	}
+
+	sk := sessionKeyFromCallback(msg, callback)
+	msgID := 0
+	if sess, ok := epicBot.sessions.get(sk); ok {
+		msgID = sess.MessageID
+	}
+
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+
+	epicBot.sessions.set(sk, &Session{
+		Step:      StepRenameTeamName,
+		ThreadID:  msg.MessageThreadID,
+		Username:  callback.From.Username,
+		MessageID: msgID,
+		Data:      map[string]string{"pendingTeamID": teamID.String()},
+	})
+	epicBot.editOrSend(ctx, msg, msgID,
+		fmt.Sprintf("✏️ Изменение команды «%s».\n📝 Введите новое название («-» — оставить текущее):"+cancelHint, team.Name))
+}
+
+// renameTeam saves the name collected in the session and the description
+// entered last. "=" keeps the current description, "-" clears it.
+func (epicBot *Bot) renameTeam(
+	ctx context.Context,
+	msg *models.Message,
+	msgID int,
+	data map[string]string,
+	descText string,
+) {
+	op := "bot.renameTeam"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+
+	teamID, err := uuid.Parse(data["pendingTeamID"])
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка: неверный ID команды.")
+		return
+	}
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+
+	name := team.Name
+	if n, ok := data["name"]; ok {
+		name = n
+	}
+	description := descText
+	switch descText {
+	case "=":
+		description = team.Description
+	case "-":
+		description = ""
+	}
+
+	if err := epicBot.repo.UpdateTeam(ctx, teamID, name, description); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrAlreadyExists):
+			epicBot.deleteAndSend(ctx, msg, msgID, fmt.Sprintf("❌ Команда «%s» уже существует.", name))
+		case errors.Is(err, repositories.ErrNotFound):
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Команда не найдена.")
+		default:
+			log.Error("error updating team", sl.Err(err))
+			epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка изменения команды.")
+		}
+		return
+	}
+
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionTeamUpdated,
+		fmt.Sprintf("%s → %s", team.Name, name))
+	text := fmt.Sprintf("✅ Команда «%s» обновлена.", name)
+	if name != team.Name {
+		text = fmt.Sprintf("✅ Команда «%s» переименована в «%s».", team.Name, name)
+	}
+	if description != "" {
+		text += "\nОписание: " + description
+	}
+	epicBot.deleteAndSend(ctx, msg, msgID, text)
+}
@@ -0,0 +1,119 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /teamstats — inline keyboard ────────────────────────────────────────
+
+func (epicBot *Bot) handleTeamStats(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	return epicBot.showTeamPickerInitial(ctx, msg, "teamstats")
+}
+
+// showTeamStats replaces the team picker with a summary of the team:
+// members, role distribution, epics by status and the average final score.
+func (epicBot *Bot) showTeamStats(ctx context.Context, msg *models.Message, teamID uuid.UUID, msgID int) {
+	op := "bot.showTeamStats"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+		slog.String("team_id", teamID.String()),
+	)
+
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+	members, err := epicBot.repo.CountTeamMembers(ctx, teamID)
+	if err != nil {
+		log.Error("error counting team members", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка получения статистики команды.")
+		return
+	}
+	roles, err := epicBot.repo.GetRoleDistributionForTeam(ctx, teamID)
+	if err != nil {
+		log.Error("error getting role distribution", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка получения статистики команды.")
+		return
+	}
+	statuses, err := epicBot.repo.CountEpicsByStatusForTeam(ctx, teamID)
+	if err != nil {
+		log.Error("error counting epics by status", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка получения статистики команды.")
+		return
+	}
+	scored, err := epicBot.repo.GetEpicsByTeamIDAndStatus(ctx, teamID, domain.StatusScored)
+	if err != nil {
+		log.Error("error getting scored epics", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка получения статистики команды.")
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("📊 Статистика команды\n")
+	sb.WriteString("```\n")
+	fmt.Fprintf(&sb, "Команда: %s\n\n", codeSafe(team.Name))
+	fmt.Fprintf(&sb, "Участников: %d\n", members)
+	withRole := 0
+	for _, r := range roles {
+		fmt.Fprintf(&sb, "  %s: %d\n", codeSafe(r.Name), r.UserCount)
+		withRole += r.UserCount
+	}
+	if members > withRole {
+		fmt.Fprintf(&sb, "  без роли: %d\n", members-withRole)
+	}
+
+	total := 0
+	for _, n := range statuses {
+		total += n
+	}
+	fmt.Fprintf(&sb, "\nЭпиков: %d\n", total)
+	for _, status := range []domain.Status{domain.StatusNew, domain.StatusScoring, domain.StatusScored} {
+		if n := statuses[status]; n > 0 {
+			fmt.Fprintf(&sb, "  %s: %d\n", status, n)
+		}
+	}
+
+	var sum float64
+	var finals int
+	for _, e := range scored {
+		if e.FinalScore != nil {
+			sum += *e.FinalScore
+			finals++
+		}
+	}
+	if finals > 0 {
+		fmt.Fprintf(&sb, "\nСредняя итоговая оценка: %.1f\n", sum/float64(finals))
+	} else {
+		sb.WriteString("\nСредняя итоговая оценка: —\n")
+	}
+	sb.WriteString("```")
+
+	if msgID > 0 {
+		if err := epicBot.deleteMessage(ctx, msg.Chat.ID, msgID); err != nil {
+			log.Error("failed to delete message", sl.Err(err))
+		}
+	}
+	if _, err := epicBot.sendMarkdownOrPlain(ctx, msg, sb.String()); err != nil {
+		log.Error("failed to send team stats", sl.Err(err))
+	}
+}
+
+// codeSafe strips characters that would terminate a Markdown code block.
+func codeSafe(s string) string {
+	return strings.ReplaceAll(s, "`", "'")
+}
@@ -0,0 +1,282 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /setteamweights — team picker, then inline weight editor ─────────────
+
+// teamWeightStep is how much one tap on ➖/➕ changes a weight.
+const teamWeightStep = 10
+
+// handleSetTeamWeights shows a team picker for editing the weights of all
+// its members at once.
+func (epicBot *Bot) handleSetTeamWeights(ctx context.Context, msg *models.Message) error {
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+	return epicBot.showTeamPickerInitial(ctx, msg, "setteamweights")
+}
+
+// startTeamWeights loads the current weights of the picked team into the
+// session and shows the editor. Nothing is saved until «Сохранить».
+func (epicBot *Bot) startTeamWeights(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	teamID uuid.UUID,
+) {
+	if !epicBot.isSuperAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return
+	}
+
+	sk := sessionKeyFromCallback(msg, callback)
+	msgID := 0
+	if sess, ok := epicBot.sessions.get(sk); ok {
+		msgID = sess.MessageID
+	}
+
+	members, err := epicBot.repo.GetUsersByTeamID(ctx, teamID)
+	if err != nil || len(members) == 0 {
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ В команде нет участников.")
+		return
+	}
+
+	sess := &Session{
+		ThreadID:  msg.MessageThreadID,
+		Username:  callback.From.Username,
+		MessageID: msgID,
+		Data:      map[string]string{"teamID": teamID.String()},
+	}
+	for _, u := range members {
+		sess.Data["w_"+u.ID.String()] = strconv.Itoa(u.Weight)
+	}
+	epicBot.sessions.set(sk, sess)
+	epicBot.showTeamWeights(ctx, msg, sk, sess)
+}
+
+// showTeamWeights renders the editor with the weights held in the session.
+func (epicBot *Bot) showTeamWeights(ctx context.Context, msg *models.Message, sk sessionKey, sess *Session) {
+	teamID, err := uuid.Parse(sess.Data["teamID"])
+	if err != nil {
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, sess.MessageID, "❌ Ошибка: неверный ID команды.")
+		return
+	}
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		epicBot.sessions.clear(sk)
+		epicBot.deleteAndSend(ctx, msg, sess.MessageID, epicBot.lookupErrorText(ctx, msg, err, "team.not_found"))
+		return
+	}
+	members, err := epicBot.repo.GetUsersByTeamID(ctx, teamID)
+	if err != nil {
+		epicBot.sessions.clear(sk)
+		epicBot.log.Error("error getting team members", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, sess.MessageID, "❌ Ошибка получения участников.")
+		return
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	for _, u := range members {
+		w, ok := sess.Data["w_"+u.ID.String()]
+		if !ok {
+			// Joined the team after the editor was opened.
+			continue
+		}
+		id := u.ID.String()
+		rows = append(rows, inlineRow(
+			inlineBtn("➖", "adm_tw_dec_"+id),
+			epicBot.pickerBtn(fmt.Sprintf("%s %s: %s", u.FirstName, u.LastName, w), "adm_tw_noop"),
+			inlineBtn("➕", "adm_tw_inc_"+id),
+		))
+	}
+	rows = append(rows,
+		inlineRow(inlineBtn("⚖️ Поровну", "adm_tw_equal")),
+		inlineRow(inlineBtn("✅ Сохранить", "adm_tw_save"), inlineBtn("❌ Отмена", "adm_cancel")),
+	)
+	epicBot.editOrSendWithKeyboard(ctx, msg, sess.MessageID,
+		fmt.Sprintf("⚖️ Веса участников команды «%s» (0–100, шаг %d).\n"+
+			"«Поровну» делит 100 между всеми. Изменения применяются кнопкой «Сохранить».",
+			team.Name, teamWeightStep),
+		inlineKeyboard(rows...))
+}
+
+// handleAdmTeamWeights handles the buttons of the weight editor.
+// data = "adm_tw_inc_<userID>", "adm_tw_dec_<userID>", "adm_tw_equal",
+// "adm_tw_save" or "adm_tw_noop".
+func (epicBot *Bot) handleAdmTeamWeights(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	data string,
+) {
+	if !epicBot.isSuperAdmin(fromCallback(callback)) {
+		epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return
+	}
+	action := strings.TrimPrefix(data, "adm_tw_")
+	if action == "noop" {
+		return
+	}
+
+	sk := sessionKeyFromCallback(msg, callback)
+	sess, ok := epicBot.sessions.get(sk)
+	if !ok || sess.Data["teamID"] == "" {
+		epicBot.sendReply(ctx, msg, "❌ Сессия истекла. Повторите команду.")
+		return
+	}
+
+	switch {
+	case action == "save":
+		epicBot.sessions.clear(sk)
+		epicBot.saveTeamWeights(ctx, msg, callback, sess)
+		return
+
+	case action == "equal":
+		// Keys in member order, so the remainder goes to the same
+		// members as listed.
+		teamID, _ := uuid.Parse(sess.Data["teamID"])
+		members, err := epicBot.repo.GetUsersByTeamID(ctx, teamID)
+		if err != nil {
+			epicBot.log.Error("error getting team members", sl.Err(err))
+			epicBot.sendReply(ctx, msg, "❌ Ошибка получения участников.")
+			return
+		}
+		sess, ok = epicBot.sessions.update(sk, func(s *Session) {
+			var keys []string
+			for _, u := range members {
+				if _, ok := s.Data["w_"+u.ID.String()]; ok {
+					keys = append(keys, "w_"+u.ID.String())
+				}
+			}
+			for i, w := range equalWeights(len(keys)) {
+				s.Data[keys[i]] = strconv.Itoa(w)
+			}
+		})
+
+	case strings.HasPrefix(action, "inc_"), strings.HasPrefix(action, "dec_"):
+		key := "w_" + action[len("inc_"):]
+		step := teamWeightStep
+		if strings.HasPrefix(action, "dec_") {
+			step = -teamWeightStep
+		}
+		valid := true
+		sess, ok = epicBot.sessions.update(sk, func(s *Session) {
+			w, err := strconv.Atoi(s.Data[key])
+			if err != nil {
+				valid = false
+				return
+			}
+			s.Data[key] = strconv.Itoa(min(max(w+step, 0), 100))
+		})
+		if ok && !valid {
+			epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+			return
+		}
+
+	default:
+		epicBot.sendReply(ctx, msg, "❌ Некорректные данные.")
+		return
+	}
+
+	if !ok {
+		epicBot.sendReply(ctx, msg, "❌ Сессия истекла. Повторите команду.")
+		return
+	}
+	epicBot.showTeamWeights(ctx, msg, sk, sess)
+}
+
+// equalWeights splits 100 between n members. The remainder goes one point
+// each to the first members, and nobody gets less than 1.
+func equalWeights(n int) []int {
+	if n <= 0 {
+		return nil
+	}
+	weights := make([]int, n)
+	base, rest := 100/n, 100%n
+	if base == 0 {
+		base, rest = 1, 0
+	}
+	for i := range weights {
+		weights[i] = base
+		if i < rest {
+			weights[i]++
+		}
+	}
+	return weights
+}
+
+// saveTeamWeights stores the weights that differ from the current ones in
+// one transaction.
+func (epicBot *Bot) saveTeamWeights(
+	ctx context.Context,
+	msg *models.Message,
+	callback *models.CallbackQuery,
+	sess *Session,
+) {
+	op := "bot.saveTeamWeights"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	msgID := sess.MessageID
+
+	teamID, err := uuid.Parse(sess.Data["teamID"])
+	if err != nil {
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка: неверный ID команды.")
+		return
+	}
+	members, err := epicBot.repo.GetUsersByTeamID(ctx, teamID)
+	if err != nil {
+		log.Error("error getting team members", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка получения участников.")
+		return
+	}
+
+	changed := make(map[uuid.UUID]int)
+	var sb strings.Builder
+	for _, u := range members {
+		w, err := strconv.Atoi(sess.Data["w_"+u.ID.String()])
+		if err != nil || w == u.Weight {
+			continue
+		}
+		changed[u.ID] = w
+		fmt.Fprintf(&sb, "\n• %s %s (@%s): %d → %d", u.FirstName, u.LastName, u.TelegramID, u.Weight, w)
+	}
+	if len(changed) == 0 {
+		epicBot.deleteAndSend(ctx, msg, msgID, "ℹ️ Веса не изменились.")
+		return
+	}
+
+	if err := epicBot.repo.UpdateWeights(ctx, changed); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			epicBot.deleteAndSend(ctx, msg, msgID,
+				"❌ Один из участников был удалён. Веса не изменены, повторите команду.")
+			return
+		}
+		log.Error("error updating weights", sl.Err(err))
+		epicBot.deleteAndSend(ctx, msg, msgID, "❌ Ошибка изменения весов.")
+		return
+	}
+	for userID, w := range changed {
+		epicBot.recordAudit(ctx, callback.From.Username, audit.ActionWeightChanged,
+			fmt.Sprintf("%s → %d", userID, w))
+	}
+	epicBot.deleteAndSend(ctx, msg, msgID, "✅ Веса обновлены:"+sb.String())
+}
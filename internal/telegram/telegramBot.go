@@ -1,13 +1,22 @@
 package telegram
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"net/http"
+	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/i18n"
+	"EpicScoreBot/internal/repositories"
 	"EpicScoreBot/internal/utils/logger/sl"
 
 	"github.com/go-telegram/bot"
@@ -21,8 +30,18 @@ type Bot struct {
 	repo        Repository
 	scoring     ScoringService
 	ai          AIClient
+	audit       AuditRecorder
+	i18n        *i18n.Localizer
+	langMu      sync.RWMutex
+	adminsMu    sync.RWMutex     // guards cfg.BotConfig.Admins and its writes to disk
+	chatLangs   map[int64]string // cached per-chat language choices
 	sessions    *sessionStore
+	undo        *undoStore
+	limiter     *rateLimiter
+	epicNumber  *regexp.Regexp // nil when any epic number is allowed
 	botUsername string
+	version     string
+	startedAt   time.Time
 	ctx         context.Context
 	cancel      context.CancelFunc
 	log         *slog.Logger
@@ -35,6 +54,9 @@ func New(
 	repo Repository,
 	scoringSvc ScoringService,
 	aiClient AIClient,
+	auditRec AuditRecorder,
+	localizer *i18n.Localizer,
+	version string,
 ) *Bot {
 	op := "telegram.New()"
 	log := logger.With(slog.String("op", op))
@@ -42,14 +64,31 @@ func New(
 	ctx, cancel := context.WithCancel(context.Background())
 
 	epicBot := &Bot{
-		cfg:      cfg,
-		repo:     repo,
-		scoring:  scoringSvc,
-		ai:       aiClient,
-		sessions: newSessionStore(),
-		ctx:      ctx,
-		cancel:   cancel,
-		log:      log,
+		cfg:       cfg,
+		repo:      repo,
+		scoring:   scoringSvc,
+		ai:        aiClient,
+		audit:     auditRec,
+		i18n:      localizer,
+		chatLangs: make(map[int64]string),
+		sessions:  newSessionStore(cfg.BotConfig.SessionTTL),
+		undo:      newUndoStore(),
+		limiter:   newRateLimiter(cfg.BotConfig.RateLimit, cfg.BotConfig.RateLimitBurst),
+		version:   version,
+		startedAt: time.Now(),
+		ctx:       ctx,
+		cancel:    cancel,
+		log:       log,
+	}
+
+	if pattern := cfg.BotConfig.EpicNumberPattern; pattern != "" {
+		re, err := compileEpicNumberPattern(pattern)
+		if err != nil {
+			log.Error("invalid epic number pattern, any number is allowed",
+				slog.String("pattern", pattern), sl.Err(err))
+		} else {
+			epicBot.epicNumber = re
+		}
 	}
 
 	b, err := bot.New(cfg.BotConfig.TgbotApiToken,
@@ -96,6 +135,10 @@ func (epicBot *Bot) defaultHandler(ctx context.Context, b *bot.Bot, update *mode
 		)
 	}
 
+	if !epicBot.allowUpdate(ctx, update) {
+		return
+	}
+
 	switch {
 	case update.Message != nil && isCommand(update.Message):
 		if err := epicBot.commandHandler(ctx, update); err != nil {
@@ -103,6 +146,8 @@ func (epicBot *Bot) defaultHandler(ctx context.Context, b *bot.Bot, update *mode
 		}
 	case update.CallbackQuery != nil:
 		epicBot.handleCallbackQuery(ctx, update)
+	case update.Message != nil && epicBot.handleRiskReply(ctx, update.Message):
+		// risk score given as a reply to the risk prompt
 	case update.Message != nil && isBotMentioned(update.Message, epicBot.botUsername):
 		epicBot.handleMention(ctx, update)
 	case update.Message != nil:
@@ -110,12 +155,58 @@ func (epicBot *Bot) defaultHandler(ctx context.Context, b *bot.Bot, update *mode
 	}
 }
 
+// allowUpdate applies the per-user rate limit. Excess messages are
+// dropped silently; excess button taps get a "slow down" alert so the
+// button does not keep spinning.
+func (epicBot *Bot) allowUpdate(ctx context.Context, update *models.Update) bool {
+	var sender MessageInfo
+	switch {
+	case update.CallbackQuery != nil:
+		sender = fromCallback(update.CallbackQuery)
+	case update.Message != nil && update.Message.From != nil:
+		sender = fromMessage(update.Message)
+	default:
+		return true
+	}
+	if epicBot.isAdmin(sender) || epicBot.limiter.allow(sender.SenderID(), time.Now()) {
+		return true
+	}
+
+	epicBot.log.Debug("rate limited",
+		slog.Int64("user_id", sender.SenderID()),
+		slog.String("user_name", sender.SenderUsername()))
+	if callback := update.CallbackQuery; callback != nil {
+		lang := epicBot.i18n.DefaultLanguage()
+		if msg := callback.Message.Message; msg != nil {
+			lang = epicBot.chatLanguage(ctx, msg.Chat.ID)
+		}
+		if _, err := epicBot.b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: callback.ID,
+			Text:            epicBot.i18n.T(lang, "rate_limited"),
+			ShowAlert:       true,
+		}); err != nil {
+			epicBot.log.Error("failed to answer throttled callback", sl.Err(err))
+		}
+	}
+	return false
+}
+
+// commandSource returns the text and entities a command is read from:
+// the message text, or the caption for media messages such as documents.
+func commandSource(msg *models.Message) (string, []models.MessageEntity) {
+	if msg.Text == "" && msg.Caption != "" {
+		return msg.Caption, msg.CaptionEntities
+	}
+	return msg.Text, msg.Entities
+}
+
 // isCommand reports whether msg is a bot command.
 func isCommand(msg *models.Message) bool {
-	if msg == nil || len(msg.Entities) == 0 {
+	if msg == nil {
 		return false
 	}
-	for _, e := range msg.Entities {
+	_, entities := commandSource(msg)
+	for _, e := range entities {
 		if e.Type == models.MessageEntityTypeBotCommand && e.Offset == 0 {
 			return true
 		}
@@ -125,12 +216,13 @@ func isCommand(msg *models.Message) bool {
 
 // commandText extracts /command from a message (without @botname suffix).
 func commandText(msg *models.Message) string {
-	if msg == nil || len(msg.Entities) == 0 {
+	if msg == nil {
 		return ""
 	}
-	for _, e := range msg.Entities {
+	text, entities := commandSource(msg)
+	for _, e := range entities {
 		if e.Type == models.MessageEntityTypeBotCommand && e.Offset == 0 {
-			raw := []rune(msg.Text)[e.Offset : e.Offset+e.Length]
+			raw := []rune(text)[e.Offset : e.Offset+e.Length]
 			cmd := string(raw)
 			// strip leading slash
 			if len(cmd) > 0 && cmd[0] == '/' {
@@ -151,13 +243,14 @@ func commandText(msg *models.Message) string {
 
 // commandArguments returns the text that follows the first /command entity.
 func commandArguments(msg *models.Message) string {
-	if msg == nil || len(msg.Entities) == 0 {
+	if msg == nil {
 		return ""
 	}
-	for _, e := range msg.Entities {
+	text, entities := commandSource(msg)
+	for _, e := range entities {
 		if e.Type == models.MessageEntityTypeBotCommand && e.Offset == 0 {
 			end := e.Offset + e.Length
-			runes := []rune(msg.Text)
+			runes := []rune(text)
 			if end >= len(runes) {
 				return ""
 			}
@@ -216,6 +309,19 @@ func (epicBot *Bot) sendMarkdown(ctx context.Context, msg *models.Message, text
 	return epicBot.b.SendMessage(ctx, p)
 }
 
+// sendMarkdownOrPlain sends a Markdown reply. If Telegram rejects it, the
+// error is logged and the text is sent once more without markup, so the
+// information still reaches the user.
+func (epicBot *Bot) sendMarkdownOrPlain(ctx context.Context, msg *models.Message, text string) (*models.Message, error) {
+	sent, err := epicBot.sendMarkdown(ctx, msg, text)
+	if err == nil {
+		return sent, nil
+	}
+	epicBot.log.Error("failed to send markdown, sending plain text",
+		slog.Int64("chat_id", msg.Chat.ID), sl.Err(err))
+	return epicBot.sendReply(ctx, msg, stripMarkdownV2(text))
+}
+
 // sendHTML sends an HTML-formatted reply to the given chat/topic.
 // HTML is more reliable than Markdown in Telegram because special characters
 // in usernames and text don't break the parser.
@@ -231,6 +337,58 @@ func (epicBot *Bot) sendHTML(ctx context.Context, msg *models.Message, text stri
 	return epicBot.b.SendMessage(ctx, p)
 }
 
+// sendDocument uploads data as a file to the given chat/topic.
+func (epicBot *Bot) sendDocument(
+	ctx context.Context,
+	msg *models.Message,
+	filename string,
+	data []byte,
+	caption string,
+) (*models.Message, error) {
+	p := &bot.SendDocumentParams{
+		ChatID:   msg.Chat.ID,
+		Document: &models.InputFileUpload{Filename: filename, Data: bytes.NewReader(data)},
+		Caption:  caption,
+	}
+	if msg.MessageThreadID != 0 {
+		p.MessageThreadID = msg.MessageThreadID
+	}
+	return epicBot.b.SendDocument(ctx, p)
+}
+
+// downloadFile fetches a file sent to the bot, refusing files larger
+// than maxSize bytes.
+func (epicBot *Bot) downloadFile(ctx context.Context, fileID string, maxSize int64) ([]byte, error) {
+	file, err := epicBot.b.GetFile(ctx, &bot.GetFileParams{FileID: fileID})
+	if err != nil {
+		return nil, fmt.Errorf("downloadFile: get file: %w", err)
+	}
+	if file.FileSize > maxSize {
+		return nil, fmt.Errorf("downloadFile: file is too large: %d bytes", file.FileSize)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, epicBot.b.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, fmt.Errorf("downloadFile: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloadFile: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloadFile: unexpected status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("downloadFile: read: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("downloadFile: file is too large")
+	}
+	return data, nil
+}
+
 // sendWithKeyboard sends a plain-text reply with an inline keyboard.
 func (epicBot *Bot) sendWithKeyboard(
 	ctx context.Context,
@@ -354,6 +512,58 @@ func inlineBtn(text, data string) models.InlineKeyboardButton {
 	return models.InlineKeyboardButton{Text: text, CallbackData: data}
 }
 
+// lookupErrorText renders the catalog message notFoundKey when a
+// repository lookup found nothing. Any other error is logged and answered
+// with a generic failure message: database outages are not reported as
+// missing records, and error details stay out of the chat.
+func (epicBot *Bot) lookupErrorText(
+	ctx context.Context,
+	msg *models.Message,
+	err error,
+	notFoundKey string,
+	args ...any,
+) string {
+	if errors.Is(err, repositories.ErrNotFound) {
+		return epicBot.t(ctx, msg, notFoundKey, args...)
+	}
+	epicBot.log.Error("lookup failed", sl.Err(err))
+	return epicBot.t(ctx, msg, "error.database")
+}
+
+// epicNumberErrorText is lookupErrorText for an epic looked up by number
+// alone, which fails with ErrAmbiguous when several teams use the number.
+func (epicBot *Bot) epicNumberErrorText(ctx context.Context, msg *models.Message, err error, number string) string {
+	if errors.Is(err, repositories.ErrAmbiguous) {
+		return epicBot.t(ctx, msg, "epic.number_ambiguous", number)
+	}
+	return epicBot.lookupErrorText(ctx, msg, err, "epic.number_not_found", number)
+}
+
+// compileEpicNumberPattern compiles BotConfig.EpicNumberPattern so that it
+// must match a whole epic number.
+func compileEpicNumberPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
+
+// truncateLabel shortens s to at most limit characters, replacing the tail
+// with an ellipsis when it does not fit.
+func truncateLabel(s string, limit int) string {
+	runes := []rune(s)
+	if limit <= 0 || len(runes) <= limit {
+		return s
+	}
+	if limit == 1 {
+		return "…"
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// pickerBtn creates an inline button whose label is truncated to the
+// configured maximum so verbose names never get the keyboard rejected.
+func (epicBot *Bot) pickerBtn(text, data string) models.InlineKeyboardButton {
+	return inlineBtn(truncateLabel(text, epicBot.cfg.BotConfig.ButtonLabelLimit()), data)
+}
+
 // escapeMarkdownV2 escapes all MarkdownV2 reserved characters in a string
 // so it can be safely embedded in a MarkdownV2-formatted message.
 func escapeMarkdownV2(s string) string {
@@ -381,6 +591,27 @@ func escapeMarkdownV2(s string) string {
 	return replacer.Replace(s)
 }
 
+// stripMarkdownV2 turns MarkdownV2 into plain text: escaped characters are
+// kept literally and formatting markers are dropped.
+func stripMarkdownV2(s string) string {
+	var sb strings.Builder
+	escaped := false
+	for _, r := range s {
+		switch {
+		case escaped:
+			sb.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case strings.ContainsRune("*_~`|", r):
+			// formatting marker
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
 // splitTextIntoChunks splits text into chunks of the specified size.
 func splitTextIntoChunks(text string, chunkSize int) []string {
 	var chunks []string
@@ -0,0 +1,343 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"EpicScoreBot/internal/config"
+	"EpicScoreBot/internal/i18n"
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+func TestEscapeMarkdownV2(t *testing.T) {
+	for _, r := range markdownSpecials {
+		t.Run(string(r), func(t *testing.T) {
+			want := `\` + string(r)
+			if got := escapeMarkdownV2(string(r)); got != want {
+				t.Errorf("escapeMarkdownV2(%q) = %q, want %q", string(r), got, want)
+			}
+		})
+	}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"Login page", "Login page"},
+		{"Эпик «Вход»", "Эпик «Вход»"},
+		{markdownSpecials, escapedSpecials},
+		{`a\_b`, `a\\\_b`},
+	}
+	for _, tt := range tests {
+		if got := escapeMarkdownV2(tt.in); got != tt.want {
+			t.Errorf("escapeMarkdownV2(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStripMarkdownV2UndoesEscaping(t *testing.T) {
+	for _, s := range []string{"Login", markdownSpecials, "a*b_c" + markdownSpecials + "«д»"} {
+		if got := stripMarkdownV2(escapeMarkdownV2(s)); got != s {
+			t.Errorf("stripMarkdownV2(escapeMarkdownV2(%q)) = %q", s, got)
+		}
+	}
+	if got := stripMarkdownV2("*bold* _it_ " + escapeMarkdownV2("1.5")); got != "bold it 1.5" {
+		t.Errorf("stripMarkdownV2() = %q, want %q", got, "bold it 1.5")
+	}
+}
+
+func TestSendMarkdownOrPlain(t *testing.T) {
+	text := "*Итог* " + escapeMarkdownV2("1.5 (x_y)")
+	tests := []struct {
+		name      string
+		reject    func(apiCall) bool
+		wantTexts []string
+	}{
+		{"markdown accepted", nil, []string{text}},
+		{
+			"falls back to plain text",
+			func(c apiCall) bool { return c.Params["parse_mode"] == string(models.ParseModeMarkdown) },
+			[]string{text, "Итог 1.5 (x_y)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			epicBot, api := newTestBot(t, nil, &fakeRepo{})
+			api.reject = tt.reject
+
+			if _, err := epicBot.sendMarkdownOrPlain(context.Background(), testMessage("/report"), text); err != nil {
+				t.Fatalf("sendMarkdownOrPlain() error = %v", err)
+			}
+
+			calls := api.Calls()
+			if len(calls) != len(tt.wantTexts) {
+				t.Fatalf("sent %d messages, want %d", len(calls), len(tt.wantTexts))
+			}
+			for i, c := range calls {
+				if c.Params["text"] != tt.wantTexts[i] {
+					t.Errorf("message %d = %q, want %q", i, c.Params["text"], tt.wantTexts[i])
+				}
+			}
+			if last := calls[len(calls)-1]; tt.reject != nil && last.Params["parse_mode"] != "" {
+				t.Errorf("fallback sent with parse mode %q", last.Params["parse_mode"])
+			}
+		})
+	}
+}
+
+func TestLookupErrorText(t *testing.T) {
+	epicBot, _ := newTestBot(t, nil, &fakeRepo{})
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"not found", fmt.Errorf("Repository.GetEpicByID: %w", repositories.ErrNotFound), "❌ Эпик не найден."},
+		{"query error", errors.New("dial tcp 10.0.0.5:5432: connection refused"), "❌ Ошибка базы данных. Попробуйте позже."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := epicBot.lookupErrorText(context.Background(), testMessage(""), tt.err, "epic.not_found"); got != tt.want {
+				t.Errorf("lookupErrorText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateLabel(t *testing.T) {
+	tests := []struct {
+		name  string
+		s     string
+		limit int
+		want  string
+	}{
+		{"fits", "Login", 10, "Login"},
+		{"exactly the limit", "Login", 5, "Login"},
+		{"cut with an ellipsis", "Login page", 6, "Login…"},
+		{"counts runes, not bytes", "Эпик входа", 5, "Эпик…"},
+		{"limit of one", "Login", 1, "…"},
+		{"no limit", "Login page", 0, "Login page"},
+		{"negative limit", "Login page", -1, "Login page"},
+		{"empty", "", 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateLabel(tt.s, tt.limit); got != tt.want {
+				t.Errorf("truncateLabel(%q, %d) = %q, want %q", tt.s, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+// apiCall is one request the bot made to the Telegram Bot API.
+type apiCall struct {
+	Method string
+	Params map[string]string
+}
+
+// fakeTelegram is a Bot API server that records the calls it receives.
+// Calls matched by reject fail with 400 Bad Request.
+type fakeTelegram struct {
+	mu     sync.Mutex
+	calls  []apiCall
+	reject func(call apiCall) bool
+}
+
+func (f *fakeTelegram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	call := apiCall{Method: path.Base(r.URL.Path), Params: make(map[string]string)}
+	if err := r.ParseMultipartForm(1 << 20); err == nil {
+		for k, v := range r.MultipartForm.Value {
+			call.Params[k] = v[0]
+		}
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, call)
+	reject := f.reject != nil && f.reject(call)
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case reject:
+		io.WriteString(w, `{"ok":false,"error_code":400,"description":"Bad Request: can't parse entities"}`)
+	case call.Method == "deleteMessage" || call.Method == "answerCallbackQuery":
+		io.WriteString(w, `{"ok":true,"result":true}`)
+	default:
+		io.WriteString(w, `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`)
+	}
+}
+
+// Calls returns the calls received so far.
+func (f *fakeTelegram) Calls() []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]apiCall(nil), f.calls...)
+}
+
+// Texts returns the text of every message sent or edited so far.
+func (f *fakeTelegram) Texts() []string {
+	var texts []string
+	for _, c := range f.Calls() {
+		if text, ok := c.Params["text"]; ok {
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// newTestBot returns a Bot talking to a fake Telegram server. The config
+// may be nil.
+func newTestBot(t *testing.T, cfg *config.Config, repo Repository) (*Bot, *fakeTelegram) {
+	t.Helper()
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	api := &fakeTelegram{}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	b, err := bot.New("test-token", bot.WithServerURL(srv.URL), bot.WithSkipGetMe())
+	if err != nil {
+		t.Fatalf("bot.New() error = %v", err)
+	}
+	localizer, err := i18n.New("ru")
+	if err != nil {
+		t.Fatalf("i18n.New() error = %v", err)
+	}
+	return &Bot{
+		b:         b,
+		cfg:       cfg,
+		repo:      repo,
+		i18n:      localizer,
+		chatLangs: make(map[int64]string),
+		sessions:  newSessionStore(0),
+		undo:      newUndoStore(),
+		ctx:       context.Background(),
+		log:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}, api
+}
+
+// testMessage is an incoming private message from user "ann".
+func testMessage(text string) *models.Message {
+	return &models.Message{
+		ID:   10,
+		Text: text,
+		Chat: models.Chat{ID: 1, Type: models.ChatTypePrivate},
+		From: &models.User{ID: 100, Username: "ann"},
+	}
+}
+
+// testCommand is testMessage with its first word marked as a command.
+func testCommand(text string) *models.Message {
+	msg := testMessage(text)
+	cmd, _, _ := strings.Cut(text, " ")
+	msg.Entities = []models.MessageEntity{
+		{Type: models.MessageEntityTypeBotCommand, Offset: 0, Length: len([]rune(cmd))},
+	}
+	return msg
+}
+
+// fakeRepo serves the records a test puts in it. Methods the tests do not
+// reach are left to the embedded nil interface.
+type fakeRepo struct {
+	Repository
+
+	epic      *domain.Epic
+	epicScore *domain.EpicScore
+	riskScore *domain.RiskScore
+	epics     []domain.Epic // listed by the epic pickers
+	team      *domain.Team
+	lookupErr error // returned by the epic lookups when set
+	createErr error // returned by CreateEpic when set
+
+	lookedUpNumbers []string
+}
+
+func (r *fakeRepo) GetChatLanguage(context.Context, int64) (string, error) {
+	return "", nil
+}
+
+func (r *fakeRepo) GetEpicByID(_ context.Context, _ uuid.UUID) (*domain.Epic, error) {
+	if r.lookupErr != nil {
+		return nil, r.lookupErr
+	}
+	if r.epic == nil {
+		return nil, repositories.ErrNotFound
+	}
+	return r.epic, nil
+}
+
+func (r *fakeRepo) GetEpicByNumber(_ context.Context, number string) (*domain.Epic, error) {
+	if r.lookupErr != nil {
+		return nil, r.lookupErr
+	}
+	if r.epic == nil || r.epic.Number != number {
+		return nil, repositories.ErrNotFound
+	}
+	return r.epic, nil
+}
+
+func (r *fakeRepo) GetEpicByNumberAndTeam(_ context.Context, number string, _ uuid.UUID) (*domain.Epic, error) {
+	r.lookedUpNumbers = append(r.lookedUpNumbers, number)
+	if r.epic == nil || r.epic.Number != number {
+		return nil, repositories.ErrNotFound
+	}
+	return r.epic, nil
+}
+
+func (r *fakeRepo) CreateEpic(_ context.Context, number, name, desc string, teamID uuid.UUID) (*domain.Epic, error) {
+	if r.createErr != nil {
+		return nil, r.createErr
+	}
+	return &domain.Epic{ID: uuid.New(), Number: number, Name: name, Description: desc, TeamID: teamID}, nil
+}
+
+func (r *fakeRepo) GetUserEpicScore(_ context.Context, _, _ uuid.UUID) (*domain.EpicScore, error) {
+	if r.epicScore == nil {
+		return nil, repositories.ErrNotFound
+	}
+	return r.epicScore, nil
+}
+
+func (r *fakeRepo) GetUserRiskScore(_ context.Context, _, _ uuid.UUID) (*domain.RiskScore, error) {
+	if r.riskScore == nil {
+		return nil, repositories.ErrNotFound
+	}
+	return r.riskScore, nil
+}
+
+func (r *fakeRepo) GetAllEpics(context.Context) ([]domain.Epic, error) {
+	return r.epics, nil
+}
+
+func (r *fakeRepo) GetEpicsByTeamID(_ context.Context, teamID uuid.UUID) ([]domain.Epic, error) {
+	var epics []domain.Epic
+	for _, e := range r.epics {
+		if e.TeamID == teamID {
+			epics = append(epics, e)
+		}
+	}
+	return epics, nil
+}
+
+func (r *fakeRepo) GetTeamByName(_ context.Context, name string) (*domain.Team, error) {
+	if r.team == nil || r.team.Name != name {
+		return nil, repositories.ErrNotFound
+	}
+	return r.team, nil
+}
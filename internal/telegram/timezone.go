@@ -0,0 +1,91 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// timeLayout is how timestamps are shown in chat messages.
+const timeLayout = "02.01.2006 15:04 MST"
+
+// formatTime renders a timestamp in the given timezone.
+func formatTime(ts time.Time, loc *time.Location) string {
+	return ts.In(loc).Format(timeLayout)
+}
+
+// teamLocation returns the timezone configured for a team, or UTC when the
+// team cannot be loaded.
+func (epicBot *Bot) teamLocation(ctx context.Context, teamID uuid.UUID) *time.Location {
+	team, err := epicBot.repo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return time.UTC
+	}
+	return team.Location()
+}
+
+// loadTimezone loads an IANA timezone known to the runtime. "Local" is
+// rejected because it depends on the server.
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return time.LoadLocation(name)
+}
+
+// ─── /settimezone ─────────────────────────────────────────────────────────
+
+// handleSetTimezone sets the timezone used to display a team's timestamps.
+// Usage: /settimezone <team> <IANA zone>
+func (epicBot *Bot) handleSetTimezone(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleSetTimezone"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+
+	// Team names may contain spaces; zone names never do.
+	args := strings.TrimSpace(commandArguments(msg))
+	i := strings.LastIndexAny(args, " \t")
+	if i < 0 {
+		_, err := epicBot.sendReply(ctx, msg,
+			"⚠️ Использование: /settimezone <команда> <часовой пояс>\nНапример: /settimezone Платформа Europe/Moscow")
+		return err
+	}
+	teamName, zone := strings.TrimSpace(args[:i]), args[i+1:]
+
+	loc, err := loadTimezone(zone)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg,
+			fmt.Sprintf("❌ Неизвестный часовой пояс «%s». Используйте имя из базы IANA, например Europe/Moscow.", zone))
+		return retErr
+	}
+	team, err := epicBot.repo.GetTeamByName(ctx, teamName)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.lookupErrorText(ctx, msg, err, "team.name_not_found", teamName))
+		return retErr
+	}
+	if err := epicBot.repo.SetTeamTimezone(ctx, team.ID, zone); err != nil {
+		log.Error("error setting team timezone", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, "❌ Ошибка сохранения часового пояса.")
+		return retErr
+	}
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionTimezoneChanged,
+		fmt.Sprintf("%s: %s", team.Name, zone))
+	_, err = epicBot.sendReply(ctx, msg,
+		fmt.Sprintf("✅ Часовой пояс команды «%s»: %s (сейчас %s).",
+			team.Name, zone, formatTime(time.Now(), loc)))
+	return err
+}
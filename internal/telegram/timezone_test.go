@@ -0,0 +1,50 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"EpicScoreBot/internal/config"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    string
+		wantErr bool
+	}{
+		{"IANA name", "Europe/Moscow", false},
+		{"UTC", "UTC", false},
+		{"empty", "", true},
+		{"server zone", "Local", true},
+		{"unknown", "Mars/Olympus", true},
+		{"offset", "+03:00", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc, err := loadTimezone(tt.zone)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("loadTimezone(%q) error = %v, wantErr %v", tt.zone, err, tt.wantErr)
+			}
+			if err == nil && loc.String() != tt.zone {
+				t.Errorf("loadTimezone(%q) = %s", tt.zone, loc)
+			}
+		})
+	}
+}
+
+func TestSetTimezoneRejectsUnknownZone(t *testing.T) {
+	cfg := &config.Config{BotConfig: config.BotConfig{Admins: []string{"ann"}}}
+	// The fake has no teams: the zone must be rejected before any lookup.
+	epicBot, api := newTestBot(t, cfg, &fakeRepo{})
+
+	if err := epicBot.handleSetTimezone(context.Background(), testCommand("/settimezone Платформа Mars/Olympus")); err != nil {
+		t.Fatalf("handleSetTimezone() error = %v", err)
+	}
+
+	texts := api.Texts()
+	if len(texts) != 1 || !strings.Contains(texts[0], "Mars/Olympus") || !strings.Contains(texts[0], "Europe/Moscow") {
+		t.Errorf("replies = %q, want the unknown zone and an example", texts)
+	}
+}
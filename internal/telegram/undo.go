@@ -0,0 +1,109 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"EpicScoreBot/internal/audit"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// undoDepth is how many reversible actions are remembered per chat and actor.
+const undoDepth = 10
+
+// undoKey identifies whose actions an undo entry belongs to.
+type undoKey struct {
+	ChatID int64
+	Actor  string
+}
+
+// undoEntry is a reversible admin action together with its inverse.
+type undoEntry struct {
+	Description string
+	Revert      func(ctx context.Context) error
+}
+
+// undoStore keeps the most recent reversible actions in memory, newest last.
+// Entries are lost on restart.
+type undoStore struct {
+	mu   sync.Mutex
+	data map[undoKey][]undoEntry
+}
+
+func newUndoStore() *undoStore {
+	return &undoStore{data: make(map[undoKey][]undoEntry)}
+}
+
+func (s *undoStore) push(key undoKey, entry undoEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := append(s.data[key], entry)
+	if len(entries) > undoDepth {
+		entries = entries[len(entries)-undoDepth:]
+	}
+	s.data[key] = entries
+}
+
+func (s *undoStore) pop(key undoKey) (undoEntry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := s.data[key]
+	if len(entries) == 0 {
+		return undoEntry{}, false
+	}
+	entry := entries[len(entries)-1]
+	if len(entries) == 1 {
+		delete(s.data, key)
+	} else {
+		s.data[key] = entries[:len(entries)-1]
+	}
+	return entry, true
+}
+
+// rememberUndo registers the inverse of an action the actor just performed
+// in the chat.
+func (epicBot *Bot) rememberUndo(chatID int64, actor, description string, revert func(ctx context.Context) error) {
+	epicBot.undo.push(undoKey{ChatID: chatID, Actor: actor}, undoEntry{
+		Description: description,
+		Revert:      revert,
+	})
+}
+
+// ─── /undo ────────────────────────────────────────────────────────────────
+
+// handleUndo reverts the caller's most recent reversible action in the chat.
+func (epicBot *Bot) handleUndo(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleUndo"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isSuperAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.superadmin_only"))
+		return err
+	}
+
+	entry, ok := epicBot.undo.pop(undoKey{ChatID: msg.Chat.ID, Actor: msg.From.Username})
+	if !ok {
+		_, err := epicBot.sendReply(ctx, msg, "ℹ️ Нечего отменять.")
+		return err
+	}
+	if err := entry.Revert(ctx); err != nil {
+		log.Error("error reverting action", slog.String("action", entry.Description), sl.Err(err))
+		text := fmt.Sprintf("❌ Не удалось отменить «%s».", entry.Description)
+		if errors.Is(err, repositories.ErrAlreadyExists) {
+			text = fmt.Sprintf("❌ Не удалось отменить «%s»: запись уже создана заново.", entry.Description)
+		}
+		_, retErr := epicBot.sendReply(ctx, msg, text)
+		return retErr
+	}
+	epicBot.recordAudit(ctx, msg.From.Username, audit.ActionUndone, entry.Description)
+	_, err := epicBot.sendReply(ctx, msg, fmt.Sprintf("↩️ Отменено: %s", entry.Description))
+	return err
+}
@@ -0,0 +1,101 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ─── /viewas ──────────────────────────────────────────────────────────────
+
+// handleViewAs shows what a user sees in /score — their role, weight, teams
+// and outstanding epics and risks — as a read-only support view.
+// Usage: /viewas @username
+func (epicBot *Bot) handleViewAs(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleViewAs"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	if !epicBot.isAdmin(fromMessage(msg)) {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "access.admin_only"))
+		return err
+	}
+	username := strings.TrimPrefix(strings.TrimSpace(commandArguments(msg)), "@")
+	if username == "" || strings.ContainsAny(username, " \t\n") {
+		_, err := epicBot.sendReply(ctx, msg, "⚠️ Использование: /viewas @username")
+		return err
+	}
+
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			_, retErr := epicBot.sendReply(ctx, msg,
+				fmt.Sprintf("❌ Пользователь @%s не зарегистрирован.", username))
+			return retErr
+		}
+		log.Error("error finding user", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal"))
+		return retErr
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "👁 Просмотр от имени @%s (только чтение)\n\n", user.TelegramID)
+	fmt.Fprintf(&sb, "👤 %s %s\n", user.FirstName, user.LastName)
+	roleName := "— (не назначена, оценивать эпики нельзя)"
+	if role, err := epicBot.repo.GetRoleByUserID(ctx, user.ID); err == nil {
+		roleName = role.Name
+	}
+	fmt.Fprintf(&sb, "Роль: %s\n", roleName)
+	fmt.Fprintf(&sb, "Вес: %d\n", user.Weight)
+
+	teams, err := epicBot.repo.GetTeamsByUserTelegramID(ctx, user.TelegramID)
+	if err != nil {
+		log.Error("error getting user teams", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal"))
+		return retErr
+	}
+	if len(teams) == 0 {
+		sb.WriteString("\n❌ Не состоит ни в одной команде — /score покажет ошибку.")
+		_, err := epicBot.sendReply(ctx, msg, sb.String())
+		return err
+	}
+
+	for _, team := range teams {
+		fmt.Fprintf(&sb, "\n👥 %s\n", team.Name)
+		epics, err := epicBot.repo.GetUnscoredEpicsByUser(ctx, user.ID, team.ID)
+		if err != nil {
+			log.Error("error getting unscored epics", sl.Err(err))
+			sb.WriteString("  ❌ Ошибка получения эпиков\n")
+			continue
+		}
+		if len(epics) == 0 {
+			sb.WriteString("  ✅ Нет эпиков, ожидающих оценки\n")
+			continue
+		}
+		for _, epic := range epics {
+			fmt.Fprintf(&sb, "  📝 #%s %s\n", epic.Number, epic.Name)
+			scored, err := epicBot.repo.HasUserScoredEpic(ctx, epic.ID, user.ID)
+			if err == nil && !scored {
+				// Users outside the epic's required roles do not score effort.
+				if scorer, err := epicBot.repo.IsUserEpicScorer(ctx, epic.ID, user.ID); err == nil && scorer {
+					sb.WriteString("    • трудоёмкость не оценена\n")
+				}
+			}
+			risks, err := epicBot.repo.GetUnscoredRisksByUser(ctx, user.ID, epic.ID)
+			if err == nil && len(risks) > 0 {
+				fmt.Fprintf(&sb, "    • неоценённых рисков: %d\n", len(risks))
+			}
+		}
+	}
+
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}
@@ -0,0 +1,139 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"EpicScoreBot/internal/models/domain"
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+)
+
+// ─── /watchepic, /unwatchepic ─────────────────────────────────────────────
+
+// handleWatchEpic subscribes the chat to the final score of an epic. The
+// chat is told once, when the epic is finalized. Usage: /watchepic <number>
+func (epicBot *Bot) handleWatchEpic(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleWatchEpic"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	number := strings.TrimPrefix(strings.TrimSpace(commandArguments(msg)), "#")
+	if number == "" {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "watch.usage"))
+		return err
+	}
+
+	epic, err := epicBot.repo.GetEpicByNumber(ctx, number)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.epicNumberErrorText(ctx, msg, err, number))
+		return retErr
+	}
+	if epic.Status == domain.StatusScored {
+		text := epicBot.t(ctx, msg, "watch.already_scored", epic.Number)
+		if epic.FinalScore != nil {
+			text += "\n" + epicBot.t(ctx, msg, "epic.final_score", strconv.FormatFloat(*epic.FinalScore, 'f', -1, 64))
+		}
+		_, err := epicBot.sendReply(ctx, msg, text)
+		return err
+	}
+
+	added, err := epicBot.repo.AddEpicWatcher(ctx, domain.EpicWatcher{
+		EpicID:   epic.ID,
+		ChatID:   msg.Chat.ID,
+		ThreadID: msg.MessageThreadID,
+	})
+	if err != nil {
+		log.Error("error adding epic watcher", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "watch.error"))
+		return retErr
+	}
+	if !added {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "watch.already", epic.Number))
+		return err
+	}
+	_, err = epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "watch.added", epic.Number, epic.Name, epic.Number))
+	return err
+}
+
+// handleUnwatchEpic cancels a /watchepic subscription of the chat.
+// Usage: /unwatchepic <number>
+func (epicBot *Bot) handleUnwatchEpic(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleUnwatchEpic"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	number := strings.TrimPrefix(strings.TrimSpace(commandArguments(msg)), "#")
+	if number == "" {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "watch.unwatch_usage"))
+		return err
+	}
+
+	epic, err := epicBot.repo.GetEpicByNumber(ctx, number)
+	if err != nil {
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.epicNumberErrorText(ctx, msg, err, number))
+		return retErr
+	}
+	err = epicBot.repo.RemoveEpicWatcher(ctx, domain.EpicWatcher{
+		EpicID:   epic.ID,
+		ChatID:   msg.Chat.ID,
+		ThreadID: msg.MessageThreadID,
+	})
+	switch {
+	case errors.Is(err, repositories.ErrNotFound):
+		_, err = epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "watch.not_watching", epic.Number))
+		return err
+	case err != nil:
+		log.Error("error removing epic watcher", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "watch.unwatch_error"))
+		return retErr
+	}
+	_, err = epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "watch.removed", epic.Number))
+	return err
+}
+
+// NotifyEpicWatchers tells every chat watching an epic its final score.
+// The subscriptions are removed first, so a chat is told only once.
+func (epicBot *Bot) NotifyEpicWatchers(ctx context.Context, epicID uuid.UUID) {
+	log := epicBot.log.With(
+		slog.String("op", "bot.NotifyEpicWatchers"),
+		slog.String("epicID", epicID.String()),
+	)
+	watchers, err := epicBot.repo.TakeEpicWatchers(ctx, epicID)
+	if err != nil {
+		log.Error("failed to get epic watchers", sl.Err(err))
+		return
+	}
+	if len(watchers) == 0 {
+		return
+	}
+	epic, err := epicBot.repo.GetEpicByID(ctx, epicID)
+	if err != nil {
+		log.Error("failed to load scored epic", sl.Err(err))
+		return
+	}
+
+	for _, w := range watchers {
+		msg := &models.Message{
+			Chat:            models.Chat{ID: w.ChatID},
+			MessageThreadID: w.ThreadID,
+		}
+		// Each chat is told in its own language.
+		text := epicBot.t(ctx, msg, "watch.scored", epic.Number, epic.Name)
+		if epic.FinalScore != nil {
+			text += "\n" + epicBot.t(ctx, msg, "epic.final_score", strconv.FormatFloat(*epic.FinalScore, 'f', -1, 64))
+		}
+		if _, err := epicBot.sendReply(ctx, msg, text); err != nil {
+			log.Error("failed to notify epic watcher",
+				slog.Int64("chat_id", w.ChatID), sl.Err(err))
+		}
+	}
+}
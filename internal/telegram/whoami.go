@@ -0,0 +1,82 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"EpicScoreBot/internal/repositories"
+	"EpicScoreBot/internal/utils/logger/sl"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// ─── /whoami ──────────────────────────────────────────────────────────────
+
+// handleWhoAmI shows the caller's registration: name, weight, role, teams
+// and admin status.
+func (epicBot *Bot) handleWhoAmI(ctx context.Context, msg *models.Message) error {
+	op := "bot.handleWhoAmI"
+	log := epicBot.log.With(
+		slog.String("op", op),
+		slog.Int64("chat_id", msg.Chat.ID),
+	)
+	username := msg.From.Username
+	if username == "" {
+		_, err := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "user.no_username"))
+		return err
+	}
+
+	user, err := epicBot.repo.FindUserByTelegramID(ctx, username)
+	if err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "whoami.not_registered", username))
+			return retErr
+		}
+		log.Error("error finding user", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal"))
+		return retErr
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "👤 %s %s (@%s)\n", user.FirstName, user.LastName, user.TelegramID)
+	fmt.Fprintln(&sb, epicBot.t(ctx, msg, "whoami.weight", user.Weight))
+
+	roleName := epicBot.t(ctx, msg, "whoami.no_role")
+	role, err := epicBot.repo.GetRoleByUserID(ctx, user.ID)
+	switch {
+	case err == nil:
+		roleName = role.Name
+	case !errors.Is(err, repositories.ErrNotFound):
+		log.Error("error getting user role", sl.Err(err))
+	}
+	fmt.Fprintln(&sb, epicBot.t(ctx, msg, "whoami.role", roleName))
+
+	teams, err := epicBot.repo.GetTeamsByUserTelegramID(ctx, user.TelegramID)
+	if err != nil {
+		log.Error("error getting user teams", sl.Err(err))
+		_, retErr := epicBot.sendReply(ctx, msg, epicBot.t(ctx, msg, "error.internal"))
+		return retErr
+	}
+	if len(teams) == 0 {
+		fmt.Fprintln(&sb, epicBot.t(ctx, msg, "whoami.no_teams"))
+	} else {
+		names := make([]string, 0, len(teams))
+		for _, team := range teams {
+			names = append(names, team.Name)
+		}
+		fmt.Fprintln(&sb, epicBot.t(ctx, msg, "whoami.teams", strings.Join(names, ", ")))
+	}
+
+	switch {
+	case epicBot.isSuperAdmin(fromMessage(msg)):
+		fmt.Fprintln(&sb, epicBot.t(ctx, msg, "whoami.superadmin"))
+	case epicBot.isAdmin(fromMessage(msg)):
+		fmt.Fprintln(&sb, epicBot.t(ctx, msg, "whoami.admin"))
+	}
+
+	_, err = epicBot.sendReply(ctx, msg, sb.String())
+	return err
+}